@@ -7,8 +7,11 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/lumina-tech/gooq/pkg/database"
 	"github.com/lumina-tech/gooq/pkg/generator"
+	"github.com/lumina-tech/gooq/pkg/generator/metadata"
+	"github.com/lumina-tech/gooq/pkg/generator/plugin"
 	"github.com/lumina-tech/gooq/pkg/generator/plugin/enumgen"
 	"github.com/lumina-tech/gooq/pkg/generator/plugin/modelgen"
+	"github.com/lumina-tech/gooq/pkg/generator/plugin/routinegen"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -62,15 +65,43 @@ func loadConfig() error {
 func generateModelsForDB(
 	db *sqlx.DB, config *database.DatabaseConfig,
 ) {
-	enumOutputFile := fmt.Sprintf("%s/%s_enum.generated.go", config.ModelPath, config.DatabaseName)
-	modelOutputFile := fmt.Sprintf("%s/%s_model.generated.go", config.ModelPath, config.DatabaseName)
-	tableOutputFile := fmt.Sprintf("%s/%s_table.generated.go", config.TablePath, config.DatabaseName)
-	err := generator.NewGenerator(
-		enumgen.NewEnumGenerator(enumOutputFile),
-		modelgen.NewModelGenerator(modelOutputFile, "table", "model", &config.ModelOverrides),
-		modelgen.NewTableGenerator(tableOutputFile, "table", "model", nil),
-	).Run(db)
-	if err != nil {
+	var gen *generator.Generator
+	if config.PackagePerSchema {
+		gen = generator.NewPackagePerSchemaGenerator(func(schema string) []plugin.Plugin {
+			// e.g. the models of the schema billing are in model/billing with
+			// the package billing and its tables in table/billing with the
+			// package billingtable
+			modelPackage := metadata.SchemaPackage(schema)
+			tablePackage := modelPackage + "table"
+			modelPath := fmt.Sprintf("%s/%s", config.ModelPath, modelPackage)
+			tablePath := fmt.Sprintf("%s/%s", config.TablePath, modelPackage)
+			return []plugin.Plugin{
+				enumgen.NewPackageEnumGenerator(
+					fmt.Sprintf("%s/%s_enum.generated.go", modelPath, config.DatabaseName), modelPackage),
+				modelgen.NewModelGenerator(
+					fmt.Sprintf("%s/%s_model.generated.go", modelPath, config.DatabaseName),
+					tablePackage, modelPackage, &config.ModelOverrides),
+				modelgen.NewTableGenerator(
+					fmt.Sprintf("%s/%s_table.generated.go", tablePath, config.DatabaseName),
+					tablePackage, modelPackage, nil),
+				routinegen.NewRoutineGenerator(
+					fmt.Sprintf("%s/%s_routine.generated.go", tablePath, config.DatabaseName),
+					tablePackage),
+			}
+		})
+	} else {
+		enumOutputFile := fmt.Sprintf("%s/%s_enum.generated.go", config.ModelPath, config.DatabaseName)
+		modelOutputFile := fmt.Sprintf("%s/%s_model.generated.go", config.ModelPath, config.DatabaseName)
+		tableOutputFile := fmt.Sprintf("%s/%s_table.generated.go", config.TablePath, config.DatabaseName)
+		routineOutputFile := fmt.Sprintf("%s/%s_routine.generated.go", config.TablePath, config.DatabaseName)
+		gen = generator.NewGenerator(
+			enumgen.NewEnumGenerator(enumOutputFile),
+			modelgen.NewModelGenerator(modelOutputFile, "table", "model", &config.ModelOverrides),
+			modelgen.NewTableGenerator(tableOutputFile, "table", "model", nil),
+			routinegen.NewRoutineGenerator(routineOutputFile, "table"),
+		)
+	}
+	if err := gen.WithSchemas(config.Schemas...).Run(db); err != nil {
 		_, _ = fmt.Fprint(os.Stderr, "cannot generate code:", err)
 		os.Exit(1)
 	}
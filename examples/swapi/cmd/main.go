@@ -129,8 +129,7 @@ func main() {
 		selection = append(selection,
 			getColumnsWithPrefix("species", table.Species.GetColumns())...)
 		stmt := gooq.Select(selection...).From(table.Person).
-			Join(table.Species).
-			On(table.Person.SpeciesID.Eq(table.Species.ID))
+			JoinRelationship(table.Person.JoinSpecies())
 
 		builder := &gooq.Builder{}
 		stmt.Render(builder)
@@ -144,6 +143,19 @@ func main() {
 		bytes, _ := json.Marshal(results)
 		fmt.Println(string(bytes))
 	}
+
+	// views are read-only relations that scan into their own model
+	{
+		stmt := gooq.Select().From(table.PersonSpecies)
+
+		results, err := table.PersonSpecies.ScanRowsWithContext(ctx, dockerDB.DB, stmt)
+		if err != nil {
+			fmt.Fprint(os.Stderr, err.Error())
+			return
+		}
+		bytes, _ := json.Marshal(results)
+		fmt.Println(string(bytes))
+	}
 }
 
 func getColumnsWithPrefix(
@@ -0,0 +1,21 @@
+-- example to show read-only views
+CREATE VIEW person_species AS
+  SELECT person.id, person.name, species.name AS species_name, species.classification
+  FROM person
+  JOIN species ON species.id = person.species_id;
+
+-- example to show scalar functions
+CREATE FUNCTION count_people(species_id uuid) RETURNS bigint AS $$
+  SELECT count(*) FROM person WHERE person.species_id = count_people.species_id
+$$ LANGUAGE sql STABLE;
+
+-- example to show table functions
+CREATE FUNCTION people_taller_than(min_height numeric)
+RETURNS TABLE(id uuid, name text, height numeric) AS $$
+  SELECT person.id, person.name, person.height FROM person WHERE person.height > min_height
+$$ LANGUAGE sql STABLE;
+
+-- example to show procedures
+CREATE PROCEDURE arm_person(person_id uuid, weapon_id uuid) AS $$
+  UPDATE person SET weapon_id = arm_person.weapon_id WHERE person.id = arm_person.person_id
+$$ LANGUAGE sql;
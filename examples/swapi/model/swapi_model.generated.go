@@ -5,6 +5,7 @@ package model
 import (
 	"github.com/google/uuid"
 	"github.com/lumina-tech/gooq/pkg/nullable"
+	"gopkg.in/guregu/null.v3"
 )
 
 type ColorReferenceTable struct {
@@ -27,6 +28,13 @@ type Person struct {
 	Status    string        `db:"status" json:"status"`
 }
 
+type PersonSpecies struct {
+	ID             nullable.UUID `db:"id" json:"id"`
+	Name           null.String   `db:"name" json:"name"`
+	SpeciesName    null.String   `db:"species_name" json:"species_name"`
+	Classification null.String   `db:"classification" json:"classification"`
+}
+
 type Species struct {
 	ID              uuid.UUID         `db:"id" json:"id"`
 	Name            string            `db:"name" json:"name"`
@@ -0,0 +1,53 @@
+// THIS FILE WAS AUTOGENERATED - ANY EDITS TO THIS WILL BE LOST WHEN IT IS REGENERATED
+
+package table
+
+import "github.com/lumina-tech/gooq/pkg/gooq"
+
+func CountPeople(
+	speciesID gooq.UUIDExpression,
+) gooq.NumericExpression {
+	return gooq.NewNumericExpressionFunction("public.count_people", speciesID)
+}
+
+type peopleTallerThanFunction struct {
+	gooq.TableFunctionImpl
+	ID     gooq.UUIDField
+	Name   gooq.StringField
+	Height gooq.DecimalField
+}
+
+func newPeopleTallerThanFunction(
+	impl gooq.TableFunctionImpl,
+) *peopleTallerThanFunction {
+	instance := &peopleTallerThanFunction{TableFunctionImpl: impl}
+	instance.ID = gooq.NewUUIDField(instance, "id")
+	instance.Name = gooq.NewStringField(instance, "name")
+	instance.Height = gooq.NewDecimalField(instance, "height")
+	return instance
+}
+
+func PeopleTallerThan(
+	minHeight gooq.NumericExpression,
+) *peopleTallerThanFunction {
+	return newPeopleTallerThanFunction(*gooq.NewTableFunction("public", "people_taller_than", minHeight))
+}
+
+func (t *peopleTallerThanFunction) As(alias string) *peopleTallerThanFunction {
+	return newPeopleTallerThanFunction(*t.TableFunctionImpl.As(alias))
+}
+
+func (t *peopleTallerThanFunction) GetColumns() []gooq.Expression {
+	return []gooq.Expression{
+		t.ID,
+		t.Name,
+		t.Height,
+	}
+}
+
+func ArmPerson(
+	personID gooq.UUIDExpression,
+	weaponID gooq.UUIDExpression,
+) gooq.CallFinalStep {
+	return gooq.Call("public.arm_person", personID, weaponID)
+}
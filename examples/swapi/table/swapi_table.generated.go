@@ -5,6 +5,7 @@ package table
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/lumina-tech/gooq/examples/swapi/model"
 	"github.com/lumina-tech/gooq/pkg/gooq"
 	"gopkg.in/guregu/null.v3"
@@ -37,7 +38,7 @@ func newColorReferenceTableConstraints(
 
 func newColorReferenceTable() *colorReferenceTable {
 	instance := &colorReferenceTable{}
-	instance.Initialize("public", "color_reference_table")
+	instance.TableImpl.Initialize("public", "color_reference_table")
 	instance.Asterisk = gooq.NewStringField(instance, "*")
 	instance.Value = gooq.NewStringField(instance, "value")
 	instance.Constraints = newColorReferenceTableConstraints(instance)
@@ -144,7 +145,7 @@ func newPersonConstraints(
 
 func newPerson() *person {
 	instance := &person{}
-	instance.Initialize("public", "person")
+	instance.TableImpl.Initialize("public", "person")
 	instance.Asterisk = gooq.NewStringField(instance, "*")
 	instance.ID = gooq.NewUUIDField(instance, "id")
 	instance.Name = gooq.NewStringField(instance, "name")
@@ -229,6 +230,267 @@ func (t *person) ScanRowsWithContext(
 
 var Person = newPerson()
 
+// JoinSpecies joins the species referenced by person_species_id_fkey, e.g.
+// gooq.Select().From(Person).JoinRelationship(Person.JoinSpecies())
+func (t *person) JoinSpecies() gooq.Relationship {
+	referenced := Species
+	return gooq.ForeignKey{
+		Name:  "person_species_id_fkey",
+		Table: t,
+		Columns: []gooq.Field{
+			t.SpeciesID,
+		},
+		ReferencedTable: referenced,
+		ReferencedColumns: []gooq.Field{
+			referenced.ID,
+		},
+	}.Join()
+}
+
+// LoadPersonsForSpecies loads the person rows that reference keys
+// by person_species_id_fkey with a single query, grouped by key
+func LoadPersonsForSpecies(
+	ctx context.Context, db gooq.DBInterface, keys []uuid.UUID,
+) (map[uuid.UUID][]model.Person, error) {
+	results := make(map[uuid.UUID][]model.Person, len(keys))
+	if len(keys) == 0 {
+		return results, nil
+	}
+	rows, err := Person.ScanRowsWithContext(ctx, db, gooq.Select().From(Person).
+		Where(Person.SpeciesID.Eq(gooq.UUIDArray(keys...).Any())))
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		key := uuid.UUID(row.SpeciesID)
+		results[key] = append(results[key], row)
+	}
+	return results, nil
+}
+
+// LoadSpeciesForPersons loads the species rows that rows reference
+// by person_species_id_fkey with a single query, by key
+func LoadSpeciesForPersons(
+	ctx context.Context, db gooq.DBInterface, rows []model.Person,
+) (map[uuid.UUID]model.Species, error) {
+	keys := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		keys = append(keys, uuid.UUID(row.SpeciesID))
+	}
+	results := make(map[uuid.UUID]model.Species, len(keys))
+	if len(keys) == 0 {
+		return results, nil
+	}
+	referenced, err := Species.ScanRowsWithContext(ctx, db, gooq.Select().From(Species).
+		Where(Species.ID.Eq(gooq.UUIDArray(keys...).Any())))
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range referenced {
+		results[row.ID] = row
+	}
+	return results, nil
+}
+
+// JoinWeapon joins the weapon referenced by person_weapon_id_fkey, e.g.
+// gooq.Select().From(Person).JoinRelationship(Person.JoinWeapon())
+func (t *person) JoinWeapon() gooq.Relationship {
+	referenced := Weapon
+	return gooq.ForeignKey{
+		Name:  "person_weapon_id_fkey",
+		Table: t,
+		Columns: []gooq.Field{
+			t.WeaponID,
+		},
+		ReferencedTable: referenced,
+		ReferencedColumns: []gooq.Field{
+			referenced.ID,
+		},
+	}.Join()
+}
+
+// LoadPersonsForWeapon loads the person rows that reference keys
+// by person_weapon_id_fkey with a single query, grouped by key
+func LoadPersonsForWeapon(
+	ctx context.Context, db gooq.DBInterface, keys []uuid.UUID,
+) (map[uuid.UUID][]model.Person, error) {
+	results := make(map[uuid.UUID][]model.Person, len(keys))
+	if len(keys) == 0 {
+		return results, nil
+	}
+	rows, err := Person.ScanRowsWithContext(ctx, db, gooq.Select().From(Person).
+		Where(Person.WeaponID.Eq(gooq.UUIDArray(keys...).Any())))
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		key := uuid.UUID(row.WeaponID.UUID)
+		results[key] = append(results[key], row)
+	}
+	return results, nil
+}
+
+// LoadWeaponForPersons loads the weapon rows that rows reference
+// by person_weapon_id_fkey with a single query, by key
+func LoadWeaponForPersons(
+	ctx context.Context, db gooq.DBInterface, rows []model.Person,
+) (map[uuid.UUID]model.Weapon, error) {
+	keys := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		if !row.WeaponID.Valid {
+			continue
+		}
+		keys = append(keys, uuid.UUID(row.WeaponID.UUID))
+	}
+	results := make(map[uuid.UUID]model.Weapon, len(keys))
+	if len(keys) == 0 {
+		return results, nil
+	}
+	referenced, err := Weapon.ScanRowsWithContext(ctx, db, gooq.Select().From(Weapon).
+		Where(Weapon.ID.Eq(gooq.UUIDArray(keys...).Any())))
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range referenced {
+		results[row.ID] = row
+	}
+	return results, nil
+}
+
+// PersonWithReferences is a person row together with the rows
+// that it references
+type PersonWithReferences struct {
+	model.Person
+	Species *model.Species `json:"species,omitempty"`
+	Weapon  *model.Weapon  `json:"weapon,omitempty"`
+}
+
+// LoadPersonReferences loads the rows that rows reference with a query per
+// foreign key rather than per row
+func LoadPersonReferences(
+	ctx context.Context, db gooq.DBInterface, rows []model.Person,
+) ([]PersonWithReferences, error) {
+	results := make([]PersonWithReferences, len(rows))
+	for index := range rows {
+		results[index].Person = rows[index]
+	}
+	referencedSpecies, err := LoadSpeciesForPersons(ctx, db, rows)
+	if err != nil {
+		return nil, err
+	}
+	for index := range results {
+		result := &results[index]
+		if row, ok := referencedSpecies[uuid.UUID(result.SpeciesID)]; ok {
+			result.Species = &row
+		}
+	}
+	referencedWeapon, err := LoadWeaponForPersons(ctx, db, rows)
+	if err != nil {
+		return nil, err
+	}
+	for index := range results {
+		result := &results[index]
+		if !result.WeaponID.Valid {
+			continue
+		}
+		if row, ok := referencedWeapon[uuid.UUID(result.WeaponID.UUID)]; ok {
+			result.Weapon = &row
+		}
+	}
+	return results, nil
+}
+
+type personSpeciesConstraints struct {
+}
+
+// personSpecies is a view, it cannot be modified
+type personSpecies struct {
+	gooq.ViewImpl
+	Asterisk       gooq.StringField
+	ID             gooq.UUIDField
+	Name           gooq.StringField
+	SpeciesName    gooq.StringField
+	Classification gooq.StringField
+
+	Constraints *personSpeciesConstraints
+}
+
+func newPersonSpeciesConstraints(
+	instance *personSpecies,
+) *personSpeciesConstraints {
+	constraints := &personSpeciesConstraints{}
+	return constraints
+}
+
+func newPersonSpecies() *personSpecies {
+	instance := &personSpecies{}
+	instance.ViewImpl.Initialize("public", "person_species")
+	instance.Asterisk = gooq.NewStringField(instance, "*")
+	instance.ID = gooq.NewUUIDField(instance, "id")
+	instance.Name = gooq.NewStringField(instance, "name")
+	instance.SpeciesName = gooq.NewStringField(instance, "species_name")
+	instance.Classification = gooq.NewStringField(instance, "classification")
+	instance.Constraints = newPersonSpeciesConstraints(instance)
+	return instance
+}
+
+func (t *personSpecies) As(alias string) *personSpecies {
+	instance := newPersonSpecies()
+	instance.ViewImpl = *instance.ViewImpl.As(alias)
+	return instance
+}
+
+func (t *personSpecies) GetColumns() []gooq.Expression {
+	return []gooq.Expression{
+		t.ID,
+		t.Name,
+		t.SpeciesName,
+		t.Classification,
+	}
+}
+
+func (t *personSpecies) ScanRow(
+	db gooq.DBInterface, stmt gooq.Fetchable,
+) (*model.PersonSpecies, error) {
+	result := model.PersonSpecies{}
+	if err := gooq.ScanRow(db, stmt, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (t *personSpecies) ScanRows(
+	db gooq.DBInterface, stmt gooq.Fetchable,
+) ([]model.PersonSpecies, error) {
+	results := []model.PersonSpecies{}
+	if err := gooq.ScanRows(db, stmt, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (t *personSpecies) ScanRowWithContext(
+	ctx context.Context, db gooq.DBInterface, stmt gooq.Fetchable,
+) (*model.PersonSpecies, error) {
+	result := model.PersonSpecies{}
+	if err := gooq.ScanRowWithContext(ctx, db, stmt, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (t *personSpecies) ScanRowsWithContext(
+	ctx context.Context, db gooq.DBInterface, stmt gooq.Fetchable,
+) ([]model.PersonSpecies, error) {
+	results := []model.PersonSpecies{}
+	if err := gooq.ScanRowsWithContext(ctx, db, stmt, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+var PersonSpecies = newPersonSpecies()
+
 type speciesConstraints struct {
 	SpeciesPkey                 gooq.DatabaseConstraint
 	SpeciesUniquenessConstraint gooq.DatabaseConstraint
@@ -272,7 +534,7 @@ func newSpeciesConstraints(
 
 func newSpecies() *species {
 	instance := &species{}
-	instance.Initialize("public", "species")
+	instance.TableImpl.Initialize("public", "species")
 	instance.Asterisk = gooq.NewStringField(instance, "*")
 	instance.ID = gooq.NewUUIDField(instance, "id")
 	instance.Name = gooq.NewStringField(instance, "name")
@@ -380,7 +642,7 @@ func newWeaponConstraints(
 
 func newWeapon() *weapon {
 	instance := &weapon{}
-	instance.Initialize("public", "weapon")
+	instance.TableImpl.Initialize("public", "weapon")
 	instance.Asterisk = gooq.NewStringField(instance, "*")
 	instance.ID = gooq.NewUUIDField(instance, "id")
 	instance.Damage = gooq.NewIntField(instance, "damage")
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/lumina-tech/gooq/pkg/generator/metadata"
 	"github.com/lumina-tech/gooq/pkg/generator/plugin/modelgen"
 
 	"github.com/jmoiron/sqlx"
@@ -27,6 +28,12 @@ type DatabaseConfig struct {
 	ModelPath      string
 	TablePath      string
 	ModelOverrides modelgen.ModelOverride
+	// Schemas are the schemas to generate code for, public by default
+	Schemas []metadata.SchemaConfig
+	// PackagePerSchema generates the models and tables of each schema in
+	// their own package instead of a package with schema-qualified names,
+	// the tables of a schema cannot use the types of another selected schema
+	PackagePerSchema bool
 }
 
 func NewDockerizedDB(
@@ -8,24 +8,72 @@ import (
 )
 
 type Generator struct {
-	plugins []plugin.Plugin
+	plugins       []plugin.Plugin
+	schemaPlugins func(schema string) []plugin.Plugin
+	schemas       []metadata.SchemaConfig
 }
 
+// NewGenerator returns a generator that runs plugins once for all the
+// schemas, i.e. it generates a combined package
 func NewGenerator(
 	plugins ...plugin.Plugin,
 ) *Generator {
 	return &Generator{plugins: plugins}
 }
 
+// NewPackagePerSchemaGenerator returns a generator that runs the plugins
+// returned by schemaPlugins for each schema, i.e. it generates a package per
+// schema
+func NewPackagePerSchemaGenerator(
+	schemaPlugins func(schema string) []plugin.Plugin,
+) *Generator {
+	return &Generator{schemaPlugins: schemaPlugins}
+}
+
+// WithSchemas sets the schemas to generate code for, the public schema is
+// used when there are none
+func (gen *Generator) WithSchemas(
+	schemas ...metadata.SchemaConfig,
+) *Generator {
+	gen.schemas = schemas
+	return gen
+}
+
 func (gen *Generator) Run(
 	db *sqlx.DB,
 ) error {
-	loader := postgres.NewPostgresLoader()
-	data, err := metadata.NewData(db, loader)
+	return gen.run(db, postgres.NewPostgresLoader())
+}
+
+func (gen *Generator) run(
+	db *sqlx.DB, loader *metadata.Loader,
+) error {
+	data, err := metadata.NewData(db, loader, gen.schemas...)
 	if err != nil {
 		return err
 	}
-	for _, plugin := range gen.plugins {
+	if gen.schemaPlugins == nil {
+		return runPlugins(gen.plugins, data)
+	}
+	// every schema is checked before any code is generated
+	schemaData := make([]*metadata.Data, len(data.Schemas))
+	for index, schema := range data.Schemas {
+		if schemaData[index], err = data.ForSchema(schema); err != nil {
+			return err
+		}
+	}
+	for index, schema := range data.Schemas {
+		if err := runPlugins(gen.schemaPlugins(schema), schemaData[index]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runPlugins(
+	plugins []plugin.Plugin, data *metadata.Data,
+) error {
+	for _, plugin := range plugins {
 		if err := plugin.GenerateCode(data); err != nil {
 			return err
 		}
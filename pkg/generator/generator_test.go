@@ -0,0 +1,180 @@
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lumina-tech/gooq/pkg/generator/metadata"
+	"github.com/lumina-tech/gooq/pkg/generator/plugin"
+	"github.com/lumina-tech/gooq/pkg/generator/plugin/enumgen"
+	"github.com/lumina-tech/gooq/pkg/generator/plugin/modelgen"
+	"github.com/lumina-tech/gooq/pkg/generator/plugin/routinegen"
+	"github.com/lumina-tech/gooq/pkg/generator/postgres"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLoader returns a loader of the tables public.person, which uses the
+// enum type billing.currency and references billing.invoice, public.payment,
+// which only references billing.invoice, and billing.invoice
+func newTestLoader() *metadata.Loader {
+	currency := metadata.ColumnMetadata{
+		ColumnName: "currency", DataType: "USER-DEFINED",
+		UserDefinedTypeName: "currency", UserDefinedTypeSchema: "billing",
+	}
+	tables := map[string][]metadata.TableMetadata{
+		"public": {
+			{Type: metadata.TableTypeBaseTable, TableName: "person"},
+			{Type: metadata.TableTypeBaseTable, TableName: "payment"},
+		},
+		"billing": {{Type: metadata.TableTypeBaseTable, TableName: "invoice"}},
+	}
+	columns := map[string][]metadata.ColumnMetadata{
+		"person": {
+			{ColumnName: "id", DataType: "integer"},
+			{ColumnName: "invoice_id", DataType: "integer"},
+			currency,
+		},
+		"payment": {
+			{ColumnName: "id", DataType: "integer"},
+			{ColumnName: "invoice_id", DataType: "integer"},
+		},
+		"invoice": {{ColumnName: "id", DataType: "integer"}, currency},
+	}
+	postgresLoader := postgres.NewPostgresLoader()
+	return &metadata.Loader{
+		SchemaList: func(_ *sqlx.DB) ([]string, error) {
+			return []string{"billing", "public"}, nil
+		},
+		TableList: func(_ *sqlx.DB, schema string) ([]metadata.TableMetadata, error) {
+			return tables[schema], nil
+		},
+		ColumnList: func(_ *sqlx.DB, _, table string) ([]metadata.ColumnMetadata, error) {
+			return columns[table], nil
+		},
+		ConstraintList: func(_ *sqlx.DB, _, table string) ([]metadata.ConstraintMetadata, error) {
+			return []metadata.ConstraintMetadata{{
+				IndexName: table + "_pkey", IsUnique: true, IsPrimary: true, IndexKeys: `["id"]`,
+			}}, nil
+		},
+		ForeignKeyConstraintList: func(_ *sqlx.DB, _, table string) ([]metadata.ForeignKeyConstraintMetadata, error) {
+			if table == "invoice" {
+				return nil, nil
+			}
+			return []metadata.ForeignKeyConstraintMetadata{{
+				ConstraintName: table + "_invoice_id_fkey", ColumnName: "invoice_id", OrdinalPosition: 1,
+				ForeignTableSchema: "billing", ForeignTableName: "invoice", ForeignColumnName: "id",
+			}}, nil
+		},
+		CheckConstraintList: func(_ *sqlx.DB, _, _ string) ([]metadata.CheckConstraintMetadata, error) {
+			return nil, nil
+		},
+		ExclusionConstraintList: func(_ *sqlx.DB, _, _ string) ([]metadata.ExclusionConstraintMetadata, error) {
+			return nil, nil
+		},
+		EnumList: func(_ *sqlx.DB, schema string) ([]metadata.EnumMetadata, error) {
+			if schema == "billing" {
+				return []metadata.EnumMetadata{{EnumName: "currency"}}, nil
+			}
+			return nil, nil
+		},
+		EnumValueList: func(_ *sqlx.DB, _, _ string) ([]metadata.EnumValueMetadata, error) {
+			return []metadata.EnumValueMetadata{{EnumValue: "EUR"}, {EnumValue: "USD"}}, nil
+		},
+		RoutineList: func(_ *sqlx.DB, _ string) ([]metadata.RoutineMetadata, error) {
+			return nil, nil
+		},
+		GetDataType:   postgresLoader.GetDataType,
+		GetTypeByName: postgresLoader.GetTypeByName,
+	}
+}
+
+func newTestGenerator(
+	directory string, packagePerSchema bool,
+) *Generator {
+	if !packagePerSchema {
+		return NewGenerator(
+			enumgen.NewEnumGenerator(filepath.Join(directory, "model", "test_enum.generated.go")),
+			modelgen.NewModelGenerator(filepath.Join(directory, "model", "test_model.generated.go"),
+				"table", "model", nil),
+			modelgen.NewTableGenerator(filepath.Join(directory, "table", "test_table.generated.go"),
+				"table", "model", nil),
+			routinegen.NewRoutineGenerator(filepath.Join(directory, "table", "test_routine.generated.go"),
+				"table"),
+		)
+	}
+	return NewPackagePerSchemaGenerator(func(schema string) []plugin.Plugin {
+		modelPackage := metadata.SchemaPackage(schema)
+		tablePackage := modelPackage + "table"
+		modelPath := filepath.Join(directory, "model", modelPackage)
+		tablePath := filepath.Join(directory, "table", modelPackage)
+		return []plugin.Plugin{
+			enumgen.NewPackageEnumGenerator(filepath.Join(modelPath, "test_enum.generated.go"), modelPackage),
+			modelgen.NewModelGenerator(filepath.Join(modelPath, "test_model.generated.go"),
+				tablePackage, modelPackage, nil),
+			modelgen.NewTableGenerator(filepath.Join(tablePath, "test_table.generated.go"),
+				tablePackage, modelPackage, nil),
+			routinegen.NewRoutineGenerator(filepath.Join(tablePath, "test_routine.generated.go"),
+				tablePackage),
+		}
+	})
+}
+
+func TestGeneratorCrossSchemaReferences(t *testing.T) {
+	goBinary, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("the generated code cannot be compiled without go")
+	}
+	testCases := []struct {
+		name             string
+		packagePerSchema bool
+		schemas          []metadata.SchemaConfig
+		expectedError    string
+	}{
+		{
+			name:    "combined package",
+			schemas: []metadata.SchemaConfig{{Name: "public"}, {Name: "billing"}},
+		},
+		{
+			name:    "combined package with referenced schema",
+			schemas: []metadata.SchemaConfig{{Name: "public"}},
+		},
+		{
+			name:             "package per schema with referenced schema",
+			packagePerSchema: true,
+			schemas:          []metadata.SchemaConfig{{Name: "public"}},
+		},
+		{
+			name:             "package per schema with foreign key",
+			packagePerSchema: true,
+			schemas:          []metadata.SchemaConfig{{Name: "public", Include: []string{"payment"}}, {Name: "billing"}},
+		},
+		{
+			name:             "package per schema with enum type",
+			packagePerSchema: true,
+			schemas:          []metadata.SchemaConfig{{Name: "public"}, {Name: "billing"}},
+			expectedError:    "table public.person uses the type billing.currency of another schema, which a package per schema cannot reference",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// the code is generated in the module so that it can import gooq
+			directory, err := ioutil.TempDir(".", "generated")
+			require.NoError(t, err)
+			defer os.RemoveAll(directory)
+
+			gen := newTestGenerator(directory, testCase.packagePerSchema).WithSchemas(testCase.schemas...)
+			err = gen.run(nil, newTestLoader())
+			if testCase.expectedError != "" {
+				require.EqualError(t, err, testCase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			output, err := exec.Command(goBinary, "build", "./"+directory+"/...").CombinedOutput()
+			require.NoError(t, err, string(output))
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package metadata
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/jmoiron/sqlx"
@@ -10,54 +12,208 @@ const (
 	ReferenceTableSuffix = "_reference_table"
 )
 
+// Data is the metadata of the schemas that code is generated for. Schema is
+// set to the schema of the package that is generated when a package is
+// generated per schema, see ForSchema. Schemas are the selected schemas,
+// ReferencedSchemas are the schemas that are only loaded for the types that
+// the selected tables use, which are generated with the selected schemas.
 type Data struct {
 	Schema              string
+	Schemas             []string
+	ReferencedSchemas   []string
+	PackagePerSchema    bool
 	Tables              []Table
 	Enums               []Enum
 	ReferenceTableEnums []Enum
+	Routines            []Routine
 	Loader              *Loader
 }
 
 type Enum struct {
+	Schema             string
 	Name               string
 	Values             []EnumValueMetadata
 	IsReferenceTable   bool
 	ReferenceTableName string
 }
 
+// Table is a table or a view. ForeignKeyConstraints lists the column pairs of
+// its foreign keys, which are grouped by constraint in ForeignKeys.
 type Table struct {
+	Schema                string
 	Table                 TableMetadata
 	Columns               []ColumnMetadata
 	Constraints           []ConstraintMetadata
 	ForeignKeyConstraints []ForeignKeyConstraintMetadata
+	ForeignKeys           []ForeignKey
+	CheckConstraints      []CheckConstraintMetadata
+	ExclusionConstraints  []ExclusionConstraintMetadata
 }
 
+// ForeignKey is a foreign key of a table whose Columns reference the
+// ReferencedColumns of the referenced table in order, UpdateRule and
+// DeleteRule are its ForeignKeyAction* referential actions
+type ForeignKey struct {
+	Name                string
+	Columns             []string
+	ReferencedSchema    string
+	ReferencedTable     string
+	ReferencedColumns   []string
+	UpdateRule          string
+	DeleteRule          string
+	IsDeferrable        bool
+	IsInitiallyDeferred bool
+}
+
+// IsComposite is true for the foreign keys of more than one column
+func (fk *ForeignKey) IsComposite() bool {
+	return len(fk.Columns) > 1
+}
+
+// Routine is a function or a procedure. The Columns of a function are the
+// columns of its result when it returns rows, i.e. its OUT parameters or the
+// columns of the table type it returns.
+type Routine struct {
+	Schema     string
+	Routine    RoutineMetadata
+	Parameters []ParameterMetadata
+	Columns    []ColumnMetadata
+}
+
+// InputParameters returns the parameters that are passed to the routine. The
+// OUT parameters of a procedure are passed as well (as NULL), CALL requires
+// an argument for them since PostgreSQL 14.
+func (routine *Routine) InputParameters() []ParameterMetadata {
+	var parameters []ParameterMetadata
+	for _, parameter := range routine.Parameters {
+		if parameter.ParameterMode != ParameterModeOut ||
+			routine.Routine.RoutineType == RoutineTypeProcedure {
+			parameters = append(parameters, parameter)
+		}
+	}
+	return parameters
+}
+
+// ReturnsRows is true for the functions that are selected from, i.e. the
+// set-returning functions and the functions with a composite result
+func (routine *Routine) ReturnsRows() bool {
+	return routine.Routine.RoutineType == RoutineTypeFunction &&
+		(routine.Routine.ReturnsSet || len(routine.Columns) > 0)
+}
+
+// NewData loads the metadata of the schemas selected by configs, or of the
+// public schema when there are none. The enum types and reference tables of
+// other schemas that are used by the selected tables are loaded as well so
+// that their types can be generated.
 func NewData(
-	db *sqlx.DB, loader *Loader,
+	db *sqlx.DB, loader *Loader, configs ...SchemaConfig,
 ) (*Data, error) {
-	schema, err := loader.Schema()
+	if len(configs) == 0 {
+		configs = []SchemaConfig{{Name: DefaultSchema}}
+	}
+	databaseSchemas, err := loader.SchemaList(db)
 	if err != nil {
 		return nil, err
 	}
-	tables, err := getDatabaseTables(db, loader, schema)
+	schemas, schemaConfigs, err := selectSchemas(configs, databaseSchemas)
 	if err != nil {
 		return nil, err
 	}
-	dbEnums, err := getDatabaseEnums(db, loader, schema)
-	if err != nil {
+	data := &Data{Loader: loader}
+	for _, schema := range schemas {
+		if err := data.loadSchema(db, schema, schemaConfigs[schema]); err != nil {
+			return nil, err
+		}
+	}
+	if err := data.loadReferencedTypes(db); err != nil {
 		return nil, err
 	}
-	refTableEnums, err := getReferenceTableEnums(db, loader, schema)
+	return data, nil
+}
+
+func (data *Data) loadSchema(
+	db *sqlx.DB, schema string, config *SchemaConfig,
+) error {
+	tables, err := getDatabaseTables(db, data.Loader, schema, config)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return &Data{
-		Schema:              schema,
-		Tables:              tables,
-		Enums:               dbEnums,
-		ReferenceTableEnums: refTableEnums,
-		Loader:              loader,
-	}, nil
+	dbEnums, err := getDatabaseEnums(db, data.Loader, schema)
+	if err != nil {
+		return err
+	}
+	refTableEnums, err := getReferenceTableEnums(db, data.Loader, schema, tables)
+	if err != nil {
+		return err
+	}
+	routines, err := getRoutines(db, data.Loader, schema)
+	if err != nil {
+		return err
+	}
+	data.Schemas = append(data.Schemas, schema)
+	data.Tables = append(data.Tables, tables...)
+	data.Enums = append(data.Enums, dbEnums...)
+	data.ReferenceTableEnums = append(data.ReferenceTableEnums, refTableEnums...)
+	data.Routines = append(data.Routines, routines...)
+	return nil
+}
+
+// loadReferencedTypes loads the enum types and reference tables of the
+// schemas that are not selected but are used by the columns and foreign keys
+// of the selected tables
+func (data *Data) loadReferencedTypes(
+	db *sqlx.DB,
+) error {
+	loaded := make(map[string]bool)
+	for _, schema := range data.Schemas {
+		loaded[schema] = true
+	}
+	load := func(schema string) error {
+		if loaded[schema] {
+			return nil
+		}
+		loaded[schema] = true
+		enums, err := getDatabaseEnums(db, data.Loader, schema)
+		if err != nil {
+			return err
+		}
+		data.ReferencedSchemas = append(data.ReferencedSchemas, schema)
+		data.Enums = append(data.Enums, enums...)
+		return nil
+	}
+	referenceTables := make(map[string]bool)
+	for _, enum := range data.ReferenceTableEnums {
+		referenceTables[enum.Schema+"."+enum.ReferenceTableName] = true
+	}
+	for _, table := range data.Tables {
+		for _, column := range table.Columns {
+			// citext is the only user-defined type that is not an enum
+			if column.DataType != "USER-DEFINED" || column.UserDefinedTypeName == "citext" ||
+				column.UserDefinedTypeSchema == "" {
+				continue
+			}
+			if err := load(column.UserDefinedTypeSchema); err != nil {
+				return err
+			}
+		}
+		for _, fk := range table.ForeignKeyConstraints {
+			qualifiedName := fk.ForeignTableSchema + "." + fk.ForeignTableName
+			if !strings.HasSuffix(fk.ForeignTableName, ReferenceTableSuffix) ||
+				referenceTables[qualifiedName] {
+				continue
+			}
+			referenceTables[qualifiedName] = true
+			if err := load(fk.ForeignTableSchema); err != nil {
+				return err
+			}
+			enum, err := getReferenceTableEnum(db, data.Loader, fk.ForeignTableSchema, fk.ForeignTableName)
+			if err != nil {
+				return err
+			}
+			data.ReferenceTableEnums = append(data.ReferenceTableEnums, enum)
+		}
+	}
+	return nil
 }
 
 func getDatabaseEnums(
@@ -76,6 +232,7 @@ func getDatabaseEnums(
 			return nil, err
 		}
 		result = append(result, Enum{
+			Schema:           schema,
 			Name:             enum.EnumName,
 			Values:           enumValues,
 			IsReferenceTable: false,
@@ -88,35 +245,48 @@ func getReferenceTableEnums(
 	db *sqlx.DB,
 	loader *Loader,
 	schema string,
+	tables []Table,
 ) ([]Enum, error) {
-	tables, err := loader.TableList(db, schema)
-	if err != nil {
-		return nil, err
-	}
 	var result []Enum
 	for _, table := range tables {
-		if !strings.HasSuffix(table.TableName, ReferenceTableSuffix) {
+		if table.Table.Type != TableTypeBaseTable ||
+			!strings.HasSuffix(table.Table.TableName, ReferenceTableSuffix) {
 			continue
 		}
-		name := strings.ReplaceAll(table.TableName, ReferenceTableSuffix, "")
-		enumValues, err := loader.ReferenceTableValueList(db, schema, table.TableName)
+		enum, err := getReferenceTableEnum(db, loader, schema, table.Table.TableName)
 		if err != nil {
 			return nil, err
 		}
-		result = append(result, Enum{
-			Name:               name,
-			Values:             enumValues,
-			IsReferenceTable:   true,
-			ReferenceTableName: table.TableName,
-		})
+		result = append(result, enum)
 	}
 	return result, nil
 }
 
+func getReferenceTableEnum(
+	db *sqlx.DB,
+	loader *Loader,
+	schema string,
+	tableName string,
+) (Enum, error) {
+	name := strings.ReplaceAll(tableName, ReferenceTableSuffix, "")
+	enumValues, err := loader.ReferenceTableValueList(db, schema, tableName)
+	if err != nil {
+		return Enum{}, err
+	}
+	return Enum{
+		Schema:             schema,
+		Name:               name,
+		Values:             enumValues,
+		IsReferenceTable:   true,
+		ReferenceTableName: tableName,
+	}, nil
+}
+
 func getDatabaseTables(
 	db *sqlx.DB,
 	loader *Loader,
 	schema string,
+	config *SchemaConfig,
 ) ([]Table, error) {
 	tables, err := loader.TableList(db, schema)
 	if err != nil {
@@ -124,6 +294,13 @@ func getDatabaseTables(
 	}
 	var result []Table
 	for _, table := range tables {
+		matched, err := config.matchesTable(table)
+		if err != nil {
+			return nil, err
+		}
+		if !matched {
+			continue
+		}
 		columns, err := loader.ColumnList(db, schema, table.TableName)
 		if err != nil {
 			return nil, err
@@ -132,15 +309,125 @@ func getDatabaseTables(
 		if err != nil {
 			return nil, err
 		}
-		foreignConstraints, err := loader.ForeignKeyConstraintList(db, table.TableName)
+		foreignConstraints, err := loader.ForeignKeyConstraintList(db, schema, table.TableName)
+		if err != nil {
+			return nil, err
+		}
+		checkConstraints, err := loader.CheckConstraintList(db, schema, table.TableName)
+		if err != nil {
+			return nil, err
+		}
+		exclusionConstraints, err := loader.ExclusionConstraintList(db, schema, table.TableName)
 		if err != nil {
 			return nil, err
 		}
 		result = append(result, Table{
+			Schema:                schema,
 			Table:                 table,
 			Columns:               columns,
 			Constraints:           constraints,
 			ForeignKeyConstraints: foreignConstraints,
+			ForeignKeys:           getForeignKeys(foreignConstraints),
+			CheckConstraints:      checkConstraints,
+			ExclusionConstraints:  exclusionConstraints,
+		})
+	}
+	return result, nil
+}
+
+// getForeignKeys groups the column pairs of the foreign keys of a table by
+// constraint, in the order of their ordinal positions
+func getForeignKeys(
+	constraints []ForeignKeyConstraintMetadata,
+) []ForeignKey {
+	sorted := make([]ForeignKeyConstraintMetadata, len(constraints))
+	copy(sorted, constraints)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].OrdinalPosition < sorted[j].OrdinalPosition
+	})
+	var result []ForeignKey
+	indexes := make(map[string]int)
+	for _, constraint := range sorted {
+		index, ok := indexes[constraint.ConstraintName]
+		if !ok {
+			index = len(result)
+			indexes[constraint.ConstraintName] = index
+			result = append(result, ForeignKey{
+				Name:                constraint.ConstraintName,
+				ReferencedSchema:    constraint.ForeignTableSchema,
+				ReferencedTable:     constraint.ForeignTableName,
+				UpdateRule:          constraint.UpdateRule,
+				DeleteRule:          constraint.DeleteRule,
+				IsDeferrable:        constraint.IsDeferrable,
+				IsInitiallyDeferred: constraint.IsInitiallyDeferred,
+			})
+		}
+		fk := &result[index]
+		fk.Columns = append(fk.Columns, constraint.ColumnName)
+		fk.ReferencedColumns = append(fk.ReferencedColumns, constraint.ForeignColumnName)
+	}
+	return result
+}
+
+func getRoutines(
+	db *sqlx.DB,
+	loader *Loader,
+	schema string,
+) ([]Routine, error) {
+	routines, err := loader.RoutineList(db, schema)
+	if err != nil {
+		return nil, err
+	}
+	var result []Routine
+	for _, routine := range routines {
+		parameters, err := loader.ParameterList(db, schema, routine.SpecificName)
+		if err != nil {
+			return nil, err
+		}
+		var columns []ColumnMetadata
+		for _, parameter := range parameters {
+			if parameter.ParameterMode == ParameterModeOut || parameter.ParameterMode == ParameterModeInOut {
+				name := parameter.ParameterName
+				if name == "" {
+					// the name postgres gives to the unnamed result columns
+					name = fmt.Sprintf("column%d", len(columns)+1)
+				}
+				columns = append(columns, ColumnMetadata{
+					ColumnName:            name,
+					DataType:              parameter.DataType,
+					UserDefinedTypeName:   parameter.UserDefinedTypeName,
+					UserDefinedTypeSchema: parameter.UserDefinedTypeSchema,
+				})
+			}
+		}
+		if len(columns) == 1 && !routine.ReturnsSet {
+			// a single OUT parameter is the scalar result of the function
+			columns = nil
+		} else if len(columns) == 0 && routine.DataType == "USER-DEFINED" {
+			// the columns of a function that returns the row type of a table,
+			// there are none for enums
+			columns, err = loader.ColumnList(db, routine.UserDefinedTypeSchema, routine.UserDefinedTypeName)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if len(columns) == 0 && routine.ReturnsSet {
+			// SETOF a scalar type has a column named after the function
+			columns = append(columns, ColumnMetadata{
+				ColumnName:            routine.RoutineName,
+				DataType:              routine.DataType,
+				UserDefinedTypeName:   routine.UserDefinedTypeName,
+				UserDefinedTypeSchema: routine.UserDefinedTypeSchema,
+			})
+		}
+		if routine.RoutineType == RoutineTypeProcedure {
+			columns = nil
+		}
+		result = append(result, Routine{
+			Schema:     schema,
+			Routine:    routine,
+			Parameters: parameters,
+			Columns:    columns,
 		})
 	}
 	return result, nil
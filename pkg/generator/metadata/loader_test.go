@@ -0,0 +1,120 @@
+package metadata
+
+import (
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetForeignKeys(t *testing.T) {
+	// the rows of a composite foreign key are not necessarily adjacent or in
+	// the order of their ordinal positions
+	constraints := []ForeignKeyConstraintMetadata{
+		{
+			ConstraintName: "person_planet_fkey", ColumnName: "planet_system", OrdinalPosition: 2,
+			ForeignTableSchema: "public", ForeignTableName: "planet", ForeignColumnName: "system",
+			UpdateRule: ForeignKeyActionCascade, DeleteRule: ForeignKeyActionSetNull,
+		},
+		{
+			ConstraintName: "person_species_id_fkey", ColumnName: "species_id", OrdinalPosition: 1,
+			ForeignTableSchema: "public", ForeignTableName: "species", ForeignColumnName: "id",
+			UpdateRule: ForeignKeyActionNoAction, DeleteRule: ForeignKeyActionRestrict,
+			IsDeferrable: true, IsInitiallyDeferred: true,
+		},
+		{
+			ConstraintName: "person_planet_fkey", ColumnName: "planet_name", OrdinalPosition: 1,
+			ForeignTableSchema: "public", ForeignTableName: "planet", ForeignColumnName: "name",
+			UpdateRule: ForeignKeyActionCascade, DeleteRule: ForeignKeyActionSetNull,
+		},
+	}
+	require.Equal(t, []ForeignKey{
+		{
+			Name:                "person_species_id_fkey",
+			Columns:             []string{"species_id"},
+			ReferencedSchema:    "public",
+			ReferencedTable:     "species",
+			ReferencedColumns:   []string{"id"},
+			UpdateRule:          ForeignKeyActionNoAction,
+			DeleteRule:          ForeignKeyActionRestrict,
+			IsDeferrable:        true,
+			IsInitiallyDeferred: true,
+		},
+		{
+			Name:              "person_planet_fkey",
+			Columns:           []string{"planet_name", "planet_system"},
+			ReferencedSchema:  "public",
+			ReferencedTable:   "planet",
+			ReferencedColumns: []string{"name", "system"},
+			UpdateRule:        ForeignKeyActionCascade,
+			DeleteRule:        ForeignKeyActionSetNull,
+		},
+	}, getForeignKeys(constraints))
+	// the rows are not sorted in place
+	require.Equal(t, "planet_system", constraints[0].ColumnName)
+	require.Empty(t, getForeignKeys(nil))
+}
+
+func TestForeignKeyIsComposite(t *testing.T) {
+	require.False(t, (&ForeignKey{Columns: []string{"species_id"}}).IsComposite())
+	require.True(t, (&ForeignKey{Columns: []string{"planet_name", "planet_system"}}).IsComposite())
+}
+
+func TestNewDataReferencedSchemas(t *testing.T) {
+	loader := &Loader{
+		SchemaList: func(_ *sqlx.DB) ([]string, error) {
+			return []string{"billing", "public", "tenant"}, nil
+		},
+		TableList: func(_ *sqlx.DB, schema string) ([]TableMetadata, error) {
+			return []TableMetadata{{Type: TableTypeBaseTable, TableName: schema + "_table"}}, nil
+		},
+		ColumnList: func(_ *sqlx.DB, _, _ string) ([]ColumnMetadata, error) {
+			return []ColumnMetadata{{
+				ColumnName: "currency", DataType: "USER-DEFINED",
+				UserDefinedTypeName: "currency", UserDefinedTypeSchema: "billing",
+			}}, nil
+		},
+		ConstraintList: func(_ *sqlx.DB, _, _ string) ([]ConstraintMetadata, error) {
+			return nil, nil
+		},
+		ForeignKeyConstraintList: func(_ *sqlx.DB, _, _ string) ([]ForeignKeyConstraintMetadata, error) {
+			return nil, nil
+		},
+		CheckConstraintList: func(_ *sqlx.DB, _, _ string) ([]CheckConstraintMetadata, error) {
+			return nil, nil
+		},
+		ExclusionConstraintList: func(_ *sqlx.DB, _, _ string) ([]ExclusionConstraintMetadata, error) {
+			return nil, nil
+		},
+		EnumList: func(_ *sqlx.DB, schema string) ([]EnumMetadata, error) {
+			if schema == "billing" {
+				return []EnumMetadata{{EnumName: "currency"}}, nil
+			}
+			return nil, nil
+		},
+		EnumValueList: func(_ *sqlx.DB, _, _ string) ([]EnumValueMetadata, error) {
+			return []EnumValueMetadata{{EnumValue: "USD"}}, nil
+		},
+		RoutineList: func(_ *sqlx.DB, _ string) ([]RoutineMetadata, error) {
+			return nil, nil
+		},
+	}
+	// the schema of an enum type is loaded but is not selected
+	data, err := NewData(nil, loader, SchemaConfig{Name: "tenant"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"tenant"}, data.Schemas)
+	require.Equal(t, []string{"billing"}, data.ReferencedSchemas)
+	require.Len(t, data.Tables, 1)
+	require.Equal(t, []Enum{{
+		Schema: "billing", Name: "currency", Values: []EnumValueMetadata{{EnumValue: "USD"}},
+	}}, data.Enums)
+	require.Equal(t, "TenantTable", data.TypeName("tenant", "tenant_table"))
+	require.Equal(t, "Currency", data.TypeName("billing", "currency"))
+
+	// the schema is not referenced when it is selected
+	data, err = NewData(nil, loader, SchemaConfig{Name: "tenant"}, SchemaConfig{Name: "billing"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"tenant", "billing"}, data.Schemas)
+	require.Empty(t, data.ReferencedSchemas)
+	require.Equal(t, "BillingCurrency", data.TypeName("billing", "currency"))
+}
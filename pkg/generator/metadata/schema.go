@@ -0,0 +1,238 @@
+package metadata
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/knq/snaker"
+)
+
+const (
+	DefaultSchema = "public"
+)
+
+// SchemaConfig selects the schemas and tables to generate code for, e.g.
+//
+//	schemas:
+//	  - name: public
+//	    exclude: ["*_archive"]
+//	  - name: "tenant_*"
+//	    include: ["users", "user_*"]
+//
+// Name, Include and Exclude are glob patterns as in path.Match. Include and
+// Exclude are matched against table names, all tables are included when
+// Include is empty. The partitions of partitioned tables are skipped unless
+// IncludePartitions is set.
+type SchemaConfig struct {
+	Name              string
+	Include           []string
+	Exclude           []string
+	IncludePartitions bool
+}
+
+func (config *SchemaConfig) matchesSchema(
+	schema string,
+) (bool, error) {
+	return path.Match(config.Name, schema)
+}
+
+func (config *SchemaConfig) matchesTable(
+	table TableMetadata,
+) (bool, error) {
+	if table.IsPartition && !config.IncludePartitions {
+		return false, nil
+	}
+	tableName := table.TableName
+	included := len(config.Include) == 0
+	for _, pattern := range config.Include {
+		matched, err := path.Match(pattern, tableName)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return false, nil
+	}
+	for _, pattern := range config.Exclude {
+		matched, err := path.Match(pattern, tableName)
+		if err != nil || matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// selectSchemas returns the schemas of the database that match configs in
+// the order of configs, together with the config that selected each
+func selectSchemas(
+	configs []SchemaConfig, schemas []string,
+) ([]string, map[string]*SchemaConfig, error) {
+	var selected []string
+	selectedConfigs := make(map[string]*SchemaConfig)
+	for index := range configs {
+		config := &configs[index]
+		found := false
+		for _, schema := range schemas {
+			matched, err := config.matchesSchema(schema)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !matched {
+				continue
+			}
+			found = true
+			if _, ok := selectedConfigs[schema]; !ok {
+				selected = append(selected, schema)
+				selectedConfigs[schema] = config
+			}
+		}
+		if !found && !strings.ContainsAny(config.Name, `*?[\`) {
+			return nil, nil, fmt.Errorf("schema %s does not exist", config.Name)
+		}
+	}
+	return selected, selectedConfigs, nil
+}
+
+// SchemaPackage returns the name of the package that is generated for schema
+// when a package is generated per schema, i.e. the schema name in lowercase
+// without the characters that are not valid in a package name
+func SchemaPackage(
+	schema string,
+) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, schema)
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "schema" + name
+	}
+	return name
+}
+
+// TypeName returns the Go type generated for the database object name of
+// schema (a table or an enum type) as it is referenced from the code that is
+// generated for data. In a package per schema, the objects of other selected
+// schemas are qualified by their package, e.g. billing.Currency, which the
+// generated code does not import, see ForSchema. In a
+// combined package, the objects of selected schemas other than public are
+// prefixed by their schema when there is more than one, e.g.
+// BillingCurrency. The types of referenced schemas are generated with the
+// selected schemas and are never qualified.
+func (data *Data) TypeName(
+	schema, name string,
+) string {
+	switch {
+	case data.PackagePerSchema && schema != data.Schema && data.isSelected(schema):
+		return fmt.Sprintf("%s.%s", SchemaPackage(schema), snaker.SnakeToCamelIdentifier(name))
+	case !data.PackagePerSchema && data.qualifiesNames(schema):
+		return snaker.SnakeToCamelIdentifier(fmt.Sprintf("%s_%s", schema, name))
+	default:
+		return snaker.SnakeToCamelIdentifier(name)
+	}
+}
+
+// QualifiedName returns name prefixed by schema as TypeName does, in snake
+// case, e.g. billing_invoice for the table invoice of the schema billing
+func (data *Data) QualifiedName(
+	schema, name string,
+) string {
+	if !data.PackagePerSchema && data.qualifiesNames(schema) {
+		return fmt.Sprintf("%s_%s", schema, name)
+	}
+	return name
+}
+
+// qualifiesNames is true for the selected schemas other than public when
+// more than one schema is selected
+func (data *Data) qualifiesNames(
+	schema string,
+) bool {
+	return len(data.Schemas) > 1 && schema != DefaultSchema && data.isSelected(schema)
+}
+
+func (data *Data) isSelected(
+	schema string,
+) bool {
+	for _, selected := range data.Schemas {
+		if selected == schema {
+			return true
+		}
+	}
+	return false
+}
+
+// ForSchema returns the objects of data that belong to schema for generating
+// the package of schema, together with the types of the referenced schemas.
+// It fails when a table of schema uses a type of another selected schema
+// since the package of schema would have to import the package of the type.
+func (data *Data) ForSchema(
+	schema string,
+) (*Data, error) {
+	result := &Data{
+		Schema:            schema,
+		Schemas:           data.Schemas,
+		ReferencedSchemas: data.ReferencedSchemas,
+		PackagePerSchema:  true,
+		Loader:            data.Loader,
+	}
+	for _, table := range data.Tables {
+		if table.Schema != schema {
+			continue
+		}
+		if typeName, ok := data.getSelectedSchemaType(table); ok {
+			return nil, fmt.Errorf(
+				"table %s.%s uses the type %s of another schema, which a package per schema cannot reference",
+				table.Schema, table.Table.TableName, typeName)
+		}
+		result.Tables = append(result.Tables, table)
+	}
+	for _, enum := range data.Enums {
+		if enum.Schema == schema || !data.isSelected(enum.Schema) {
+			result.Enums = append(result.Enums, enum)
+		}
+	}
+	for _, enum := range data.ReferenceTableEnums {
+		if enum.Schema == schema || !data.isSelected(enum.Schema) {
+			result.ReferenceTableEnums = append(result.ReferenceTableEnums, enum)
+		}
+	}
+	for _, routine := range data.Routines {
+		if routine.Schema == schema {
+			result.Routines = append(result.Routines, routine)
+		}
+	}
+	return result, nil
+}
+
+// getSelectedSchemaType returns the schema qualified name of the first type
+// of a selected schema other than the schema of table that one of its
+// columns uses, i.e. an enum type or the enum of a reference table
+func (data *Data) getSelectedSchemaType(
+	table Table,
+) (string, bool) {
+	isOtherSchema := func(schema string) bool {
+		return schema != table.Schema && data.isSelected(schema)
+	}
+	for _, column := range table.Columns {
+		// citext is the only user-defined type that is not an enum
+		if column.DataType == "USER-DEFINED" && column.UserDefinedTypeName != "citext" &&
+			isOtherSchema(column.UserDefinedTypeSchema) {
+			return column.UserDefinedTypeSchema + "." + column.UserDefinedTypeName, true
+		}
+	}
+	for _, fk := range table.ForeignKeys {
+		if strings.HasSuffix(fk.ReferencedTable, ReferenceTableSuffix) && !fk.IsComposite() &&
+			isOtherSchema(fk.ReferencedSchema) {
+			return fk.ReferencedSchema + "." + fk.ReferencedTable, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,241 @@
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectSchemas(t *testing.T) {
+	databaseSchemas := []string{"billing", "public", "tenant_a", "tenant_b"}
+	configs := []SchemaConfig{
+		{Name: "tenant_*"},
+		{Name: "public"},
+		{Name: "tenant_a", Include: []string{"users"}},
+	}
+	schemas, schemaConfigs, err := selectSchemas(configs, databaseSchemas)
+	require.NoError(t, err)
+	require.Equal(t, []string{"tenant_a", "tenant_b", "public"}, schemas)
+	// the first config that matches a schema selects it
+	require.Equal(t, &configs[0], schemaConfigs["tenant_a"])
+	require.Equal(t, &configs[0], schemaConfigs["tenant_b"])
+	require.Equal(t, &configs[1], schemaConfigs["public"])
+
+	// a pattern may match no schema, a name has to exist
+	schemas, _, err = selectSchemas([]SchemaConfig{{Name: "audit_*"}}, databaseSchemas)
+	require.NoError(t, err)
+	require.Empty(t, schemas)
+	_, _, err = selectSchemas([]SchemaConfig{{Name: "audit"}}, databaseSchemas)
+	require.EqualError(t, err, "schema audit does not exist")
+	_, _, err = selectSchemas([]SchemaConfig{{Name: "[a"}}, databaseSchemas)
+	require.Error(t, err)
+}
+
+func TestSchemaPackage(t *testing.T) {
+	testCases := []struct {
+		schema   string
+		expected string
+	}{
+		{"public", "public"},
+		{"Billing", "billing"},
+		{"tenant_a", "tenanta"},
+		{"2020", "schema2020"},
+		{"_", "schema"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.schema, func(t *testing.T) {
+			require.Equal(t, testCase.expected, SchemaPackage(testCase.schema))
+		})
+	}
+}
+
+func TestTypeName(t *testing.T) {
+	testCases := []struct {
+		name              string
+		data              *Data
+		schema            string
+		expectedType      string
+		expectedQualified string
+	}{
+		{
+			name:              "single schema",
+			data:              &Data{Schemas: []string{"billing"}},
+			schema:            "billing",
+			expectedType:      "Invoice",
+			expectedQualified: "invoice",
+		},
+		{
+			name:              "combined package public",
+			data:              &Data{Schemas: []string{"public", "billing"}},
+			schema:            "public",
+			expectedType:      "Invoice",
+			expectedQualified: "invoice",
+		},
+		{
+			name:              "combined package other schema",
+			data:              &Data{Schemas: []string{"public", "billing"}},
+			schema:            "billing",
+			expectedType:      "BillingInvoice",
+			expectedQualified: "billing_invoice",
+		},
+		{
+			name:              "combined package referenced schema",
+			data:              &Data{Schemas: []string{"public", "billing"}, ReferencedSchemas: []string{"ledger"}},
+			schema:            "ledger",
+			expectedType:      "Invoice",
+			expectedQualified: "invoice",
+		},
+		{
+			name:              "package per schema same schema",
+			data:              &Data{Schema: "billing", Schemas: []string{"public", "billing"}, PackagePerSchema: true},
+			schema:            "billing",
+			expectedType:      "Invoice",
+			expectedQualified: "invoice",
+		},
+		{
+			name:              "package per schema other schema",
+			data:              &Data{Schema: "public", Schemas: []string{"public", "billing"}, PackagePerSchema: true},
+			schema:            "billing",
+			expectedType:      "billing.Invoice",
+			expectedQualified: "invoice",
+		},
+		{
+			name:              "package per schema referenced schema",
+			data:              &Data{Schema: "public", Schemas: []string{"public"}, ReferencedSchemas: []string{"billing"}, PackagePerSchema: true},
+			schema:            "billing",
+			expectedType:      "Invoice",
+			expectedQualified: "invoice",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expectedType, testCase.data.TypeName(testCase.schema, "invoice"))
+			require.Equal(t, testCase.expectedQualified, testCase.data.QualifiedName(testCase.schema, "invoice"))
+		})
+	}
+}
+
+func TestForSchema(t *testing.T) {
+	data := &Data{
+		Schemas:           []string{"public", "billing"},
+		ReferencedSchemas: []string{"ledger"},
+		Tables: []Table{
+			{Schema: "public", Table: TableMetadata{TableName: "person"}},
+			{Schema: "billing", Table: TableMetadata{TableName: "invoice"}},
+		},
+		Enums: []Enum{
+			{Schema: "billing", Name: "currency"},
+			{Schema: "ledger", Name: "account_type"},
+		},
+		Routines: []Routine{{Schema: "public", Routine: RoutineMetadata{RoutineName: "count_people"}}},
+	}
+	billing, err := data.ForSchema("billing")
+	require.NoError(t, err)
+	require.Equal(t, "billing", billing.Schema)
+	require.True(t, billing.PackagePerSchema)
+	require.Equal(t, data.Schemas, billing.Schemas)
+	require.Equal(t, data.ReferencedSchemas, billing.ReferencedSchemas)
+	require.Equal(t, data.Tables[1:], billing.Tables)
+	require.Equal(t, data.Enums, billing.Enums)
+	require.Empty(t, billing.Routines)
+	// the types of the referenced schemas are generated in every package
+	public, err := data.ForSchema("public")
+	require.NoError(t, err)
+	require.Equal(t, data.Enums[1:], public.Enums)
+
+	// the package of public would have to import the package of billing
+	data.Tables[0].Columns = []ColumnMetadata{{
+		ColumnName: "currency", DataType: "USER-DEFINED",
+		UserDefinedTypeName: "currency", UserDefinedTypeSchema: "billing",
+	}}
+	_, err = data.ForSchema("public")
+	require.EqualError(t, err,
+		"table public.person uses the type billing.currency of another schema, which a package per schema cannot reference")
+	data.Tables[0].Columns = nil
+	data.Tables[0].ForeignKeys = []ForeignKey{{
+		Name: "person_color_fkey", Columns: []string{"color"},
+		ReferencedSchema: "billing", ReferencedTable: "color_reference_table", ReferencedColumns: []string{"value"},
+	}}
+	_, err = data.ForSchema("public")
+	require.EqualError(t, err,
+		"table public.person uses the type billing.color_reference_table of another schema, which a package per schema cannot reference")
+	// the types of the referenced schemas are generated in the package
+	data.Tables[0].ForeignKeys[0].ReferencedSchema = "ledger"
+	_, err = data.ForSchema("public")
+	require.NoError(t, err)
+}
+
+func TestMatchesTable(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   SchemaConfig
+		table    TableMetadata
+		expected bool
+	}{
+		{
+			name:     "all tables",
+			config:   SchemaConfig{Name: "public"},
+			table:    TableMetadata{TableName: "person"},
+			expected: true,
+		},
+		{
+			name:     "included",
+			config:   SchemaConfig{Name: "public", Include: []string{"user", "person*"}},
+			table:    TableMetadata{TableName: "person_archive"},
+			expected: true,
+		},
+		{
+			name:     "not included",
+			config:   SchemaConfig{Name: "public", Include: []string{"user"}},
+			table:    TableMetadata{TableName: "person"},
+			expected: false,
+		},
+		{
+			name:     "excluded",
+			config:   SchemaConfig{Name: "public", Include: []string{"person*"}, Exclude: []string{"*_archive"}},
+			table:    TableMetadata{TableName: "person_archive"},
+			expected: false,
+		},
+		{
+			name:     "partitioned table",
+			config:   SchemaConfig{Name: "public"},
+			table:    TableMetadata{TableName: "event", Type: TableTypePartitionedTable},
+			expected: true,
+		},
+		{
+			name:     "partition",
+			config:   SchemaConfig{Name: "public"},
+			table:    TableMetadata{TableName: "event_2020", IsPartition: true},
+			expected: false,
+		},
+		{
+			name:     "included partition",
+			config:   SchemaConfig{Name: "public", IncludePartitions: true},
+			table:    TableMetadata{TableName: "event_2020", IsPartition: true},
+			expected: true,
+		},
+		{
+			name:     "excluded partition",
+			config:   SchemaConfig{Name: "public", Exclude: []string{"event_*"}, IncludePartitions: true},
+			table:    TableMetadata{TableName: "event_2020", IsPartition: true},
+			expected: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			matched, err := testCase.config.matchesTable(testCase.table)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, matched)
+		})
+	}
+
+	_, err := (&SchemaConfig{Name: "public", Include: []string{"[a"}}).matchesTable(TableMetadata{TableName: "a"})
+	require.Error(t, err)
+}
+
+func TestIsView(t *testing.T) {
+	require.False(t, TableMetadata{Type: TableTypeBaseTable}.IsView())
+	require.False(t, TableMetadata{Type: TableTypePartitionedTable}.IsView())
+	require.True(t, TableMetadata{Type: TableTypeView}.IsView())
+	require.True(t, TableMetadata{Type: TableTypeMaterializedView}.IsView())
+}
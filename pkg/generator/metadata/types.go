@@ -22,6 +22,9 @@ var (
 	DataTypeJSONB       = DataType{Name: "Jsonb", Literal: "[]byte", NullableLiteral: "nullable.Jsonb"}
 	DataTypeString      = DataType{Name: "String", Literal: "string", NullableLiteral: "null.String"}
 	DataTypeStringArray = DataType{Name: "StringArray", Literal: "pq.StringArray", NullableLiteral: "pq.StringArray"}
+	DataTypeInterval    = DataType{Name: "Interval", Literal: "string", NullableLiteral: "null.String"}
+	DataTypeIntArray    = DataType{Name: "IntArray", Literal: "pq.Int64Array", NullableLiteral: "pq.Int64Array"}
+	DataTypeUUIDArray   = DataType{Name: "UUIDArray", Literal: "nullable.UUIDArray", NullableLiteral: "nullable.UUIDArray"}
 	DataTypeTime        = DataType{Name: "Time", Literal: "time.Time", NullableLiteral: "null.Time"}
 	DataTypeUUID        = DataType{Name: "UUID", Literal: "uuid.UUID", NullableLiteral: "nullable.UUID"}
 )
@@ -36,6 +39,9 @@ var NameToType = map[string]DataType{
 	DataTypeJSONB.Name:       DataTypeJSONB,
 	DataTypeString.Name:      DataTypeString,
 	DataTypeStringArray.Name: DataTypeStringArray,
+	DataTypeInterval.Name:    DataTypeInterval,
+	DataTypeIntArray.Name:    DataTypeIntArray,
+	DataTypeUUIDArray.Name:   DataTypeUUIDArray,
 	DataTypeTime.Name:        DataTypeTime,
 	DataTypeUUID.Name:        DataTypeUUID,
 }
@@ -50,10 +56,27 @@ type EnumValueMetadata struct {
 	ConstValue  int    `db:"const_value"`
 }
 
+// the types of TableMetadata, as in information_schema.tables.table_type
+// where they exist
+const (
+	TableTypeBaseTable        = "BASE TABLE"
+	TableTypeView             = "VIEW"
+	TableTypeMaterializedView = "MATERIALIZED VIEW"
+	TableTypeForeignTable     = "FOREIGN"
+	TableTypePartitionedTable = "PARTITIONED TABLE"
+)
+
 type TableMetadata struct {
 	Type      string `db:"type"`
 	TableName string `db:"table_name"`
 	ManualPk  bool   `db:"manual_pk"`
+	// IsPartition is true for the partitions of a partitioned table
+	IsPartition bool `db:"is_partition"`
+}
+
+// IsView is true for views and materialized views, which cannot be modified
+func (table TableMetadata) IsView() bool {
+	return table.Type == TableTypeView || table.Type == TableTypeMaterializedView
 }
 
 type ColumnMetadata struct {
@@ -61,6 +84,8 @@ type ColumnMetadata struct {
 	DataType            string `db:"data_type"`
 	IsNullable          bool   `db:"is_nullable"`
 	UserDefinedTypeName string `db:"udt_name"`
+	// UserDefinedTypeSchema is the schema of the enum type of the column
+	UserDefinedTypeSchema string `db:"udt_schema"`
 }
 
 type ConstraintMetadata struct {
@@ -73,25 +98,107 @@ type ConstraintMetadata struct {
 	IndexKeys      string      `db:"index_keys"`
 }
 
+// the referential actions of ForeignKeyConstraintMetadata, as in
+// information_schema.referential_constraints
+const (
+	ForeignKeyActionNoAction   = "NO ACTION"
+	ForeignKeyActionRestrict   = "RESTRICT"
+	ForeignKeyActionCascade    = "CASCADE"
+	ForeignKeyActionSetNull    = "SET NULL"
+	ForeignKeyActionSetDefault = "SET DEFAULT"
+)
+
+// ForeignKeyConstraintMetadata is a column of a foreign key together with the
+// column that it references, OrdinalPosition is the position of the pair in
+// the foreign key starting at 1
 type ForeignKeyConstraintMetadata struct {
-	TableSchema        string `db:"table_schema"`
-	ConstraintName     string `db:"constraint_name"`
-	TableName          string `db:"table_name"`
-	ColumnName         string `db:"column_name"`
-	ForeignTableSchema string `db:"foreign_table_schema"`
-	ForeignTableName   string `db:"foreign_table_name"`
-	ForeignColumnName  string `db:"foreign_column_name"`
+	TableSchema         string `db:"table_schema"`
+	ConstraintName      string `db:"constraint_name"`
+	TableName           string `db:"table_name"`
+	ColumnName          string `db:"column_name"`
+	OrdinalPosition     int    `db:"ordinal_position"`
+	ForeignTableSchema  string `db:"foreign_table_schema"`
+	ForeignTableName    string `db:"foreign_table_name"`
+	ForeignColumnName   string `db:"foreign_column_name"`
+	UpdateRule          string `db:"update_rule"`
+	DeleteRule          string `db:"delete_rule"`
+	IsDeferrable        bool   `db:"is_deferrable"`
+	IsInitiallyDeferred bool   `db:"is_initially_deferred"`
+}
+
+// CheckConstraintMetadata is a CHECK constraint of a table, Expression is its
+// condition without the CHECK keyword, e.g. price > 0::numeric, and Columns is
+// the JSON array of the columns that it references
+type CheckConstraintMetadata struct {
+	ConstraintName string `db:"constraint_name"`
+	Expression     string `db:"expression"`
+	Columns        string `db:"columns"`
+	IsNoInherit    bool   `db:"is_no_inherit"`
+}
+
+// ExclusionConstraintMetadata is an EXCLUDE constraint of a table. Elements
+// and Operators are the JSON arrays of the index expressions and the
+// operators that they are compared with, e.g. ["room", "during"] and
+// ["=", "&&"] for EXCLUDE USING gist (room WITH =, during WITH &&).
+type ExclusionConstraintMetadata struct {
+	ConstraintName      string      `db:"constraint_name"`
+	IndexMethod         string      `db:"index_method"`
+	Elements            string      `db:"elements"`
+	Operators           string      `db:"operators"`
+	Predicate           null.String `db:"predicate"`
+	Definition          string      `db:"definition"`
+	IsDeferrable        bool        `db:"is_deferrable"`
+	IsInitiallyDeferred bool        `db:"is_initially_deferred"`
+}
+
+// the types of RoutineMetadata, as in information_schema.routines
+const (
+	RoutineTypeFunction  = "FUNCTION"
+	RoutineTypeProcedure = "PROCEDURE"
+)
+
+// RoutineMetadata is a user-defined function or procedure, DataType and the
+// user-defined type are those of the return type as in
+// information_schema.routines
+type RoutineMetadata struct {
+	SpecificName          string `db:"specific_name"`
+	RoutineName           string `db:"routine_name"`
+	RoutineType           string `db:"routine_type"`
+	DataType              string `db:"data_type"`
+	UserDefinedTypeName   string `db:"udt_name"`
+	UserDefinedTypeSchema string `db:"udt_schema"`
+	ReturnsSet            bool   `db:"returns_set"`
+}
+
+// the modes of ParameterMetadata, the columns of RETURNS TABLE are OUT
+// parameters
+const (
+	ParameterModeIn    = "IN"
+	ParameterModeOut   = "OUT"
+	ParameterModeInOut = "INOUT"
+)
+
+type ParameterMetadata struct {
+	ParameterName         string `db:"parameter_name"`
+	ParameterMode         string `db:"parameter_mode"`
+	DataType              string `db:"data_type"`
+	UserDefinedTypeName   string `db:"udt_name"`
+	UserDefinedTypeSchema string `db:"udt_schema"`
 }
 
 type Loader struct {
-	Schema                   func() (string, error)
+	SchemaList               func(*sqlx.DB) ([]string, error)
 	TableList                func(*sqlx.DB, string) ([]TableMetadata, error)
 	ColumnList               func(*sqlx.DB, string, string) ([]ColumnMetadata, error)
 	ConstraintList           func(*sqlx.DB, string, string) ([]ConstraintMetadata, error)
-	ForeignKeyConstraintList func(*sqlx.DB, string) ([]ForeignKeyConstraintMetadata, error)
+	ForeignKeyConstraintList func(*sqlx.DB, string, string) ([]ForeignKeyConstraintMetadata, error)
+	CheckConstraintList      func(*sqlx.DB, string, string) ([]CheckConstraintMetadata, error)
+	ExclusionConstraintList  func(*sqlx.DB, string, string) ([]ExclusionConstraintMetadata, error)
 	EnumList                 func(*sqlx.DB, string) ([]EnumMetadata, error)
 	EnumValueList            func(*sqlx.DB, string, string) ([]EnumValueMetadata, error)
 	ReferenceTableValueList  func(*sqlx.DB, string, string) ([]EnumValueMetadata, error)
-	GetDataType              func(string) (DataType, error)
+	RoutineList              func(*sqlx.DB, string) ([]RoutineMetadata, error)
+	ParameterList            func(*sqlx.DB, string, string) ([]ParameterMetadata, error)
+	GetDataType              func(ColumnMetadata) (DataType, error)
 	GetTypeByName            func(string) (DataType, error)
 }
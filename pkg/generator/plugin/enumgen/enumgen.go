@@ -11,26 +11,40 @@ import (
 )
 
 type EnumGenerator struct {
-	outputFile string
+	outputFile  string
+	packageName string
 }
 
 func NewEnumGenerator(
 	outputFile string,
+) *EnumGenerator {
+	return NewPackageEnumGenerator(outputFile, "model")
+}
+
+func NewPackageEnumGenerator(
+	outputFile, packageName string,
 ) *EnumGenerator {
 	return &EnumGenerator{
-		outputFile: outputFile,
+		outputFile:  outputFile,
+		packageName: packageName,
 	}
 }
 
 func (gen *EnumGenerator) GenerateCode(
 	data *metadata.Data,
 ) error {
-	enums := append(data.Enums, data.ReferenceTableEnums...)
+	var enums []enumTemplateArgs
+	for _, enum := range append(data.Enums, data.ReferenceTableEnums...) {
+		enums = append(enums, enumTemplateArgs{
+			Enum: enum,
+			Type: data.TypeName(enum.Schema, enum.Name),
+		})
+	}
 	sort.SliceStable(enums, func(i, j int) bool {
-		return strings.Compare(enums[i].Name, enums[j].Name) < 0
+		return strings.Compare(enums[i].Type, enums[j].Type) < 0
 	})
 	args := templateArgs{
-		Package:   "model",
+		Package:   gen.packageName,
 		Timestamp: time.Now().Format(time.RFC3339),
 		Enums:     enums,
 	}
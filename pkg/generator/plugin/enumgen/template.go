@@ -8,7 +8,7 @@ package {{ .Package }}
 import "github.com/lumina-tech/gooq/pkg/gooq"
 
 {{ range $_, $enum := .Enums -}}
-{{- $type := (snakeToCamelID $enum.Name) -}}
+{{- $type := $enum.Type -}}
 type {{ $type }} string
 
 const (
@@ -6,5 +6,10 @@ type templateArgs struct {
 	Timestamp string
 	Package   string
 	Schema    string
-	Enums     []metadata.Enum
+	Enums     []enumTemplateArgs
+}
+
+type enumTemplateArgs struct {
+	metadata.Enum
+	Type string
 }
@@ -53,12 +53,18 @@ func (gen *ModelGenerator) GenerateCode(
 		Schema:    data.Schema,
 		Tables:    make([]TableTemplateArgs, 0),
 	}
+	tableFields := make(map[string][]FieldTemplateArgs)
 	for _, table := range data.Tables {
-		tableName := table.Table.TableName
 		fields, err := getFieldArgs(data, table, gen.overrides)
 		if err != nil {
 			return err
 		}
+		tableFields[getTableKey(table.Schema, table.Table.TableName)] = fields
+	}
+	for _, table := range data.Tables {
+		tableName := table.Table.TableName
+		qualifiedTableName := data.QualifiedName(table.Schema, tableName)
+		fields := tableFields[getTableKey(table.Schema, tableName)]
 		constraints, err := getConstraintArgs(table)
 		if err != nil {
 			return err
@@ -67,18 +73,41 @@ func (gen *ModelGenerator) GenerateCode(
 		if err != nil {
 			return err
 		}
-		modelType := snaker.SnakeToCamelIdentifier(tableName)
+		checkConstraints, err := getCheckConstraintArgs(table)
+		if err != nil {
+			return err
+		}
+		exclusionConstraints, err := getExclusionConstraintArgs(table)
+		if err != nil {
+			return err
+		}
+		modelType := data.TypeName(table.Schema, tableName)
+		relationships := getRelationshipArgs(data, table, tableFields, gen.modelPackage)
+		hasReferenceLoaders := false
+		for _, relationship := range relationships {
+			hasReferenceLoaders = hasReferenceLoaders || relationship.HasLoaders
+		}
 		args.Tables = append(args.Tables, TableTemplateArgs{
+			Schema:                 table.Schema,
 			TableName:              table.Table.TableName,
-			TableType:              snaker.ForceLowerCamelIdentifier(tableName),
-			TableSingletonName:     snaker.SnakeToCamelIdentifier(tableName),
+			TableType:              snaker.ForceLowerCamelIdentifier(qualifiedTableName),
+			TableSingletonName:     modelType,
 			ModelType:              modelType,
 			QualifiedModelType:     fmt.Sprintf("%s.%s", gen.modelPackage, modelType),
 			IsReferenceTable:       isReferenceTable(tableName),
-			ReferenceTableEnumType: getEnumTypeFromReferenceTableName(tableName),
+			ReferenceTableEnumType: getEnumTypeFromReferenceTableName(data, table.Schema, tableName),
+			RelationImpl:           getRelationImpl(table.Table),
+			IsView:                 table.Table.IsView(),
+			IsMaterializedView:     table.Table.Type == metadata.TableTypeMaterializedView,
 			Fields:                 fields,
 			Constraints:            constraints,
 			ForeignKeyConstraints:  foreignKeyConstraints,
+			ForeignKeys:            getForeignKeyArgs(table),
+			CheckConstraints:       checkConstraints,
+			ExclusionConstraints:   exclusionConstraints,
+			Relationships:          relationships,
+			PluralModelType:        pluralize(modelType),
+			HasReferenceLoaders:    hasReferenceLoaders,
 		})
 	}
 	enumTemplate := utils.GetTemplate(gen.templateString)
@@ -86,12 +115,14 @@ func (gen *ModelGenerator) GenerateCode(
 }
 
 func getColumnToTypeMapping(
-	table metadata.Table,
+	data *metadata.Data, table metadata.Table,
 ) map[string]string {
 	result := make(map[string]string)
-	for _, fk := range table.ForeignKeyConstraints {
-		if isReferenceTable(fk.ForeignTableName) {
-			result[fk.ColumnName] = getEnumTypeFromReferenceTableName(fk.ForeignTableName)
+	for _, fk := range table.ForeignKeys {
+		// the value column of a reference table is its only key
+		if isReferenceTable(fk.ReferencedTable) && !fk.IsComposite() {
+			result[fk.Columns[0]] = getEnumTypeFromReferenceTableName(
+				data, fk.ReferencedSchema, fk.ReferencedTable)
 		}
 	}
 	return result
@@ -100,7 +131,7 @@ func getColumnToTypeMapping(
 func getFieldArgs(
 	data *metadata.Data, table metadata.Table, overrides *ModelOverride,
 ) ([]FieldTemplateArgs, error) {
-	columnToRefTableMapping := getColumnToTypeMapping(table)
+	columnToRefTableMapping := getColumnToTypeMapping(data, table)
 	var results []FieldTemplateArgs
 	for _, column := range table.Columns {
 		var dataType metadata.DataType
@@ -109,7 +140,7 @@ func getFieldArgs(
 		if dataTypeKey, ok := getOverrideDataType(table.Table.TableName, column.ColumnName, overrides); ok {
 			dataType, err = data.Loader.GetTypeByName(dataTypeKey)
 		} else {
-			dataType, err = data.Loader.GetDataType(column.DataType)
+			dataType, err = data.Loader.GetDataType(column)
 		}
 
 		if err != nil {
@@ -123,7 +154,7 @@ func getFieldArgs(
 			literal = enumName
 		} else if column.DataType == "USER-DEFINED" && column.UserDefinedTypeName != "citext" {
 			// citext is the only user-defined type that is not an enum
-			literal = snaker.SnakeToCamelIdentifier(column.UserDefinedTypeName)
+			literal = data.TypeName(column.UserDefinedTypeSchema, column.UserDefinedTypeName)
 		}
 		results = append(results, FieldTemplateArgs{
 			Name:     column.ColumnName,
@@ -167,15 +198,250 @@ func getForeignKeyConstraintArgs(
 	var results []ForeignKeyConstraintTemplateArgs
 	for _, constraint := range table.ForeignKeyConstraints {
 		results = append(results, ForeignKeyConstraintTemplateArgs{
-			Name:              constraint.ConstraintName,
-			ColumnName:        constraint.ColumnName,
-			ForeignTableName:  constraint.ForeignTableName,
-			ForeignColumnName: constraint.ForeignColumnName,
+			Name:               constraint.ConstraintName,
+			ColumnName:         constraint.ColumnName,
+			OrdinalPosition:    constraint.OrdinalPosition,
+			ForeignTableSchema: constraint.ForeignTableSchema,
+			ForeignTableName:   constraint.ForeignTableName,
+			ForeignColumnName:  constraint.ForeignColumnName,
 		})
 	}
 	return results, nil
 }
 
+var (
+	// relationshipKeyArrays are the literals of the gooq arrays that the keys
+	// of a relationship are collected into by type of the referenced column
+	relationshipKeyArrays = map[string]string{
+		metadata.DataTypeUUID.Literal:   "gooq.UUIDArray",
+		metadata.DataTypeString.Literal: "gooq.StringArray",
+		metadata.DataTypeInt.Literal:    "gooq.IntArray",
+		metadata.DataTypeInt64.Literal:  "gooq.Int64Array",
+	}
+	// relationshipKeyValues are the fields that hold the value of the
+	// nullable types that can reference a key
+	relationshipKeyValues = map[string]string{
+		metadata.DataTypeUUID.NullableLiteral:   ".UUID",
+		metadata.DataTypeString.NullableLiteral: ".String",
+		metadata.DataTypeInt.NullableLiteral:    ".Int64",
+	}
+)
+
+// getRelationshipArgs returns the foreign keys of table that reference one
+// of the tables of data other than a reference table. In a package per
+// schema, the foreign keys that reference a table of another schema are
+// skipped since the referenced table and model are in other packages.
+func getRelationshipArgs(
+	data *metadata.Data, table metadata.Table,
+	tableFields map[string][]FieldTemplateArgs, modelPackage string,
+) []RelationshipTemplateArgs {
+	var results []RelationshipTemplateArgs
+	// the model is embedded in the struct of the rows it references
+	names := map[string]bool{
+		data.TypeName(table.Schema, table.Table.TableName): true,
+	}
+	for _, fk := range table.ForeignKeys {
+		if data.PackagePerSchema && fk.ReferencedSchema != table.Schema {
+			continue
+		}
+		referencedFields, ok := tableFields[getTableKey(fk.ReferencedSchema, fk.ReferencedTable)]
+		if !ok || isReferenceTable(fk.ReferencedTable) {
+			continue
+		}
+		referencedType := data.TypeName(fk.ReferencedSchema, fk.ReferencedTable)
+		relationshipName := fk.ReferencedTable
+		if !fk.IsComposite() {
+			relationshipName = strings.TrimSuffix(fk.Columns[0], "_id")
+		}
+		name := snaker.SnakeToCamelIdentifier(relationshipName)
+		if names[name] {
+			name = snaker.SnakeToCamelIdentifier(fk.Name)
+		}
+		names[name] = true
+		relationship := RelationshipTemplateArgs{
+			Name:                    name,
+			JSONName:                snaker.CamelToSnake(name),
+			ConstraintName:          fk.Name,
+			Columns:                 fk.Columns,
+			ReferencedTableName:     fk.ReferencedTable,
+			ReferencedSingletonName: referencedType,
+			ReferencedColumns:       fk.ReferencedColumns,
+			ReferencedModelType:     fmt.Sprintf("%s.%s", modelPackage, referencedType),
+		}
+		if fk.ReferencedSchema == table.Schema && fk.ReferencedTable == table.Table.TableName {
+			relationship.ReferencedAlias = getReferencedAlias(table.Table.TableName, relationshipName, fk.Name)
+		}
+		if !fk.IsComposite() {
+			relationship.Column = fk.Columns[0]
+			relationship.ReferencedColumn = fk.ReferencedColumns[0]
+			column, columnOK := findField(tableFields[getTableKey(table.Schema, table.Table.TableName)], relationship.Column)
+			referenced, referencedOK := findField(referencedFields, relationship.ReferencedColumn)
+			keyArray, keyOK := relationshipKeyArrays[referenced.Type]
+			if columnOK && referencedOK && keyOK && column.GooqType == referenced.GooqType {
+				keyValue, isNullable := relationshipKeyValues[column.Type]
+				if _, ok := relationshipKeyArrays[column.Type]; ok || isNullable {
+					relationship.HasLoaders = true
+					relationship.IsNullable = isNullable
+					relationship.KeyType = referenced.Type
+					relationship.KeyArray = keyArray
+					relationship.KeyValue = keyValue
+				}
+			}
+		}
+		results = append(results, relationship)
+	}
+	return results
+}
+
+// getReferencedAlias returns the alias that a table is joined to itself as,
+// which is the name of the relationship unless the relationship is named
+// after the table, e.g. for person.person_id or a composite foreign key
+func getReferencedAlias(
+	tableName, relationshipName, constraintName string,
+) string {
+	if relationshipName == tableName {
+		return constraintName
+	}
+	return relationshipName
+}
+
+func findField(
+	fields []FieldTemplateArgs, name string,
+) (FieldTemplateArgs, bool) {
+	for _, field := range fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return FieldTemplateArgs{}, false
+}
+
+func getTableKey(
+	schema, tableName string,
+) string {
+	return fmt.Sprintf("%s.%s", schema, tableName)
+}
+
+// uncountableNouns are the english nouns that have the same singular and
+// plural form, as the last word of a model type
+var uncountableNouns = []string{
+	"Data", "Equipment", "Fish", "Information", "News", "Series", "Sheep", "Species",
+}
+
+// pluralize returns the plural of the english noun name for the names of the
+// generated loaders, e.g. Person, Persons or Category, Categories. The names
+// that end with an uncountable noun are left as they are, e.g. PersonSpecies.
+func pluralize(
+	name string,
+) string {
+	for _, noun := range uncountableNouns {
+		if strings.HasSuffix(name, noun) {
+			return name
+		}
+	}
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, "s") || strings.HasSuffix(lower, "x") ||
+		strings.HasSuffix(lower, "z") || strings.HasSuffix(lower, "ch") ||
+		strings.HasSuffix(lower, "sh"):
+		return name + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 &&
+		!strings.ContainsAny(lower[len(lower)-2:len(lower)-1], "aeiou"):
+		return name[:len(name)-1] + "ies"
+	default:
+		return name + "s"
+	}
+}
+
+func getForeignKeyArgs(
+	table metadata.Table,
+) []ForeignKeyTemplateArgs {
+	var results []ForeignKeyTemplateArgs
+	for _, fk := range table.ForeignKeys {
+		results = append(results, ForeignKeyTemplateArgs{
+			Name:                fk.Name,
+			Columns:             fk.Columns,
+			ReferencedSchema:    fk.ReferencedSchema,
+			ReferencedTable:     fk.ReferencedTable,
+			ReferencedColumns:   fk.ReferencedColumns,
+			UpdateRule:          fk.UpdateRule,
+			DeleteRule:          fk.DeleteRule,
+			IsDeferrable:        fk.IsDeferrable,
+			IsInitiallyDeferred: fk.IsInitiallyDeferred,
+		})
+	}
+	return results
+}
+
+func getCheckConstraintArgs(
+	table metadata.Table,
+) ([]CheckConstraintTemplateArgs, error) {
+	var results []CheckConstraintTemplateArgs
+	for _, constraint := range table.CheckConstraints {
+		var columns []string
+		err := json.Unmarshal([]byte(constraint.Columns), &columns)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, CheckConstraintTemplateArgs{
+			Name:        constraint.ConstraintName,
+			Expression:  constraint.Expression,
+			Columns:     columns,
+			IsNoInherit: constraint.IsNoInherit,
+		})
+	}
+	return results, nil
+}
+
+func getExclusionConstraintArgs(
+	table metadata.Table,
+) ([]ExclusionConstraintTemplateArgs, error) {
+	var results []ExclusionConstraintTemplateArgs
+	for _, constraint := range table.ExclusionConstraints {
+		var expressions, operators []string
+		if err := json.Unmarshal([]byte(constraint.Elements), &expressions); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(constraint.Operators), &operators); err != nil {
+			return nil, err
+		}
+		if len(expressions) != len(operators) {
+			return nil, fmt.Errorf("exclusion constraint %s has %d elements and %d operators",
+				constraint.ConstraintName, len(expressions), len(operators))
+		}
+		var elements []ExclusionElementTemplateArgs
+		for index, expression := range expressions {
+			elements = append(elements, ExclusionElementTemplateArgs{
+				Expression: expression,
+				Operator:   operators[index],
+			})
+		}
+		results = append(results, ExclusionConstraintTemplateArgs{
+			Name:                constraint.ConstraintName,
+			IndexMethod:         constraint.IndexMethod,
+			Elements:            elements,
+			Predicate:           constraint.Predicate,
+			Definition:          constraint.Definition,
+			IsDeferrable:        constraint.IsDeferrable,
+			IsInitiallyDeferred: constraint.IsInitiallyDeferred,
+		})
+	}
+	return results, nil
+}
+
+func getRelationImpl(
+	table metadata.TableMetadata,
+) string {
+	switch table.Type {
+	case metadata.TableTypeView:
+		return "ViewImpl"
+	case metadata.TableTypeMaterializedView:
+		return "MaterializedViewImpl"
+	default:
+		return "TableImpl"
+	}
+}
+
 func isReferenceTable(
 	tableName string,
 ) bool {
@@ -183,10 +449,10 @@ func isReferenceTable(
 }
 
 func getEnumTypeFromReferenceTableName(
-	tableName string,
+	data *metadata.Data, schema, tableName string,
 ) string {
 	enumNameSnakeCase := strings.ReplaceAll(tableName, metadata.ReferenceTableSuffix, "")
-	return snaker.SnakeToCamelIdentifier(enumNameSnakeCase)
+	return data.TypeName(schema, enumNameSnakeCase)
 }
 
 func getOverrideDataType(
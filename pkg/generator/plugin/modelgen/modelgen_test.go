@@ -0,0 +1,290 @@
+package modelgen
+
+import (
+	"testing"
+
+	"github.com/lumina-tech/gooq/pkg/generator/metadata"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v3"
+)
+
+var relationshipTestFields = map[string][]FieldTemplateArgs{
+	"public.person": {
+		{Name: "id", GooqType: "UUID", Type: "uuid.UUID"},
+		{Name: "name", GooqType: "String", Type: "string"},
+		{Name: "person_id", GooqType: "UUID", Type: "nullable.UUID"},
+		{Name: "mentor_id", GooqType: "UUID", Type: "nullable.UUID"},
+		{Name: "parent_name", GooqType: "String", Type: "null.String"},
+	},
+}
+
+func getRelationshipTestArgs(
+	fks ...metadata.ForeignKey,
+) []RelationshipTemplateArgs {
+	data := &metadata.Data{Schema: "public", Schemas: []string{"public"}}
+	table := metadata.Table{
+		Schema:      "public",
+		Table:       metadata.TableMetadata{TableName: "person", Type: metadata.TableTypeBaseTable},
+		ForeignKeys: fks,
+	}
+	return getRelationshipArgs(data, table, relationshipTestFields, "model")
+}
+
+func TestSelfReferencingRelationshipArgs(t *testing.T) {
+	testCases := []struct {
+		fk            metadata.ForeignKey
+		expectedName  string
+		expectedAlias string
+	}{
+		{
+			fk: metadata.ForeignKey{
+				Name: "person_mentor_id_fkey", Columns: []string{"mentor_id"},
+				ReferencedSchema: "public", ReferencedTable: "person", ReferencedColumns: []string{"id"},
+			},
+			expectedName:  "Mentor",
+			expectedAlias: "mentor",
+		},
+		{
+			fk: metadata.ForeignKey{
+				Name: "person_person_id_fkey", Columns: []string{"person_id"},
+				ReferencedSchema: "public", ReferencedTable: "person", ReferencedColumns: []string{"id"},
+			},
+			expectedName:  "PersonPersonIDFkey",
+			expectedAlias: "person_person_id_fkey",
+		},
+		{
+			fk: metadata.ForeignKey{
+				Name: "person_parent_fkey", Columns: []string{"person_id", "parent_name"},
+				ReferencedSchema: "public", ReferencedTable: "person", ReferencedColumns: []string{"id", "name"},
+			},
+			expectedName:  "PersonParentFkey",
+			expectedAlias: "person_parent_fkey",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.fk.Name, func(t *testing.T) {
+			relationships := getRelationshipTestArgs(testCase.fk)
+			require.Len(t, relationships, 1)
+			require.Equal(t, testCase.expectedName, relationships[0].Name)
+			require.Equal(t, testCase.expectedAlias, relationships[0].ReferencedAlias)
+			require.Equal(t, "Person", relationships[0].ReferencedSingletonName)
+		})
+	}
+}
+
+func TestPluralize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{"Person", "Persons"},
+		{"Weapon", "Weapons"},
+		{"Species", "Species"},
+		{"Status", "Statuses"},
+		{"Box", "Boxes"},
+		{"Match", "Matches"},
+		{"Wish", "Wishes"},
+		{"Category", "Categories"},
+		{"Company", "Companies"},
+		{"CountryCity", "CountryCities"},
+		{"Day", "Days"},
+		{"Y", "Ys"},
+		{"PersonSpecies", "PersonSpecies"},
+		{"TimeSeries", "TimeSeries"},
+		{"News", "News"},
+		{"Selfish", "Selfishes"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expected, pluralize(testCase.name))
+		})
+	}
+}
+
+func TestRelationshipArgs(t *testing.T) {
+	data := &metadata.Data{Schema: "public", Schemas: []string{"public"}}
+	tableFields := map[string][]FieldTemplateArgs{
+		"public.person": {
+			{Name: "id", GooqType: "UUID", Type: "uuid.UUID"},
+			{Name: "species_id", GooqType: "UUID", Type: "uuid.UUID"},
+			{Name: "home_species_id", GooqType: "UUID", Type: "nullable.UUID"},
+			{Name: "planet_name", GooqType: "String", Type: "string"},
+			{Name: "planet_system", GooqType: "String", Type: "string"},
+			{Name: "birth_day", GooqType: "Time", Type: "time.Time"},
+			{Name: "weapon_code", GooqType: "Int", Type: "int64"},
+			{Name: "color", GooqType: "String", Type: "string"},
+		},
+		"public.species":               {{Name: "id", GooqType: "UUID", Type: "uuid.UUID"}},
+		"public.planet":                {{Name: "name", GooqType: "String", Type: "string"}, {Name: "system", GooqType: "String", Type: "string"}},
+		"public.day":                   {{Name: "day", GooqType: "Time", Type: "time.Time"}},
+		"public.weapon":                {{Name: "code", GooqType: "String", Type: "string"}},
+		"public.color_reference_table": {{Name: "value", GooqType: "String", Type: "string"}},
+	}
+	table := metadata.Table{
+		Schema: "public",
+		Table:  metadata.TableMetadata{TableName: "person", Type: metadata.TableTypeBaseTable},
+		ForeignKeys: []metadata.ForeignKey{
+			{
+				Name: "person_species_id_fkey", Columns: []string{"species_id"},
+				ReferencedSchema: "public", ReferencedTable: "species", ReferencedColumns: []string{"id"},
+			},
+			{
+				Name: "person_home_species_id_fkey", Columns: []string{"home_species_id"},
+				ReferencedSchema: "public", ReferencedTable: "species", ReferencedColumns: []string{"id"},
+			},
+			{
+				Name: "person_planet_fkey", Columns: []string{"planet_name", "planet_system"},
+				ReferencedSchema: "public", ReferencedTable: "planet", ReferencedColumns: []string{"name", "system"},
+			},
+			{
+				Name: "person_birth_planet_fkey", Columns: []string{"planet_name", "planet_system"},
+				ReferencedSchema: "public", ReferencedTable: "planet", ReferencedColumns: []string{"name", "system"},
+			},
+			{
+				Name: "person_birth_day_fkey", Columns: []string{"birth_day"},
+				ReferencedSchema: "public", ReferencedTable: "day", ReferencedColumns: []string{"day"},
+			},
+			{
+				Name: "person_weapon_code_fkey", Columns: []string{"weapon_code"},
+				ReferencedSchema: "public", ReferencedTable: "weapon", ReferencedColumns: []string{"code"},
+			},
+			{
+				Name: "person_color_fkey", Columns: []string{"color"},
+				ReferencedSchema: "public", ReferencedTable: "color_reference_table", ReferencedColumns: []string{"value"},
+			},
+			{
+				Name: "person_vehicle_id_fkey", Columns: []string{"vehicle_id"},
+				ReferencedSchema: "public", ReferencedTable: "vehicle", ReferencedColumns: []string{"id"},
+			},
+		},
+	}
+	relationships := getRelationshipArgs(data, table, tableFields, "model")
+	require.Equal(t, []RelationshipTemplateArgs{
+		{
+			Name: "Species", JSONName: "species", ConstraintName: "person_species_id_fkey",
+			Columns: []string{"species_id"}, ReferencedTableName: "species", ReferencedSingletonName: "Species",
+			ReferencedColumns: []string{"id"}, ReferencedModelType: "model.Species",
+			Column: "species_id", ReferencedColumn: "id",
+			HasLoaders: true, KeyType: "uuid.UUID", KeyArray: "gooq.UUIDArray",
+		},
+		{
+			Name: "HomeSpecies", JSONName: "home_species", ConstraintName: "person_home_species_id_fkey",
+			Columns: []string{"home_species_id"}, ReferencedTableName: "species", ReferencedSingletonName: "Species",
+			ReferencedColumns: []string{"id"}, ReferencedModelType: "model.Species",
+			Column: "home_species_id", ReferencedColumn: "id",
+			HasLoaders: true, IsNullable: true, KeyType: "uuid.UUID", KeyArray: "gooq.UUIDArray", KeyValue: ".UUID",
+		},
+		{
+			Name: "Planet", JSONName: "planet", ConstraintName: "person_planet_fkey",
+			Columns: []string{"planet_name", "planet_system"}, ReferencedTableName: "planet", ReferencedSingletonName: "Planet",
+			ReferencedColumns: []string{"name", "system"}, ReferencedModelType: "model.Planet",
+		},
+		{
+			Name: "PersonBirthPlanetFkey", JSONName: "person_birth_planet_fkey", ConstraintName: "person_birth_planet_fkey",
+			Columns: []string{"planet_name", "planet_system"}, ReferencedTableName: "planet", ReferencedSingletonName: "Planet",
+			ReferencedColumns: []string{"name", "system"}, ReferencedModelType: "model.Planet",
+		},
+		{
+			Name: "BirthDay", JSONName: "birth_day", ConstraintName: "person_birth_day_fkey",
+			Columns: []string{"birth_day"}, ReferencedTableName: "day", ReferencedSingletonName: "Day",
+			ReferencedColumns: []string{"day"}, ReferencedModelType: "model.Day",
+			Column: "birth_day", ReferencedColumn: "day",
+		},
+		{
+			Name: "WeaponCode", JSONName: "weapon_code", ConstraintName: "person_weapon_code_fkey",
+			Columns: []string{"weapon_code"}, ReferencedTableName: "weapon", ReferencedSingletonName: "Weapon",
+			ReferencedColumns: []string{"code"}, ReferencedModelType: "model.Weapon",
+			Column: "weapon_code", ReferencedColumn: "code",
+		},
+	}, relationships)
+}
+
+func TestCrossSchemaRelationshipArgs(t *testing.T) {
+	tableFields := map[string][]FieldTemplateArgs{
+		"public.person":   {{Name: "invoice_id", GooqType: "Int", Type: "int"}},
+		"billing.invoice": {{Name: "id", GooqType: "Int", Type: "int"}},
+	}
+	table := metadata.Table{
+		Schema: "public",
+		Table:  metadata.TableMetadata{TableName: "person", Type: metadata.TableTypeBaseTable},
+		ForeignKeys: []metadata.ForeignKey{{
+			Name: "person_invoice_id_fkey", Columns: []string{"invoice_id"},
+			ReferencedSchema: "billing", ReferencedTable: "invoice", ReferencedColumns: []string{"id"},
+		}},
+	}
+
+	// the referenced table is prefixed by its schema in a combined package
+	data := &metadata.Data{Schemas: []string{"public", "billing"}}
+	relationships := getRelationshipArgs(data, table, tableFields, "model")
+	require.Len(t, relationships, 1)
+	require.Equal(t, "BillingInvoice", relationships[0].ReferencedSingletonName)
+	require.Equal(t, "model.BillingInvoice", relationships[0].ReferencedModelType)
+
+	// the referenced table is in another package in a package per schema
+	data = &metadata.Data{Schema: "public", Schemas: []string{"public", "billing"}, PackagePerSchema: true}
+	require.Empty(t, getRelationshipArgs(data, table, tableFields, "public"))
+}
+
+func TestCheckConstraintArgs(t *testing.T) {
+	table := metadata.Table{
+		CheckConstraints: []metadata.CheckConstraintMetadata{
+			{ConstraintName: "weapon_damage_check", Expression: "damage > 0", Columns: `["damage"]`},
+			{ConstraintName: "weapon_range_check", Expression: "min_range <= max_range",
+				Columns: `["min_range", "max_range"]`, IsNoInherit: true},
+			{ConstraintName: "weapon_check", Expression: "false", Columns: `[]`},
+		},
+	}
+	constraints, err := getCheckConstraintArgs(table)
+	require.NoError(t, err)
+	require.Equal(t, []CheckConstraintTemplateArgs{
+		{Name: "weapon_damage_check", Expression: "damage > 0", Columns: []string{"damage"}},
+		{Name: "weapon_range_check", Expression: "min_range <= max_range",
+			Columns: []string{"min_range", "max_range"}, IsNoInherit: true},
+		{Name: "weapon_check", Expression: "false", Columns: []string{}},
+	}, constraints)
+
+	table.CheckConstraints[0].Columns = `damage`
+	_, err = getCheckConstraintArgs(table)
+	require.Error(t, err)
+}
+
+func TestExclusionConstraintArgs(t *testing.T) {
+	table := metadata.Table{
+		ExclusionConstraints: []metadata.ExclusionConstraintMetadata{
+			{
+				ConstraintName: "booking_room_during_excl",
+				IndexMethod:    "gist",
+				Elements:       `["room", "during"]`,
+				Operators:      `["=", "&&"]`,
+				Predicate:      null.StringFrom("NOT cancelled"),
+				Definition:     "EXCLUDE USING gist (room WITH =, during WITH &&) WHERE (NOT cancelled)",
+				IsDeferrable:   true,
+			},
+		},
+	}
+	constraints, err := getExclusionConstraintArgs(table)
+	require.NoError(t, err)
+	require.Equal(t, []ExclusionConstraintTemplateArgs{
+		{
+			Name:        "booking_room_during_excl",
+			IndexMethod: "gist",
+			Elements: []ExclusionElementTemplateArgs{
+				{Expression: "room", Operator: "="},
+				{Expression: "during", Operator: "&&"},
+			},
+			Predicate:    null.StringFrom("NOT cancelled"),
+			Definition:   "EXCLUDE USING gist (room WITH =, during WITH &&) WHERE (NOT cancelled)",
+			IsDeferrable: true,
+		},
+	}, constraints)
+
+	table.ExclusionConstraints[0].Operators = `["="]`
+	_, err = getExclusionConstraintArgs(table)
+	require.EqualError(t, err, "exclusion constraint booking_room_during_excl has 2 elements and 1 operators")
+	table.ExclusionConstraints[0].Operators = `=`
+	_, err = getExclusionConstraintArgs(table)
+	require.Error(t, err)
+	table.ExclusionConstraints[0].Elements = `room`
+	_, err = getExclusionConstraintArgs(table)
+	require.Error(t, err)
+}
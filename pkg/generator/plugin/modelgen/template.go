@@ -33,8 +33,14 @@ type {{ $table.TableType }}Constraints struct {
   {{ end }}
 }
 
+{{ if $table.IsMaterializedView -}}
+// {{ $table.TableType }} is a materialized view, it can be refreshed with
+// gooq.RefreshMaterializedView but not modified
+{{ else if $table.IsView -}}
+// {{ $table.TableType }} is a view, it cannot be modified
+{{ end -}}
 type {{ $table.TableType }} struct {
-	gooq.TableImpl
+	gooq.{{ $table.RelationImpl }}
 	Asterisk gooq.StringField
   {{ range $_, $f := $table.Fields -}}
   {{ snakeToCamel $f.Name }} gooq.{{ $f.GooqType }}Field
@@ -60,7 +66,7 @@ func new{{ capitalize $table.TableType }}Constraints(
 
 func new{{ capitalize $table.TableType }}() *{{ $table.TableType }} {
   instance := &{{ $table.TableType }}{}
-	instance.Initialize("{{ $schema }}", "{{ $table.TableName }}")
+	instance.{{ $table.RelationImpl }}.Initialize("{{ $table.Schema }}", "{{ $table.TableName }}")
 	instance.Asterisk = gooq.NewStringField(instance, "*")
   {{ range $_, $f := $table.Fields -}}
   instance.{{ snakeToCamelID $f.Name }} = gooq.New{{ $f.GooqType }}Field(instance, "{{ $f.Name }}")
@@ -71,7 +77,7 @@ func new{{ capitalize $table.TableType }}() *{{ $table.TableType }} {
 
 func (t *{{ $table.TableType }}) As(alias string) *{{ $table.TableType }} {
   instance := new{{ $table.ModelType }}()
-  instance.TableImpl = *instance.TableImpl.As(alias)
+  instance.{{ $table.RelationImpl }} = *instance.{{ $table.RelationImpl }}.As(alias)
   return instance
 }
 
@@ -124,5 +130,125 @@ func (t *{{ $table.TableType }}) ScanRowsWithContext(
 }
 
 var {{ $table.TableSingletonName }} = new{{ capitalize $table.TableType }}()
+
+{{ range $_, $r := $table.Relationships -}}
+// Join{{ $r.Name }} joins the {{ $r.ReferencedTableName }} referenced by {{ $r.ConstraintName }}, e.g.
+// gooq.Select().From({{ $table.TableSingletonName }}).JoinRelationship({{ $table.TableSingletonName }}.Join{{ $r.Name }}())
+func (t *{{ $table.TableType }}) Join{{ $r.Name }}() gooq.Relationship {
+  {{ if $r.ReferencedAlias -}}
+  referenced := {{ $r.ReferencedSingletonName }}.As("{{ $r.ReferencedAlias }}")
+  {{ else -}}
+  referenced := {{ $r.ReferencedSingletonName }}
+  {{ end -}}
+  return gooq.ForeignKey{
+    Name: "{{ $r.ConstraintName }}",
+    Table: t,
+    Columns: []gooq.Field{
+      {{ range $r.Columns -}}t.{{ snakeToCamelID . }},{{ end }}
+    },
+    ReferencedTable: referenced,
+    ReferencedColumns: []gooq.Field{
+      {{ range $r.ReferencedColumns -}}referenced.{{ snakeToCamelID . }},{{ end }}
+    },
+  }.Join()
+}
+
+{{ if $r.HasLoaders -}}
+// Load{{ $table.PluralModelType }}For{{ $r.Name }} loads the {{ $table.TableName }} rows that reference keys
+// by {{ $r.ConstraintName }} with a single query, grouped by key
+func Load{{ $table.PluralModelType }}For{{ $r.Name }}(
+  ctx context.Context, db gooq.DBInterface, keys []{{ $r.KeyType }},
+) (map[{{ $r.KeyType }}][]{{ $table.QualifiedModelType }}, error) {
+  results := make(map[{{ $r.KeyType }}][]{{ $table.QualifiedModelType }}, len(keys))
+  if len(keys) == 0 {
+    return results, nil
+  }
+  rows, err := {{ $table.TableSingletonName }}.ScanRowsWithContext(ctx, db, gooq.Select().From({{ $table.TableSingletonName }}).
+    Where({{ $table.TableSingletonName }}.{{ snakeToCamelID $r.Column }}.Eq({{ $r.KeyArray }}(keys...).Any())))
+  if err != nil {
+    return nil, err
+  }
+  for _, row := range rows {
+    key := {{ $r.KeyType }}(row.{{ snakeToCamelID $r.Column }}{{ $r.KeyValue }})
+    results[key] = append(results[key], row)
+  }
+  return results, nil
+}
+
+// Load{{ $r.Name }}For{{ $table.PluralModelType }} loads the {{ $r.ReferencedTableName }} rows that rows reference
+// by {{ $r.ConstraintName }} with a single query, by key
+func Load{{ $r.Name }}For{{ $table.PluralModelType }}(
+  ctx context.Context, db gooq.DBInterface, rows []{{ $table.QualifiedModelType }},
+) (map[{{ $r.KeyType }}]{{ $r.ReferencedModelType }}, error) {
+  keys := make([]{{ $r.KeyType }}, 0, len(rows))
+  for _, row := range rows {
+    {{ if $r.IsNullable -}}
+    if !row.{{ snakeToCamelID $r.Column }}.Valid {
+      continue
+    }
+    {{ end -}}
+    keys = append(keys, {{ $r.KeyType }}(row.{{ snakeToCamelID $r.Column }}{{ $r.KeyValue }}))
+  }
+  results := make(map[{{ $r.KeyType }}]{{ $r.ReferencedModelType }}, len(keys))
+  if len(keys) == 0 {
+    return results, nil
+  }
+  referenced, err := {{ $r.ReferencedSingletonName }}.ScanRowsWithContext(ctx, db, gooq.Select().From({{ $r.ReferencedSingletonName }}).
+    Where({{ $r.ReferencedSingletonName }}.{{ snakeToCamelID $r.ReferencedColumn }}.Eq({{ $r.KeyArray }}(keys...).Any())))
+  if err != nil {
+    return nil, err
+  }
+  for _, row := range referenced {
+    results[row.{{ snakeToCamelID $r.ReferencedColumn }}] = row
+  }
+  return results, nil
+}
+
+{{ end -}}
+{{ end -}}
+
+{{ if $table.HasReferenceLoaders -}}
+// {{ $table.ModelType }}WithReferences is a {{ $table.TableName }} row together with the rows
+// that it references
+type {{ $table.ModelType }}WithReferences struct {
+  {{ $table.QualifiedModelType }}
+  {{ range $_, $r := $table.Relationships -}}
+  {{ if $r.HasLoaders -}}
+  {{ $r.Name }} *{{ $r.ReferencedModelType }} ` + "`json:\"{{ $r.JSONName }},omitempty\"`" + `
+  {{ end -}}
+  {{ end }}
+}
+
+// Load{{ $table.ModelType }}References loads the rows that rows reference with a query per
+// foreign key rather than per row
+func Load{{ $table.ModelType }}References(
+  ctx context.Context, db gooq.DBInterface, rows []{{ $table.QualifiedModelType }},
+) ([]{{ $table.ModelType }}WithReferences, error) {
+  results := make([]{{ $table.ModelType }}WithReferences, len(rows))
+  for index := range rows {
+    results[index].{{ $table.ModelType }} = rows[index]
+  }
+  {{ range $_, $r := $table.Relationships -}}
+  {{ if $r.HasLoaders -}}
+  referenced{{ $r.Name }}, err := Load{{ $r.Name }}For{{ $table.PluralModelType }}(ctx, db, rows)
+  if err != nil {
+    return nil, err
+  }
+  for index := range results {
+    result := &results[index]
+    {{ if $r.IsNullable -}}
+    if !result.{{ snakeToCamelID $r.Column }}.Valid {
+      continue
+    }
+    {{ end -}}
+    if row, ok := referenced{{ $r.Name }}[{{ $r.KeyType }}(result.{{ snakeToCamelID $r.Column }}{{ $r.KeyValue }})]; ok {
+      result.{{ $r.Name }} = &row
+    }
+  }
+  {{ end -}}
+  {{ end -}}
+  return results, nil
+}
+{{ end -}}
 {{ end }}
 `
@@ -25,6 +25,7 @@ type TemplateArgs struct {
 }
 
 type TableTemplateArgs struct {
+	Schema                 string
 	TableName              string
 	TableType              string
 	TableSingletonName     string
@@ -32,9 +33,22 @@ type TableTemplateArgs struct {
 	QualifiedModelType     string
 	ReferenceTableEnumType string
 	IsReferenceTable       bool
-	Fields                 []FieldTemplateArgs
-	Constraints            []ConstraintTemplateArgs
-	ForeignKeyConstraints  []ForeignKeyConstraintTemplateArgs
+	// RelationImpl is the gooq type embedded by the table type, views embed
+	// ViewImpl or MaterializedViewImpl so that they cannot be modified
+	RelationImpl          string
+	IsView                bool
+	IsMaterializedView    bool
+	Fields                []FieldTemplateArgs
+	Constraints           []ConstraintTemplateArgs
+	ForeignKeyConstraints []ForeignKeyConstraintTemplateArgs
+	ForeignKeys           []ForeignKeyTemplateArgs
+	CheckConstraints      []CheckConstraintTemplateArgs
+	ExclusionConstraints  []ExclusionConstraintTemplateArgs
+	// Relationships are the foreign keys of the table that reference one of
+	// the other generated tables, PluralModelType names their loaders
+	Relationships       []RelationshipTemplateArgs
+	PluralModelType     string
+	HasReferenceLoaders bool
 }
 
 type ConstraintTemplateArgs struct {
@@ -44,10 +58,82 @@ type ConstraintTemplateArgs struct {
 }
 
 type ForeignKeyConstraintTemplateArgs struct {
-	Name              string
-	ColumnName        string
-	ForeignTableName  string
-	ForeignColumnName string
+	Name               string
+	ColumnName         string
+	OrdinalPosition    int
+	ForeignTableSchema string
+	ForeignTableName   string
+	ForeignColumnName  string
+}
+
+// ForeignKeyTemplateArgs is a foreign key whose Columns reference the
+// ReferencedColumns in order, UpdateRule and DeleteRule are the referential
+// actions of metadata.ForeignKeyAction*
+type ForeignKeyTemplateArgs struct {
+	Name                string
+	Columns             []string
+	ReferencedSchema    string
+	ReferencedTable     string
+	ReferencedColumns   []string
+	UpdateRule          string
+	DeleteRule          string
+	IsDeferrable        bool
+	IsInitiallyDeferred bool
+}
+
+type CheckConstraintTemplateArgs struct {
+	Name        string
+	Expression  string
+	Columns     []string
+	IsNoInherit bool
+}
+
+// ExclusionConstraintTemplateArgs is an EXCLUDE constraint whose Elements
+// are compared with their operators, Definition is the constraint as it is
+// declared, e.g. EXCLUDE USING gist (room WITH =, during WITH &&)
+type ExclusionConstraintTemplateArgs struct {
+	Name                string
+	IndexMethod         string
+	Elements            []ExclusionElementTemplateArgs
+	Predicate           null.String
+	Definition          string
+	IsDeferrable        bool
+	IsInitiallyDeferred bool
+}
+
+type ExclusionElementTemplateArgs struct {
+	Expression string
+	Operator   string
+}
+
+// RelationshipTemplateArgs is a foreign key from the Columns to the
+// ReferencedColumns of the referenced table. Its Name is the name of the
+// column without the _id suffix, e.g. Species for species_id, or the name of
+// the referenced table for composite foreign keys. Self references join the
+// referenced table as ReferencedAlias.
+type RelationshipTemplateArgs struct {
+	Name                    string
+	JSONName                string
+	ConstraintName          string
+	Columns                 []string
+	ReferencedTableName     string
+	ReferencedSingletonName string
+	ReferencedAlias         string
+	ReferencedColumns       []string
+	ReferencedModelType     string
+	// Column and ReferencedColumn are the columns of a single column foreign
+	// key, which has loaders when the column values can be collected into a
+	// KeyArray of KeyType, i.e. the referenced column is a uuid, string or
+	// integer column that is not nullable. The key of a row is
+	// KeyType(row.Column + KeyValue), e.g. uuid.UUID(row.WeaponID.UUID) for a
+	// nullable column.
+	Column           string
+	ReferencedColumn string
+	HasLoaders       bool
+	IsNullable       bool
+	KeyType          string
+	KeyArray         string
+	KeyValue         string
 }
 
 type FieldTemplateArgs struct {
@@ -0,0 +1,202 @@
+package routinegen
+
+import (
+	"fmt"
+	"go/token"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/knq/snaker"
+	"github.com/lumina-tech/gooq/pkg/generator/metadata"
+	"github.com/lumina-tech/gooq/pkg/generator/utils"
+)
+
+var plainIdentifier = regexp.MustCompile(`^[a-z_][a-z0-9_$]*$`)
+
+// expressionTypes maps the names of the data types to the gooq expression
+// types of their values
+var expressionTypes = map[string]string{
+	metadata.DataTypeBool.Name:        "BoolExpression",
+	metadata.DataTypeFloat64.Name:     "NumericExpression",
+	metadata.DataTypeInt.Name:         "NumericExpression",
+	metadata.DataTypeBigInt.Name:      "NumericExpression",
+	metadata.DataTypeBigFloat.Name:    "NumericExpression",
+	metadata.DataTypeJSONB.Name:       "JsonbExpression",
+	metadata.DataTypeString.Name:      "StringExpression",
+	metadata.DataTypeStringArray.Name: "StringArrayExpression",
+	metadata.DataTypeInterval.Name:    "IntervalExpression",
+	metadata.DataTypeIntArray.Name:    "NumericArrayExpression",
+	metadata.DataTypeUUIDArray.Name:   "UUIDArrayExpression",
+	metadata.DataTypeTime.Name:        "DateTimeExpression",
+	metadata.DataTypeUUID.Name:        "UUIDExpression",
+}
+
+// RoutineGenerator generates the bindings of the functions and procedures:
+// scalar functions are expression constructors, the functions that return
+// rows are table functions that can be selected from and procedures return
+// a CALL statement
+type RoutineGenerator struct {
+	outputFile  string
+	packageName string
+}
+
+func NewRoutineGenerator(
+	outputFile, packageName string,
+) *RoutineGenerator {
+	return &RoutineGenerator{
+		outputFile:  outputFile,
+		packageName: packageName,
+	}
+}
+
+func (gen *RoutineGenerator) GenerateCode(
+	data *metadata.Data,
+) error {
+	args := templateArgs{
+		Timestamp: time.Now().Format(time.RFC3339),
+		Package:   gen.packageName,
+	}
+	functionNames := getFunctionNames(data)
+	for index := range data.Routines {
+		routine := &data.Routines[index]
+		functionName := functionNames[index]
+		parameters := getParameterArgs(data, routine)
+		switch {
+		case routine.Routine.RoutineType == metadata.RoutineTypeProcedure:
+			args.Procedures = append(args.Procedures, functionTemplateArgs{
+				Name:         getRoutineName(routine),
+				FunctionName: functionName,
+				Parameters:   parameters,
+			})
+		case routine.ReturnsRows():
+			fields, ok := getFieldArgs(data, routine)
+			if !ok {
+				// a column of the result has a type that has no field
+				continue
+			}
+			args.TableFunctions = append(args.TableFunctions, tableFunctionTemplateArgs{
+				Schema:       routine.Schema,
+				Name:         routine.Routine.RoutineName,
+				FunctionName: functionName,
+				TableType:    snaker.ForceLowerCamelIdentifier(functionName) + "Function",
+				Parameters:   parameters,
+				Fields:       fields,
+			})
+		default:
+			args.Functions = append(args.Functions, functionTemplateArgs{
+				Name:           getRoutineName(routine),
+				FunctionName:   functionName,
+				Parameters:     parameters,
+				ExpressionType: getExpressionType(data, routine.Routine.DataType, routine.Routine.UserDefinedTypeName),
+			})
+		}
+	}
+	routineTemplate := utils.GetTemplate(routineTemplate)
+	return utils.RenderToFile(routineTemplate, gen.outputFile, args)
+}
+
+// getFunctionNames returns the names of the bindings of the routines of
+// data. The bindings are generated into the package of the tables, so the
+// routines that are named like a table are suffixed with Fn, e.g. PeopleFn
+// for the function people next to the table people. Overloaded routines are
+// numbered in the order of their specific names, e.g. Add and Add2.
+func getFunctionNames(
+	data *metadata.Data,
+) []string {
+	tableNames := make(map[string]bool)
+	for _, table := range data.Tables {
+		tableName := data.TypeName(table.Schema, table.Table.TableName)
+		tableNames[tableName] = true
+		tableNames[snaker.ForceLowerCamelIdentifier(
+			data.QualifiedName(table.Schema, table.Table.TableName))] = true
+	}
+	var results []string
+	overloads := make(map[string]int)
+	for index := range data.Routines {
+		routine := &data.Routines[index]
+		functionName := data.TypeName(routine.Schema, routine.Routine.RoutineName)
+		if tableNames[functionName] {
+			functionName += "Fn"
+		}
+		overloads[functionName]++
+		if count := overloads[functionName]; count > 1 {
+			functionName = fmt.Sprintf("%s%d", functionName, count)
+		}
+		results = append(results, functionName)
+	}
+	return results
+}
+
+// getRoutineName returns the schema qualified name of a routine as it is
+// rendered in a statement
+func getRoutineName(
+	routine *metadata.Routine,
+) string {
+	return fmt.Sprintf("%s.%s", quoteIdentifier(routine.Schema),
+		quoteIdentifier(routine.Routine.RoutineName))
+}
+
+func quoteIdentifier(
+	name string,
+) string {
+	if plainIdentifier.MatchString(name) {
+		return name
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func getParameterArgs(
+	data *metadata.Data, routine *metadata.Routine,
+) []parameterTemplateArgs {
+	var results []parameterTemplateArgs
+	for index, parameter := range routine.InputParameters() {
+		name := snaker.ForceLowerCamelIdentifier(parameter.ParameterName)
+		if parameter.ParameterName == "" {
+			name = fmt.Sprintf("arg%d", index+1)
+		} else if token.IsKeyword(name) || name == "gooq" {
+			name += "Arg"
+		}
+		results = append(results, parameterTemplateArgs{
+			Name:           name,
+			IsOut:          parameter.ParameterMode == metadata.ParameterModeOut,
+			ExpressionType: getExpressionType(data, parameter.DataType, parameter.UserDefinedTypeName),
+		})
+	}
+	return results
+}
+
+func getFieldArgs(
+	data *metadata.Data, routine *metadata.Routine,
+) ([]fieldTemplateArgs, bool) {
+	var results []fieldTemplateArgs
+	for _, column := range routine.Columns {
+		dataType, err := data.Loader.GetDataType(column)
+		if err != nil {
+			return nil, false
+		}
+		results = append(results, fieldTemplateArgs{
+			Name:     column.ColumnName,
+			GooqType: dataType.Name,
+		})
+	}
+	return results, true
+}
+
+// getExpressionType returns the gooq expression type of the values of a data
+// type, or Expression for the types that have none (e.g. void or record)
+func getExpressionType(
+	data *metadata.Data, dataType, userDefinedTypeName string,
+) string {
+	typ, err := data.Loader.GetDataType(metadata.ColumnMetadata{
+		DataType:            dataType,
+		UserDefinedTypeName: userDefinedTypeName,
+	})
+	if err != nil {
+		return "Expression"
+	}
+	if expressionType, ok := expressionTypes[typ.Name]; ok {
+		return expressionType
+	}
+	return "Expression"
+}
@@ -0,0 +1,103 @@
+package routinegen
+
+import (
+	"testing"
+
+	"github.com/lumina-tech/gooq/pkg/generator/metadata"
+	"github.com/lumina-tech/gooq/pkg/generator/postgres"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRoutine(
+	schema, name, routineType string, parameters ...metadata.ParameterMetadata,
+) metadata.Routine {
+	return metadata.Routine{
+		Schema: schema,
+		Routine: metadata.RoutineMetadata{
+			SpecificName: name,
+			RoutineName:  name,
+			RoutineType:  routineType,
+		},
+		Parameters: parameters,
+	}
+}
+
+func TestGetFunctionNames(t *testing.T) {
+	data := &metadata.Data{
+		Schema:  "public",
+		Schemas: []string{"public"},
+		Tables: []metadata.Table{
+			{Schema: "public", Table: metadata.TableMetadata{TableName: "people"}},
+		},
+		Routines: []metadata.Routine{
+			newTestRoutine("public", "add", metadata.RoutineTypeFunction),
+			newTestRoutine("public", "add", metadata.RoutineTypeFunction),
+			newTestRoutine("public", "people", metadata.RoutineTypeFunction),
+			newTestRoutine("public", "people", metadata.RoutineTypeFunction),
+			newTestRoutine("public", "archive_people", metadata.RoutineTypeProcedure),
+		},
+	}
+	require.Equal(t, []string{"Add", "Add2", "PeopleFn", "PeopleFn2", "ArchivePeople"},
+		getFunctionNames(data))
+
+	data.Schemas = []string{"public", "billing"}
+	data.Tables = append(data.Tables, metadata.Table{
+		Schema: "billing", Table: metadata.TableMetadata{TableName: "invoice"},
+	})
+	data.Routines = []metadata.Routine{
+		newTestRoutine("billing", "invoice", metadata.RoutineTypeFunction),
+		newTestRoutine("public", "invoice", metadata.RoutineTypeFunction),
+	}
+	require.Equal(t, []string{"BillingInvoiceFn", "Invoice"}, getFunctionNames(data))
+}
+
+func TestGetParameterArgs(t *testing.T) {
+	data := &metadata.Data{Loader: postgres.NewPostgresLoader()}
+	testCases := []struct {
+		routine  metadata.Routine
+		expected []parameterTemplateArgs
+	}{
+		{
+			routine: newTestRoutine("public", "add", metadata.RoutineTypeFunction,
+				metadata.ParameterMetadata{ParameterName: "", ParameterMode: metadata.ParameterModeIn, DataType: "integer"},
+				metadata.ParameterMetadata{ParameterName: "", ParameterMode: metadata.ParameterModeIn, DataType: "integer"}),
+			expected: []parameterTemplateArgs{
+				{Name: "arg1", ExpressionType: "NumericExpression"},
+				{Name: "arg2", ExpressionType: "NumericExpression"},
+			},
+		},
+		{
+			routine: newTestRoutine("public", "find", metadata.RoutineTypeFunction,
+				metadata.ParameterMetadata{ParameterName: "type", ParameterMode: metadata.ParameterModeIn, DataType: "text"},
+				metadata.ParameterMetadata{ParameterName: "gooq", ParameterMode: metadata.ParameterModeIn, DataType: "uuid"},
+				metadata.ParameterMetadata{ParameterName: "person_id", ParameterMode: metadata.ParameterModeIn, DataType: "tsvector"},
+				metadata.ParameterMetadata{ParameterName: "total", ParameterMode: metadata.ParameterModeOut, DataType: "bigint"}),
+			expected: []parameterTemplateArgs{
+				{Name: "typeArg", ExpressionType: "StringExpression"},
+				{Name: "gooqArg", ExpressionType: "UUIDExpression"},
+				{Name: "personID", ExpressionType: "Expression"},
+			},
+		},
+		{
+			routine: newTestRoutine("public", "count_people", metadata.RoutineTypeProcedure,
+				metadata.ParameterMetadata{ParameterName: "min_age", ParameterMode: metadata.ParameterModeIn, DataType: "integer"},
+				metadata.ParameterMetadata{ParameterName: "total", ParameterMode: metadata.ParameterModeOut, DataType: "bigint"}),
+			expected: []parameterTemplateArgs{
+				{Name: "minAge", ExpressionType: "NumericExpression"},
+				{Name: "total", IsOut: true, ExpressionType: "NumericExpression"},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.routine.Routine.RoutineName, func(t *testing.T) {
+			require.Equal(t, testCase.expected, getParameterArgs(data, &testCase.routine))
+		})
+	}
+}
+
+func TestGetRoutineName(t *testing.T) {
+	routine := newTestRoutine("public", "add", metadata.RoutineTypeFunction)
+	require.Equal(t, "public.add", getRoutineName(&routine))
+	routine = newTestRoutine("Billing", `say "hi"`, metadata.RoutineTypeFunction)
+	require.Equal(t, `"Billing"."say ""hi"""`, getRoutineName(&routine))
+}
@@ -0,0 +1,80 @@
+package routinegen
+
+const routineTemplate = `
+// THIS FILE WAS AUTOGENERATED - ANY EDITS TO THIS WILL BE LOST WHEN IT IS REGENERATED
+
+package {{ .Package }}
+
+import "github.com/lumina-tech/gooq/pkg/gooq"
+
+{{ range $_, $function := .Functions -}}
+func {{ $function.FunctionName }}(
+  {{ range $_, $p := $function.Parameters -}}
+  {{ $p.Name }} gooq.{{ $p.ExpressionType }},
+  {{ end -}}
+) gooq.{{ $function.ExpressionType }} {
+  {{ if eq $function.ExpressionType "Expression" -}}
+  return gooq.NewExpressionFunction({{ printf "%q" $function.Name }},
+  {{- else -}}
+  return gooq.New{{ $function.ExpressionType }}Function({{ printf "%q" $function.Name }},
+  {{- end -}}
+  {{ range $_, $p := $function.Parameters }} {{ $p.Name }},{{ end }})
+}
+
+{{ end -}}
+
+{{ range $_, $function := .TableFunctions -}}
+type {{ $function.TableType }} struct {
+  gooq.TableFunctionImpl
+  {{ range $_, $f := $function.Fields -}}
+  {{ snakeToCamelID $f.Name }} gooq.{{ $f.GooqType }}Field
+  {{ end }}
+}
+
+func new{{ capitalize $function.TableType }}(
+  impl gooq.TableFunctionImpl,
+) *{{ $function.TableType }} {
+  instance := &{{ $function.TableType }}{TableFunctionImpl: impl}
+  {{ range $_, $f := $function.Fields -}}
+  instance.{{ snakeToCamelID $f.Name }} = gooq.New{{ $f.GooqType }}Field(instance, "{{ $f.Name }}")
+  {{ end -}}
+  return instance
+}
+
+func {{ $function.FunctionName }}(
+  {{ range $_, $p := $function.Parameters -}}
+  {{ $p.Name }} gooq.{{ $p.ExpressionType }},
+  {{ end -}}
+) *{{ $function.TableType }} {
+  return new{{ capitalize $function.TableType }}(*gooq.NewTableFunction("{{ $function.Schema }}", "{{ $function.Name }}",
+  {{- range $_, $p := $function.Parameters }} {{ $p.Name }},{{ end }}))
+}
+
+func (t *{{ $function.TableType }}) As(alias string) *{{ $function.TableType }} {
+  return new{{ capitalize $function.TableType }}(*t.TableFunctionImpl.As(alias))
+}
+
+func (t *{{ $function.TableType }}) GetColumns() []gooq.Expression {
+  return []gooq.Expression{
+    {{ range $_, $f := $function.Fields -}}
+    t.{{ snakeToCamelID $f.Name }},
+    {{ end -}}
+  }
+}
+
+{{ end -}}
+
+{{ range $_, $procedure := .Procedures -}}
+func {{ $procedure.FunctionName }}(
+  {{ range $_, $p := $procedure.Parameters -}}
+  {{ if not $p.IsOut -}}
+  {{ $p.Name }} gooq.{{ $p.ExpressionType }},
+  {{ end -}}
+  {{ end -}}
+) gooq.CallFinalStep {
+  return gooq.Call({{ printf "%q" $procedure.Name }},
+  {{- range $_, $p := $procedure.Parameters }} {{ if $p.IsOut }}gooq.Null(){{ else }}{{ $p.Name }}{{ end }},{{ end }})
+}
+
+{{ end -}}
+`
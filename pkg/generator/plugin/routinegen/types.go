@@ -0,0 +1,42 @@
+package routinegen
+
+type templateArgs struct {
+	Timestamp      string
+	Package        string
+	Functions      []functionTemplateArgs
+	TableFunctions []tableFunctionTemplateArgs
+	Procedures     []functionTemplateArgs
+}
+
+type functionTemplateArgs struct {
+	// Name is the quoted and schema qualified name of the routine
+	Name         string
+	FunctionName string
+	Parameters   []parameterTemplateArgs
+	// ExpressionType is the gooq expression type of the result of a scalar
+	// function, e.g. NumericExpression
+	ExpressionType string
+}
+
+type tableFunctionTemplateArgs struct {
+	Schema       string
+	Name         string
+	FunctionName string
+	TableType    string
+	Parameters   []parameterTemplateArgs
+	Fields       []fieldTemplateArgs
+}
+
+// parameterTemplateArgs is an argument of a routine, the OUT parameters of a
+// procedure are not arguments of its binding but passed as NULL
+type parameterTemplateArgs struct {
+	Name  string
+	IsOut bool
+	// ExpressionType is the gooq expression type of the argument
+	ExpressionType string
+}
+
+type fieldTemplateArgs struct {
+	Name     string
+	GooqType string
+}
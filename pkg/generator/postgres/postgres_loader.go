@@ -12,19 +12,30 @@ func NewPostgresLoader() *metadata.Loader {
 	return &metadata.Loader{
 		ConstraintList:           getConstraintList,
 		ForeignKeyConstraintList: getForeignKeyConstraintList,
-		Schema:                   getSchema,
+		CheckConstraintList:      getCheckConstraintList,
+		ExclusionConstraintList:  getExclusionConstraintList,
+		SchemaList:               getSchemas,
 		EnumList:                 getEnums,
 		EnumValueList:            getEnumValues,
 		ReferenceTableValueList:  getReferenceTableValues,
 		TableList:                getTable,
 		ColumnList:               getColumns,
+		RoutineList:              getRoutines,
+		ParameterList:            getParameters,
 		GetDataType:              parseType,
 		GetTypeByName:            getTypeByName,
 	}
 }
 
-func getSchema() (string, error) {
-	return "public", nil
+func getSchemas(
+	db *sqlx.DB,
+) ([]string, error) {
+	schemas := []string{}
+	err := db.Select(&schemas, schemasQuery)
+	if err != nil {
+		return nil, err
+	}
+	return schemas, nil
 }
 
 func getConstraintList(
@@ -39,10 +50,32 @@ func getConstraintList(
 }
 
 func getForeignKeyConstraintList(
-	db *sqlx.DB, tableName string,
+	db *sqlx.DB, schema, tableName string,
 ) ([]metadata.ForeignKeyConstraintMetadata, error) {
 	constraints := []metadata.ForeignKeyConstraintMetadata{}
-	err := db.Select(&constraints, foreignKeyConstraintValuesQuery, tableName)
+	err := db.Select(&constraints, foreignKeyConstraintValuesQuery, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	return constraints, nil
+}
+
+func getCheckConstraintList(
+	db *sqlx.DB, schema, tableName string,
+) ([]metadata.CheckConstraintMetadata, error) {
+	constraints := []metadata.CheckConstraintMetadata{}
+	err := db.Select(&constraints, checkConstraintValuesQuery, schema, tableName)
+	if err != nil {
+		return nil, err
+	}
+	return constraints, nil
+}
+
+func getExclusionConstraintList(
+	db *sqlx.DB, schema, tableName string,
+) ([]metadata.ExclusionConstraintMetadata, error) {
+	constraints := []metadata.ExclusionConstraintMetadata{}
+	err := db.Select(&constraints, exclusionConstraintValuesQuery, schema, tableName)
 	if err != nil {
 		return nil, err
 	}
@@ -105,6 +138,28 @@ func getColumns(
 	return columns, nil
 }
 
+func getRoutines(
+	db *sqlx.DB, schema string,
+) ([]metadata.RoutineMetadata, error) {
+	routines := []metadata.RoutineMetadata{}
+	err := db.Select(&routines, routinesQuery, schema)
+	if err != nil {
+		return nil, err
+	}
+	return routines, nil
+}
+
+func getParameters(
+	db *sqlx.DB, schema, specificName string,
+) ([]metadata.ParameterMetadata, error) {
+	parameters := []metadata.ParameterMetadata{}
+	err := db.Select(&parameters, parametersQuery, schema, specificName)
+	if err != nil {
+		return nil, err
+	}
+	return parameters, nil
+}
+
 func getTypeByName(
 	typeName string,
 ) (metadata.DataType, error) {
@@ -116,12 +171,12 @@ func getTypeByName(
 }
 
 func parseType(
-	dataType string,
+	column metadata.ColumnMetadata,
 ) (metadata.DataType, error) {
 	var typ metadata.DataType
-	switch strings.ToLower(dataType) {
+	switch strings.ToLower(column.DataType) {
 	case "array":
-		typ = metadata.DataTypeStringArray
+		return parseArrayType(column.UserDefinedTypeName)
 	case "boolean":
 		typ = metadata.DataTypeBool
 	case "character", "character varying", "text", "user-defined":
@@ -132,6 +187,8 @@ func parseType(
 		typ = metadata.DataTypeInt
 	case "bigint":
 		typ = metadata.DataTypeInt64
+	case "interval":
+		typ = metadata.DataTypeInterval
 	case "jsonb":
 		typ = metadata.DataTypeJSONB
 	case "float":
@@ -143,22 +200,88 @@ func parseType(
 	case "uuid":
 		typ = metadata.DataTypeUUID
 	default:
-		return metadata.DataType{}, fmt.Errorf("invalid type=%s", dataType)
+		return metadata.DataType{}, fmt.Errorf("invalid type=%s", column.DataType)
 	}
 	return typ, nil
 }
 
+// parseArrayType maps the udt_name of an array column, which is the element
+// type prefixed with an underscore, to the matching array type. The arrays of
+// the other element types (e.g. _bool, _numeric, _timestamptz or enums) are
+// string arrays as they were before arrays were typed.
+func parseArrayType(
+	udtName string,
+) (metadata.DataType, error) {
+	switch strings.ToLower(udtName) {
+	case "_int2", "_int4", "_int8":
+		return metadata.DataTypeIntArray, nil
+	case "_uuid":
+		return metadata.DataTypeUUIDArray, nil
+	default:
+		return metadata.DataTypeStringArray, nil
+	}
+}
+
+const schemasQuery = `
+SELECT nspname
+FROM pg_namespace
+WHERE nspname NOT IN ('information_schema', 'pg_catalog', 'pg_toast')
+	AND nspname NOT LIKE 'pg_temp_%'
+	AND nspname NOT LIKE 'pg_toast_temp_%'
+ORDER BY nspname
+`
+
+// tablesQuery lists the relations that code is generated for, which are not
+// all in information_schema.tables (e.g. materialized views)
 const tablesQuery = `
-select table_name
-from information_schema.tables
-where table_schema = $1 AND table_name != 'schema_migrations'
-order by table_name
+SELECT
+	c.relname AS table_name,
+	CASE c.relkind
+		WHEN 'r' THEN 'BASE TABLE'
+		WHEN 'v' THEN 'VIEW'
+		WHEN 'm' THEN 'MATERIALIZED VIEW'
+		WHEN 'f' THEN 'FOREIGN'
+		WHEN 'p' THEN 'PARTITIONED TABLE'
+	END AS type,
+	c.relispartition AS is_partition
+FROM pg_class AS c
+JOIN pg_namespace AS n ON n.oid = c.relnamespace
+WHERE n.nspname = $1
+	AND c.relkind IN ('r', 'v', 'm', 'f', 'p')
+	AND c.relname != 'schema_migrations'
+ORDER BY c.relname
 `
 
+// columnsQuery reads the columns of materialized views and composite types
+// from pg_attribute as they are not in information_schema.columns, their
+// data_type is the one that information_schema would report
 const columnsQuery = `
-SELECT column_name, data_type, is_nullable::boolean, udt_name
-FROM information_schema.columns
-WHERE table_schema = $1 and table_name = $2
+SELECT column_name, data_type, is_nullable, udt_name, udt_schema
+FROM (
+	SELECT column_name, data_type, is_nullable::boolean, udt_name, udt_schema, ordinal_position
+	FROM information_schema.columns
+	WHERE table_schema = $1 and table_name = $2
+	UNION ALL
+	SELECT
+		a.attname AS column_name,
+		CASE
+			WHEN t.typcategory = 'A' THEN 'ARRAY'
+			WHEN tn.nspname <> 'pg_catalog' THEN 'USER-DEFINED'
+			ELSE regexp_replace(format_type(a.atttypid, a.atttypmod), '\(.*\)', '')
+		END AS data_type,
+		NOT a.attnotnull AS is_nullable,
+		t.typname AS udt_name,
+		tn.nspname AS udt_schema,
+		a.attnum AS ordinal_position
+	FROM pg_attribute AS a
+	JOIN pg_class AS c ON c.oid = a.attrelid
+	JOIN pg_namespace AS n ON n.oid = c.relnamespace
+	JOIN pg_type AS t ON t.oid = a.atttypid
+	JOIN pg_namespace AS tn ON tn.oid = t.typnamespace
+	WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind IN ('m', 'c')
+		AND a.attnum > 0 AND NOT a.attisdropped
+) AS columns
+ORDER BY ordinal_position
 `
 
 const enumsQuery = `
@@ -198,7 +321,8 @@ SELECT
 			k)) AS index_keys
 	FROM
 		pg_indexes AS indexes
-		JOIN pg_class AS i ON i.relname = indexes.indexname
+		JOIN pg_namespace AS n ON n.nspname = indexes.schemaname
+		JOIN pg_class AS i ON i.relname = indexes.indexname AND i.relnamespace = n.oid
 		JOIN pg_index AS idx ON idx.indexrelid = i.oid
 	WHERE
 		schemaname = $1
@@ -207,22 +331,156 @@ SELECT
   	indexes.indexname
 `
 
+// foreignKeyConstraintValuesQuery lists the column pairs of the foreign keys
+// of a table, unnesting the column numbers of a constraint together keeps the
+// columns of composite foreign keys paired with the columns they reference
 const foreignKeyConstraintValuesQuery = `
 SELECT
-	tc.table_schema,
-	tc.constraint_name,
-	tc.table_name,
-	kcu.column_name,
-	ccu.table_schema AS foreign_table_schema,
-	ccu.table_name AS foreign_table_name,
-	ccu.column_name AS foreign_column_name
+	n.nspname AS table_schema,
+	c.conname AS constraint_name,
+	t.relname AS table_name,
+	a.attname AS column_name,
+	k.ordinal_position,
+	fn.nspname AS foreign_table_schema,
+	ft.relname AS foreign_table_name,
+	fa.attname AS foreign_column_name,
+	-- the referential actions as in information_schema.referential_constraints
+	CASE c.confupdtype
+		WHEN 'r' THEN 'RESTRICT'
+		WHEN 'c' THEN 'CASCADE'
+		WHEN 'n' THEN 'SET NULL'
+		WHEN 'd' THEN 'SET DEFAULT'
+		ELSE 'NO ACTION'
+	END AS update_rule,
+	CASE c.confdeltype
+		WHEN 'r' THEN 'RESTRICT'
+		WHEN 'c' THEN 'CASCADE'
+		WHEN 'n' THEN 'SET NULL'
+		WHEN 'd' THEN 'SET DEFAULT'
+		ELSE 'NO ACTION'
+	END AS delete_rule,
+	c.condeferrable AS is_deferrable,
+	c.condeferred AS is_initially_deferred
 	FROM
-		information_schema.table_constraints AS tc
-	JOIN information_schema.key_column_usage AS kcu
-	ON tc.constraint_name = kcu.constraint_name
-	AND tc.table_schema = kcu.table_schema
-	JOIN information_schema.constraint_column_usage AS ccu
-	ON ccu.constraint_name = tc.constraint_name
-	AND ccu.table_schema = tc.table_schema
-	WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_name=$1
+		pg_constraint AS c
+		JOIN pg_class AS t ON t.oid = c.conrelid
+		JOIN pg_namespace AS n ON n.oid = t.relnamespace
+		-- the referenced table can be in another schema than the constraint
+		JOIN pg_class AS ft ON ft.oid = c.confrelid
+		JOIN pg_namespace AS fn ON fn.oid = ft.relnamespace
+		CROSS JOIN LATERAL unnest(c.conkey, c.confkey)
+			WITH ORDINALITY AS k(attnum, foreign_attnum, ordinal_position)
+		JOIN pg_attribute AS a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+		JOIN pg_attribute AS fa ON fa.attrelid = c.confrelid AND fa.attnum = k.foreign_attnum
+	WHERE
+		c.contype = 'f'
+		AND n.nspname = $1
+		AND t.relname = $2
+	ORDER BY
+		c.conname,
+		k.ordinal_position
+`
+
+const checkConstraintValuesQuery = `
+SELECT
+	c.conname AS constraint_name,
+	pg_get_expr(c.conbin, c.conrelid, TRUE) AS expression,
+	array_to_json(ARRAY (
+		SELECT
+			a.attname
+		FROM
+			unnest(c.conkey) WITH ORDINALITY AS k(attnum, ordinal_position)
+			JOIN pg_attribute AS a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+		ORDER BY
+			k.ordinal_position)) AS columns,
+	c.connoinherit AS is_no_inherit
+	FROM
+		pg_constraint AS c
+		JOIN pg_class AS t ON t.oid = c.conrelid
+		JOIN pg_namespace AS n ON n.oid = t.relnamespace
+	WHERE
+		c.contype = 'c'
+		AND n.nspname = $1
+		AND t.relname = $2
+	ORDER BY
+		c.conname
+`
+
+// exclusionConstraintValuesQuery lists the exclusion constraints of a table,
+// the elements are the columns (or expressions) of the index of a constraint
+// in the order of its operators
+const exclusionConstraintValuesQuery = `
+SELECT
+	c.conname AS constraint_name,
+	am.amname AS index_method,
+	array_to_json(ARRAY (
+		SELECT
+			pg_get_indexdef(c.conindid, k.ordinal_position::int, TRUE)
+		FROM
+			unnest(c.conexclop) WITH ORDINALITY AS k(operator, ordinal_position)
+		ORDER BY
+			k.ordinal_position)) AS elements,
+	array_to_json(ARRAY (
+		SELECT
+			o.oprname
+		FROM
+			unnest(c.conexclop) WITH ORDINALITY AS k(operator, ordinal_position)
+			JOIN pg_operator AS o ON o.oid = k.operator
+		ORDER BY
+			k.ordinal_position)) AS operators,
+	pg_get_expr(idx.indpred, idx.indrelid, TRUE) AS predicate,
+	pg_get_constraintdef(c.oid, TRUE) AS definition,
+	c.condeferrable AS is_deferrable,
+	c.condeferred AS is_initially_deferred
+	FROM
+		pg_constraint AS c
+		JOIN pg_class AS t ON t.oid = c.conrelid
+		JOIN pg_namespace AS n ON n.oid = t.relnamespace
+		JOIN pg_index AS idx ON idx.indexrelid = c.conindid
+		JOIN pg_class AS i ON i.oid = c.conindid
+		JOIN pg_am AS am ON am.oid = i.relam
+	WHERE
+		c.contype = 'x'
+		AND n.nspname = $1
+		AND t.relname = $2
+	ORDER BY
+		c.conname
+`
+
+// routinesQuery lists the functions and procedures of a schema except for
+// the aggregates, the trigger functions, the variadic functions and the
+// functions of extensions
+const routinesQuery = `
+SELECT
+	r.specific_name,
+	r.routine_name,
+	r.routine_type,
+	COALESCE(r.data_type, '') AS data_type,
+	COALESCE(r.type_udt_name, '') AS udt_name,
+	COALESCE(r.type_udt_schema, '') AS udt_schema,
+	p.proretset AS returns_set
+FROM information_schema.routines AS r
+JOIN pg_namespace AS n ON n.nspname = r.specific_schema
+JOIN pg_proc AS p ON p.pronamespace = n.oid AND r.specific_name = p.proname || '_' || p.oid
+WHERE r.specific_schema = $1
+	AND r.routine_type IN ('FUNCTION', 'PROCEDURE')
+	AND r.data_type IS DISTINCT FROM 'trigger'
+	AND p.provariadic = 0
+	AND NOT EXISTS (
+		SELECT 1 FROM pg_depend AS d
+		WHERE d.classid = 'pg_proc'::regclass AND d.objid = p.oid AND d.deptype = 'e'
+	)
+ORDER BY r.routine_name, r.specific_name
+`
+
+const parametersQuery = `
+SELECT
+	COALESCE(parameter_name, '') AS parameter_name,
+	parameter_mode,
+	data_type,
+	udt_name,
+	udt_schema
+FROM information_schema.parameters
+WHERE specific_schema = $1 AND specific_name = $2
+ORDER BY ordinal_position
 `
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/lumina-tech/gooq/pkg/generator/metadata"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseType(t *testing.T) {
+	testCases := []struct {
+		column   metadata.ColumnMetadata
+		expected metadata.DataType
+	}{
+		{
+			column:   metadata.ColumnMetadata{DataType: "text"},
+			expected: metadata.DataTypeString,
+		},
+		{
+			column:   metadata.ColumnMetadata{DataType: "bigint"},
+			expected: metadata.DataTypeInt64,
+		},
+		{
+			column:   metadata.ColumnMetadata{DataType: "ARRAY", UserDefinedTypeName: "_text"},
+			expected: metadata.DataTypeStringArray,
+		},
+		{
+			column:   metadata.ColumnMetadata{DataType: "ARRAY", UserDefinedTypeName: "_int4"},
+			expected: metadata.DataTypeIntArray,
+		},
+		{
+			column:   metadata.ColumnMetadata{DataType: "ARRAY", UserDefinedTypeName: "_uuid"},
+			expected: metadata.DataTypeUUIDArray,
+		},
+		{
+			column:   metadata.ColumnMetadata{DataType: "ARRAY", UserDefinedTypeName: "_timestamptz"},
+			expected: metadata.DataTypeStringArray,
+		},
+		{
+			column:   metadata.ColumnMetadata{DataType: "ARRAY", UserDefinedTypeName: "_mood"},
+			expected: metadata.DataTypeStringArray,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.column.UserDefinedTypeName, func(t *testing.T) {
+			typ, err := parseType(testCase.column)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, typ)
+		})
+	}
+}
+
+func TestParseTypeInvalid(t *testing.T) {
+	_, err := parseType(metadata.ColumnMetadata{DataType: "tsvector"})
+	require.Error(t, err)
+}
@@ -2,20 +2,146 @@ package gooq
 
 import (
 	"bytes"
+	"database/sql/driver"
 	"fmt"
+	"reflect"
 )
 
-var (
-	invalidLiteralValueError = fmt.Errorf("literal value cannot be of kind slice")
+// QuotePolicy decides which identifiers (schema, table, column, alias and
+// constraint names) the Builder quotes
+type QuotePolicy int
+
+const (
+	// QuoteWhenNeeded quotes the identifiers that are reserved words of the
+	// dialect or that would not be parsed as is, e.g. mixed case names in
+	// Postgres
+	QuoteWhenNeeded QuotePolicy = iota
+	QuoteAlways
+	QuoteNever
 )
 
 type Builder struct {
-	isDebug   bool
+	dialect     *dialectSpec
+	quotePolicy QuotePolicy
+	isDebug     bool
+	// isInline inlines the literals that are rendered while it is set, see
+	// renderInlineConditions
+	isInline  bool
 	buffer    bytes.Buffer
 	arguments []interface{}
 	errors    []error
 }
 
+// NewBuilder returns a builder that renders statements for the given dialect.
+// The zero value Builder renders for Postgres.
+func NewBuilder(dialect Dialect) *Builder {
+	builder := &Builder{}
+	if spec, ok := dialectSpecs[dialect]; ok {
+		builder.dialect = spec
+	} else {
+		builder.errors = append(builder.errors,
+			fmt.Errorf("unknown dialect %s", dialect))
+	}
+	return builder
+}
+
+func (builder *Builder) getDialect() *dialectSpec {
+	if builder.dialect == nil {
+		return dialectSpecs[Postgres]
+	}
+	return builder.dialect
+}
+
+// Dialect returns the dialect the builder renders for
+func (builder *Builder) Dialect() Dialect {
+	return builder.getDialect().dialect
+}
+
+// Err returns the first error encountered while rendering, if any
+func (builder *Builder) Err() error {
+	if len(builder.errors) == 0 {
+		return nil
+	}
+	return builder.errors[0]
+}
+
+// Errors returns every error encountered while rendering
+func (builder *Builder) Errors() []error {
+	return builder.errors
+}
+
+// Arguments returns the arguments of the placeholders of the rendered
+// statement in order
+func (builder *Builder) Arguments() []interface{} {
+	return builder.arguments
+}
+
+// Debug makes the builder inline the arguments as literals instead of
+// rendering placeholders, see RenderInline
+func (builder *Builder) Debug() *Builder {
+	builder.isDebug = true
+	return builder
+}
+
+// requireFeature records an UnsupportedFeatureError when the builder's
+// dialect cannot render the given feature. It returns whether the feature
+// is supported so callers can skip rendering it altogether.
+func (builder *Builder) requireFeature(
+	feature DialectFeature,
+) bool {
+	dialect := builder.getDialect()
+	if dialect.features[feature] {
+		return true
+	}
+	builder.errors = append(builder.errors, &UnsupportedFeatureError{
+		Dialect: dialect.dialect,
+		Feature: feature,
+	})
+	return false
+}
+
+// WithQuotePolicy sets which identifiers the builder quotes, defaults to
+// QuoteWhenNeeded. A statement is rendered with another policy through its
+// Render method, e.g.
+//
+//	builder := NewBuilder(Postgres).WithQuotePolicy(QuoteAlways)
+//	stmt.Render(builder)
+//	rows, err := db.Queryx(builder.String(), builder.Arguments()...)
+func (builder *Builder) WithQuotePolicy(policy QuotePolicy) *Builder {
+	builder.quotePolicy = policy
+	return builder
+}
+
+// QuoteIdentifier quotes a schema, table, column, alias or constraint name
+// according to the builder's dialect and quote policy
+func (builder *Builder) QuoteIdentifier(
+	name string,
+) string {
+	dialect := builder.getDialect()
+	switch builder.quotePolicy {
+	case QuoteAlways:
+		return dialect.quoteIdentifier(name)
+	case QuoteNever:
+		return name
+	default:
+		if dialect.needsQuoting(name) {
+			return dialect.quoteIdentifier(name)
+		}
+		return name
+	}
+}
+
+// quoteTableName quotes the schema qualified name of a table or a view
+func (builder *Builder) quoteTableName(
+	table View,
+) string {
+	if table.GetSchema() == "" {
+		return builder.QuoteIdentifier(table.GetName())
+	}
+	return builder.QuoteIdentifier(table.GetSchema()) + "." +
+		builder.QuoteIdentifier(table.GetName())
+}
+
 func (builder *Builder) Printf(
 	str string, args ...interface{},
 ) *Builder {
@@ -39,15 +165,55 @@ func (builder *Builder) RenderExpression(
 func (builder *Builder) RenderLiteral(
 	value interface{},
 ) {
-	if builder.isDebug {
-		builder.Print(fmt.Sprintf("%v", value))
+	if param, ok := value.(namedParam); ok && builder.isDebug {
+		// a psql variable, e.g. \set name 'value'
+		builder.Printf(":'%s'", param.name)
+	} else if param, ok := value.(namedParam); ok && builder.isInline {
+		builder.errors = append(builder.errors, &UnsupportedClauseError{
+			Statement: "an inlined condition",
+			Clause:    fmt.Sprintf("parameter %q", param.name),
+		})
+	} else if builder.isDebug || builder.isInline {
+		literal, err := inlineLiteral(builder.getDialect(), value)
+		if err != nil {
+			builder.errors = append(builder.errors, err)
+		}
+		builder.Print(literal)
 	} else {
-		placeholder := fmt.Sprintf("$%d", len(builder.arguments)+1)
+		if isSliceLiteral(value) {
+			builder.errors = append(builder.errors, &InvalidLiteralError{Value: value})
+		}
+		placeholder := builder.getDialect().placeholder(len(builder.arguments) + 1)
 		builder.Print(placeholder)
 		builder.arguments = append(builder.arguments, value)
 	}
 }
 
+// renderInlineConditions renders conditions with their literals inlined
+// rather than as placeholders, for the conditions that Postgres has to prove
+// at plan time, e.g. the index predicate of an ON CONFLICT target, which is
+// matched against the predicates of partial unique indexes
+func (builder *Builder) renderInlineConditions(
+	conditions []Expression,
+) {
+	isInline := builder.isInline
+	builder.isInline = true
+	builder.RenderConditions(conditions)
+	builder.isInline = isInline
+}
+
+// isSliceLiteral reports whether value is a slice that the driver cannot
+// convert, i.e. neither []byte nor a driver.Valuer such as pq.StringArray
+func isSliceLiteral(
+	value interface{},
+) bool {
+	switch value.(type) {
+	case []byte, driver.Valuer:
+		return false
+	}
+	return value != nil && reflect.TypeOf(value).Kind() == reflect.Slice
+}
+
 func (builder *Builder) RenderExpressionArray(
 	array []Expression,
 ) {
@@ -66,7 +232,7 @@ func (builder *Builder) RenderFieldArray(
 ) {
 	builder.Print("(")
 	for index, field := range fields {
-		builder.Print(field.GetName())
+		builder.Print(builder.QuoteIdentifier(field.GetName()))
 		if index != len(fields)-1 {
 			builder.Print(", ")
 		}
@@ -74,9 +240,16 @@ func (builder *Builder) RenderFieldArray(
 	builder.Print(")")
 }
 
+// RenderConditions renders conditions combined with AND. Noop() conditions
+// are skipped, TRUE is rendered when there are no other conditions.
 func (builder *Builder) RenderConditions(
 	conditions []Expression,
 ) {
+	conditions = withoutNoops(conditions)
+	if len(conditions) == 0 {
+		builder.Print("TRUE")
+		return
+	}
 	for index, expression := range conditions {
 		expression.Render(builder)
 		if index != len(conditions)-1 {
@@ -114,7 +287,13 @@ func (builder *Builder) RenderSetPredicates(
 		item := &predicates[index]
 		// https://www.postgresql.org/docs/12/sql-update.html
 		// do not include the table's name in the specification of a target column — for example, UPDATE table_name SET table_name.col = 1 is invalid
-		builder.Printf("%s = ", item.field.GetName())
+		if item.row != nil {
+			builder.requireFeature(DialectFeatureRowValueAssignment)
+			builder.RenderFieldArray(item.row)
+			builder.Print(" = ")
+		} else {
+			builder.Printf("%s = ", builder.QuoteIdentifier(item.field.GetName()))
+		}
 		switch predicate := item.value.(type) {
 		case *selection:
 			builder.Print("(")
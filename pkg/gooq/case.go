@@ -0,0 +1,271 @@
+package gooq
+
+// 9.17.1. CASE
+// https://www.postgresql.org/docs/11/functions-conditional.html
+//
+// The first When determines the expression type of the whole CASE, e.g.
+// Case().WhenNumeric(Table1.BoolColumn, Int64(1)).Else(Int64(0)) is a
+// NumericExpression and only accepts numeric results in subsequent When(...)
+// and Else(...) calls. For a simple CASE (CaseOf) the condition of each When
+// is the value compared against the operand.
+
+type CaseStep interface {
+	When(condition Expression, result Expression) CaseWhenStep
+	WhenBool(condition Expression, result BoolExpression) BoolCaseWhenStep
+	WhenDateTime(condition Expression, result DateTimeExpression) DateTimeCaseWhenStep
+	WhenNumeric(condition Expression, result NumericExpression) NumericCaseWhenStep
+	WhenString(condition Expression, result StringExpression) StringCaseWhenStep
+}
+
+type CaseWhenStep interface {
+	Expression
+	When(condition Expression, result Expression) CaseWhenStep
+	Else(result Expression) Expression
+}
+
+type BoolCaseWhenStep interface {
+	BoolExpression
+	When(condition Expression, result BoolExpression) BoolCaseWhenStep
+	Else(result BoolExpression) BoolExpression
+}
+
+type DateTimeCaseWhenStep interface {
+	DateTimeExpression
+	When(condition Expression, result DateTimeExpression) DateTimeCaseWhenStep
+	Else(result DateTimeExpression) DateTimeExpression
+}
+
+type NumericCaseWhenStep interface {
+	NumericExpression
+	When(condition Expression, result NumericExpression) NumericCaseWhenStep
+	Else(result NumericExpression) NumericExpression
+}
+
+type StringCaseWhenStep interface {
+	StringExpression
+	When(condition Expression, result StringExpression) StringCaseWhenStep
+	Else(result StringExpression) StringExpression
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Implementation
+///////////////////////////////////////////////////////////////////////////////
+
+type caseWhen struct {
+	condition Expression
+	result    Expression
+}
+
+type caseClause struct {
+	operand   Expression
+	whens     []caseWhen
+	otherwise Expression
+}
+
+// withWhen returns a copy of c with another WHEN, the whens are capped so
+// that a CASE that is completed in different ways is never changed, see
+// selection.clone
+func (c caseClause) withWhen(condition, result Expression) caseClause {
+	c.whens = append(c.whens[:len(c.whens):len(c.whens)], caseWhen{
+		condition: condition.getOriginal(),
+		result:    result.getOriginal(),
+	})
+	return c
+}
+
+// withElse returns a copy of c with the ELSE result
+func (c caseClause) withElse(result Expression) caseClause {
+	c.otherwise = result.getOriginal()
+	return c
+}
+
+// caseStep is the CASE keyword and the optional operand of a simple CASE
+type caseStep struct {
+	operand Expression
+}
+
+func (s *caseStep) When(
+	condition Expression, result Expression,
+) CaseWhenStep {
+	return newCaseExpression(caseClause{operand: s.operand}.withWhen(condition, result))
+}
+
+func (s *caseStep) WhenBool(
+	condition Expression, result BoolExpression,
+) BoolCaseWhenStep {
+	return newBoolCaseExpression(caseClause{operand: s.operand}.withWhen(condition, result))
+}
+
+func (s *caseStep) WhenDateTime(
+	condition Expression, result DateTimeExpression,
+) DateTimeCaseWhenStep {
+	return newDateTimeCaseExpression(caseClause{operand: s.operand}.withWhen(condition, result))
+}
+
+func (s *caseStep) WhenNumeric(
+	condition Expression, result NumericExpression,
+) NumericCaseWhenStep {
+	return newNumericCaseExpression(caseClause{operand: s.operand}.withWhen(condition, result))
+}
+
+func (s *caseStep) WhenString(
+	condition Expression, result StringExpression,
+) StringCaseWhenStep {
+	return newStringCaseExpression(caseClause{operand: s.operand}.withWhen(condition, result))
+}
+
+func (c *caseClause) render(
+	builder *Builder,
+) {
+	builder.Print("CASE")
+	if c.operand != nil {
+		builder.Print(" ")
+		builder.RenderExpression(c.operand)
+	}
+	for _, when := range c.whens {
+		builder.Print(" WHEN ")
+		builder.RenderExpression(when.condition)
+		builder.Print(" THEN ")
+		builder.RenderExpression(when.result)
+	}
+	if c.otherwise != nil {
+		builder.Print(" ELSE ")
+		builder.RenderExpression(c.otherwise)
+	}
+	builder.Print(" END")
+}
+
+// untyped
+
+type caseExpression struct {
+	expressionImpl
+	caseClause
+}
+
+func newCaseExpression(clause caseClause) *caseExpression {
+	expr := &caseExpression{caseClause: clause}
+	expr.expressionImpl.initFunctionExpression(expr)
+	return expr
+}
+
+func (expr *caseExpression) When(
+	condition Expression, result Expression,
+) CaseWhenStep {
+	return newCaseExpression(expr.withWhen(condition, result))
+}
+
+func (expr *caseExpression) Else(result Expression) Expression {
+	return newCaseExpression(expr.withElse(result))
+}
+
+func (expr *caseExpression) Render(builder *Builder) {
+	expr.caseClause.render(builder)
+}
+
+// bool
+
+type boolCaseExpression struct {
+	boolExpressionImpl
+	caseClause
+}
+
+func newBoolCaseExpression(clause caseClause) *boolCaseExpression {
+	expr := &boolCaseExpression{caseClause: clause}
+	expr.expressionImpl.initFunctionExpression(expr)
+	return expr
+}
+
+func (expr *boolCaseExpression) When(
+	condition Expression, result BoolExpression,
+) BoolCaseWhenStep {
+	return newBoolCaseExpression(expr.withWhen(condition, result))
+}
+
+func (expr *boolCaseExpression) Else(result BoolExpression) BoolExpression {
+	return newBoolCaseExpression(expr.withElse(result))
+}
+
+func (expr *boolCaseExpression) Render(builder *Builder) {
+	expr.caseClause.render(builder)
+}
+
+// date time
+
+type dateTimeCaseExpression struct {
+	dateTimeExpressionImpl
+	caseClause
+}
+
+func newDateTimeCaseExpression(clause caseClause) *dateTimeCaseExpression {
+	expr := &dateTimeCaseExpression{caseClause: clause}
+	expr.expressionImpl.initFunctionExpression(expr)
+	return expr
+}
+
+func (expr *dateTimeCaseExpression) When(
+	condition Expression, result DateTimeExpression,
+) DateTimeCaseWhenStep {
+	return newDateTimeCaseExpression(expr.withWhen(condition, result))
+}
+
+func (expr *dateTimeCaseExpression) Else(result DateTimeExpression) DateTimeExpression {
+	return newDateTimeCaseExpression(expr.withElse(result))
+}
+
+func (expr *dateTimeCaseExpression) Render(builder *Builder) {
+	expr.caseClause.render(builder)
+}
+
+// numeric
+
+type numericCaseExpression struct {
+	numericExpressionImpl
+	caseClause
+}
+
+func newNumericCaseExpression(clause caseClause) *numericCaseExpression {
+	expr := &numericCaseExpression{caseClause: clause}
+	expr.expressionImpl.initFunctionExpression(expr)
+	return expr
+}
+
+func (expr *numericCaseExpression) When(
+	condition Expression, result NumericExpression,
+) NumericCaseWhenStep {
+	return newNumericCaseExpression(expr.withWhen(condition, result))
+}
+
+func (expr *numericCaseExpression) Else(result NumericExpression) NumericExpression {
+	return newNumericCaseExpression(expr.withElse(result))
+}
+
+func (expr *numericCaseExpression) Render(builder *Builder) {
+	expr.caseClause.render(builder)
+}
+
+// string
+
+type stringCaseExpression struct {
+	stringExpressionImpl
+	caseClause
+}
+
+func newStringCaseExpression(clause caseClause) *stringCaseExpression {
+	expr := &stringCaseExpression{caseClause: clause}
+	expr.expressionImpl.initFunctionExpression(expr)
+	return expr
+}
+
+func (expr *stringCaseExpression) When(
+	condition Expression, result StringExpression,
+) StringCaseWhenStep {
+	return newStringCaseExpression(expr.withWhen(condition, result))
+}
+
+func (expr *stringCaseExpression) Else(result StringExpression) StringExpression {
+	return newStringCaseExpression(expr.withElse(result))
+}
+
+func (expr *stringCaseExpression) Render(builder *Builder) {
+	expr.caseClause.render(builder)
+}
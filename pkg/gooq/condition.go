@@ -0,0 +1,145 @@
+package gooq
+
+import (
+	"gopkg.in/guregu/null.v3"
+)
+
+// Noop returns a condition that stands for no condition at all, e.g. an
+// optional filter that was not given. It is elided when it is rendered with
+// other conditions, e.g. Where(Noop(), expr) and And(Noop(), expr) render
+// expr, and a WHERE or HAVING clause with only Noop() conditions is omitted.
+// On its own, e.g. as an operand of a comparison, it renders TRUE.
+func Noop() BoolExpression {
+	expr := &boolExpressionImpl{}
+	expr.expressionImpl.expressionType = ExpressionTypeNoop
+	return expr
+}
+
+// isNoop reports whether expr is Noop() or only combines Noop() with the
+// logical operators, in which case it is elided
+func isNoop(expr Expression) bool {
+	impl, ok := expr.getOriginal().(*expressionImpl)
+	return ok && impl.isNoop()
+}
+
+func (expr *expressionImpl) isNoop() bool {
+	switch expr.expressionType {
+	case ExpressionTypeNoop:
+		return true
+	case ExpressionTypeUnaryPrefix, ExpressionTypeBinary, ExpressionTypeMultigrade:
+		if !isLogicalOperator(expr.operator) || len(expr.expressions) == 0 {
+			return false
+		}
+		for _, operand := range expr.expressions {
+			if !isNoop(operand) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func isLogicalOperator(operator Operator) bool {
+	return operator == OperatorAnd || operator == OperatorOr || operator == OperatorNot
+}
+
+// withoutNoops returns conditions without the Noop() conditions. conditions
+// is returned as is when it does not contain any.
+func withoutNoops(conditions []Expression) []Expression {
+	for index, condition := range conditions {
+		if !isNoop(condition) {
+			continue
+		}
+		results := append([]Expression{}, conditions[:index]...)
+		for _, condition := range conditions[index+1:] {
+			if !isNoop(condition) {
+				results = append(results, condition)
+			}
+		}
+		return results
+	}
+	return conditions
+}
+
+// Condition accumulates conditions, e.g. the optional filters of a search:
+//
+//	condition := TrueCondition()
+//	if name != "" {
+//		condition = condition.And(Table.Name.Eq(String(name)))
+//	}
+//	Select().From(Table).Where(condition)
+//
+// It starts as TRUE or FALSE, which is replaced by the first conditions given
+// to And or Or respectively. Like the statements, a Condition is never
+// modified, And, Or and Not return a new Condition.
+type Condition interface {
+	Expression
+	And(conditions ...Expression) Condition
+	Or(conditions ...Expression) Condition
+	Not() Condition
+}
+
+type condition struct {
+	Expression
+	// constant is valid while the condition is TRUE or FALSE
+	constant null.Bool
+}
+
+func TrueCondition() Condition {
+	return &condition{Expression: keyword("TRUE"), constant: null.BoolFrom(true)}
+}
+
+func FalseCondition() Condition {
+	return &condition{Expression: keyword("FALSE"), constant: null.BoolFrom(false)}
+}
+
+// And returns (condition AND conditions...), Noop() conditions are ignored
+func (c *condition) And(conditions ...Expression) Condition {
+	return c.combine(OperatorAnd, true, conditions)
+}
+
+// Or returns (condition OR (conditions...)), where conditions are combined
+// with AND. Noop() conditions are ignored.
+func (c *condition) Or(conditions ...Expression) Condition {
+	return c.combine(OperatorOr, false, conditions)
+}
+
+// combine applies operator to c and conditions where identity is the
+// constant that is replaced by conditions (TRUE for AND, FALSE for OR)
+func (c *condition) combine(
+	operator Operator, identity bool, conditions []Expression,
+) Condition {
+	conditions = withoutNoops(conditions)
+	switch {
+	case len(conditions) == 0:
+		return c
+	case c.constant.Valid && c.constant.Bool == identity:
+		return &condition{Expression: conjunction(conditions)}
+	case c.constant.Valid:
+		// FALSE AND ... is FALSE and TRUE OR ... is TRUE
+		return c
+	}
+	operand := conjunction(conditions)
+	operands := []Expression{c.Expression, operand}
+	// (a AND b).And(c) renders (a AND b AND c)
+	if impl, ok := c.getOriginal().(*expressionImpl); ok &&
+		impl.expressionType == ExpressionTypeMultigrade && impl.operator == operator {
+		operands = append(impl.expressions[:len(impl.expressions):len(impl.expressions)], operand)
+	}
+	return &condition{Expression: newMultigradeBooleanExpressionImpl(operator,
+		operands, HasParentheses(true))}
+}
+
+// Not returns (NOT condition)
+func (c *condition) Not() Condition {
+	if c.constant.Valid {
+		if c.constant.Bool {
+			return FalseCondition()
+		}
+		return TrueCondition()
+	}
+	return &condition{Expression: newUnaryPrefixBooleanExpressionImpl(OperatorNot,
+		c.Expression, HasParentheses(true))}
+}
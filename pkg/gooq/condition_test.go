@@ -0,0 +1,116 @@
+package gooq
+
+import "testing"
+
+var conditionTestCases = []TestCase{
+	{
+		Constructed:  Select().From(Table1).Where(Noop(), Table1.Column1.Eq(String("foo")), Noop()),
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE table1.column1 = $1`,
+	},
+	{
+		Constructed:  Select().From(Table1).Where(Noop(), And(Noop(), Not(Noop()))),
+		ExpectedStmt: `SELECT * FROM public.table1`,
+	},
+	{
+		Constructed:  Select().From(Table1).Where(Or(Noop(), Table1.Column1.Eq(String("foo")), Table1.Column3.Gt(Int64(1)))),
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE (table1.column1 = $1 OR table1.column3 > $2)`,
+	},
+	{
+		Constructed:  Select().From(Table1).Where(Table1.BoolColumn.IsEq(true).And(Noop())),
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE (table1.bool_column = $1)`,
+	},
+	{
+		Constructed:  Select().From(Table1).Join(Table2).On(Noop()),
+		ExpectedStmt: `SELECT * FROM public.table1 JOIN public.table2 ON TRUE`,
+	},
+	{
+		Constructed:  Select(Noop()),
+		ExpectedStmt: `SELECT TRUE`,
+	},
+	{
+		Constructed:  Select().From(Table1).GroupBy(Table1.Column1).Having(Noop()),
+		ExpectedStmt: `SELECT * FROM public.table1 GROUP BY table1.column1`,
+	},
+	{
+		Constructed:  Select().From(Table1).Where(Not(Table1.Column1.Eq(String("foo")))),
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE (NOT table1.column1 = $1)`,
+	},
+	{
+		Constructed:  Select().From(Table1).Where(TrueCondition()),
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE TRUE`,
+	},
+	{
+		Constructed:  Select().From(Table1).Where(FalseCondition()),
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE FALSE`,
+	},
+	{
+		Constructed: Select().From(Table1).Where(TrueCondition().
+			And(Table1.Column1.Eq(String("foo"))).
+			And(Noop()).
+			And(Table1.Column3.Gt(Int64(1)), Table1.BoolColumn.IsEq(true))),
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE (table1.column1 = $1 AND (table1.column3 > $2 AND table1.bool_column = $3))`,
+	},
+	{
+		Constructed: Select().From(Table1).Where(TrueCondition().
+			And(Table1.Column1.Eq(String("foo"))).
+			And(Table1.Column2.Eq(String("bar"))).
+			And(Table1.Column3.Gt(Int64(1)))),
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE (table1.column1 = $1 AND table1.column2 = $2 AND table1.column3 > $3)`,
+	},
+	{
+		Constructed: Select().From(Table1).Where(FalseCondition().
+			Or(Table1.Column1.Eq(String("foo"))).
+			Or(Table1.Column2.Eq(String("bar")))),
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE (table1.column1 = $1 OR table1.column2 = $2)`,
+	},
+	{
+		Constructed:  Select().From(Table1).Where(FalseCondition().And(Table1.Column1.Eq(String("foo")))),
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE FALSE`,
+	},
+	{
+		Constructed:  Select().From(Table1).Where(TrueCondition().Or(Table1.Column1.Eq(String("foo")))),
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE TRUE`,
+	},
+	{
+		Constructed:  Select().From(Table1).Where(TrueCondition().Not()),
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE FALSE`,
+	},
+	{
+		Constructed: Select().From(Table1).Where(TrueCondition().
+			And(Table1.Column1.Eq(String("foo"))).
+			Or(Table1.Column2.Eq(String("bar"))).Not()),
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE (NOT (table1.column1 = $1 OR table1.column2 = $2))`,
+	},
+	{
+		Constructed: Select().From(Table1).
+			WhereIf(false, Table1.Column1.Eq(String("foo"))).
+			WhereIf(true, Table1.Column2.Eq(String("bar"))),
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE table1.column2 = $1`,
+	},
+	{
+		Constructed:  Select().From(Table1).WhereIf(false, Table1.Column1.Eq(String("foo"))),
+		ExpectedStmt: `SELECT * FROM public.table1`,
+	},
+	{
+		Constructed: Update(Table1).Set(Table1.Column1, "10").
+			WhereIf(true, Table1.Column2.Eq(String("foo"))).
+			WhereIf(false, Table1.Column3.Gt(Int64(1))),
+		ExpectedStmt: `UPDATE public.table1 SET column1 = $1 WHERE table1.column2 = $2`,
+	},
+	{
+		Constructed:  Update(Table1).Set(Table1.Column1, "10").Where(Noop()),
+		ExpectedStmt: `UPDATE public.table1 SET column1 = $1`,
+	},
+	{
+		Constructed:  Delete(Table1).WhereIf(false, Table1.Column1.Eq(String("foo"))),
+		ExpectedStmt: `DELETE FROM public.table1`,
+	},
+	{
+		Constructed:  Delete(Table1).Using(Table2).On(Table1.Column1.Eq(Table2.Column1)).Where(Noop()),
+		ExpectedStmt: `DELETE FROM public.table1 USING public.table2 WHERE table1.column1 = table2.column1`,
+	},
+}
+
+func TestConditions(t *testing.T) {
+	runTestCases(t, conditionTestCases)
+}
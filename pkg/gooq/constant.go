@@ -22,8 +22,49 @@ const (
 	Join JoinType = iota
 	LeftOuterJoin
 	NotJoined
+	// the join types below are appended after NotJoined to keep its value
+	RightOuterJoin
+	FullOuterJoin
+	CrossJoin
+	NaturalJoin
 )
 
+func (t JoinType) String() string {
+	switch t {
+	case Join:
+		return "JOIN"
+	case LeftOuterJoin:
+		return "LEFT OUTER JOIN"
+	case RightOuterJoin:
+		return "RIGHT OUTER JOIN"
+	case FullOuterJoin:
+		return "FULL OUTER JOIN"
+	case CrossJoin:
+		return "CROSS JOIN"
+	case NaturalJoin:
+		return "NATURAL JOIN"
+	default:
+		return ""
+	}
+}
+
+// https://www.postgresql.org/docs/12/queries-union.html
+type SetOperator string
+
+const (
+	SetOperatorUnion        = SetOperator("UNION")
+	SetOperatorUnionAll     = SetOperator("UNION ALL")
+	SetOperatorIntersect    = SetOperator("INTERSECT")
+	SetOperatorIntersectAll = SetOperator("INTERSECT ALL")
+	SetOperatorExcept       = SetOperator("EXCEPT")
+	SetOperatorExceptAll    = SetOperator("EXCEPT ALL")
+)
+
+// INTERSECT binds more tightly than UNION and EXCEPT
+func (op SetOperator) isIntersect() bool {
+	return op == SetOperatorIntersect || op == SetOperatorIntersectAll
+}
+
 type LockingType int
 
 const (
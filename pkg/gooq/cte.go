@@ -0,0 +1,199 @@
+package gooq
+
+// 7.8. WITH Queries (Common Table Expressions)
+// https://www.postgresql.org/docs/12/queries-with.html
+
+type WithStep interface {
+	SelectWithStep
+	With(alias string, t Selectable) WithStep
+	WithRecursive(alias string, anchor, recursive Selectable) WithStep
+	WithCTE(ctes ...CommonTableExpression) WithStep
+	InsertInto(t Table) InsertSetStep
+	Update(t Table) UpdateSetStep
+	Delete(t Table) DeleteUsingStep
+}
+
+type CommonTableExpression interface {
+	Renderable
+	GetName() string
+	// Columns renames the columns of the query, i.e. name (column, ...) AS (...)
+	Columns(columns ...string) CommonTableExpression
+	Materialized() CommonTableExpression
+	NotMaterialized() CommonTableExpression
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Implementation
+///////////////////////////////////////////////////////////////////////////////
+
+type materialization int
+
+const (
+	materializationDefault materialization = iota
+	materializationMaterialized
+	materializationNotMaterialized
+)
+
+type commonTableExpression struct {
+	name            string
+	columns         []string
+	query           Selectable
+	recursive       Selectable
+	materialization materialization
+}
+
+// CTE defines a named query that can be attached to a statement with
+// WithCTE(...). The query may be a data-modifying statement with a
+// RETURNING clause.
+func CTE(name string, query Selectable) CommonTableExpression {
+	return &commonTableExpression{name: name, query: query}
+}
+
+// RecursiveCTE defines a recursive query as the UNION ALL of the anchor
+// (non-recursive term) and the recursive term which references name.
+// Attaching it to a statement renders WITH RECURSIVE.
+func RecursiveCTE(name string, anchor, recursive Selectable) CommonTableExpression {
+	return &commonTableExpression{name: name, query: anchor, recursive: recursive}
+}
+
+func (cte *commonTableExpression) GetName() string {
+	return cte.name
+}
+
+func (cte *commonTableExpression) Columns(columns ...string) CommonTableExpression {
+	c := *cte
+	c.columns = columns
+	return &c
+}
+
+func (cte *commonTableExpression) Materialized() CommonTableExpression {
+	c := *cte
+	c.materialization = materializationMaterialized
+	return &c
+}
+
+func (cte *commonTableExpression) NotMaterialized() CommonTableExpression {
+	c := *cte
+	c.materialization = materializationNotMaterialized
+	return &c
+}
+
+func (cte *commonTableExpression) isRecursive() bool {
+	return cte.recursive != nil
+}
+
+func (cte *commonTableExpression) Render(
+	builder *Builder,
+) {
+	builder.Print(builder.QuoteIdentifier(cte.name))
+	if len(cte.columns) > 0 {
+		builder.Print(" (")
+		for index, column := range cte.columns {
+			builder.Print(builder.QuoteIdentifier(column))
+			if index != len(cte.columns)-1 {
+				builder.Print(", ")
+			}
+		}
+		builder.Print(")")
+	}
+	builder.Print(" AS ")
+	if cte.materialization != materializationDefault &&
+		builder.requireFeature(DialectFeatureCTEMaterialization) {
+		if cte.materialization == materializationNotMaterialized {
+			builder.Print("NOT ")
+		}
+		builder.Print("MATERIALIZED ")
+	}
+	builder.Print("(")
+	cte.renderQuery(builder, cte.query)
+	if cte.recursive != nil {
+		builder.Print(" UNION ALL ")
+		cte.renderQuery(builder, cte.recursive)
+	}
+	builder.Print(")")
+}
+
+func (cte *commonTableExpression) renderQuery(
+	builder *Builder, query Selectable,
+) {
+	switch query.(type) {
+	case *insert, *update, *deletion:
+		builder.requireFeature(DialectFeatureDataModifyingCTE)
+	}
+	query.Render(builder)
+}
+
+// withClause is embedded by every statement that can be prefixed by WITH
+type withClause struct {
+	ctes []CommonTableExpression
+}
+
+func (w withClause) clone() withClause {
+	return withClause{ctes: w.ctes[:len(w.ctes):len(w.ctes)]}
+}
+
+func (w *withClause) addCTEs(ctes ...CommonTableExpression) {
+	w.ctes = append(w.ctes, ctes...)
+}
+
+func (w *withClause) render(
+	builder *Builder,
+) {
+	if len(w.ctes) == 0 {
+		return
+	}
+	builder.Print("WITH ")
+	for _, cte := range w.ctes {
+		if impl, ok := cte.(*commonTableExpression); ok && impl.isRecursive() {
+			builder.Print("RECURSIVE ")
+			break
+		}
+	}
+	for index, cte := range w.ctes {
+		cte.Render(builder)
+		if index != len(w.ctes)-1 {
+			builder.Print(", ")
+		}
+	}
+	builder.Print(" ")
+}
+
+func With(alias string, t Selectable) WithStep {
+	return WithCTE(CTE(alias, t))
+}
+
+func WithRecursive(alias string, anchor, recursive Selectable) WithStep {
+	return WithCTE(RecursiveCTE(alias, anchor, recursive))
+}
+
+func WithCTE(ctes ...CommonTableExpression) WithStep {
+	s := &selection{}
+	s.with.addCTEs(ctes...)
+	return s
+}
+
+func (s *selection) With(alias string, t Selectable) WithStep {
+	return s.WithCTE(CTE(alias, t))
+}
+
+func (s *selection) WithRecursive(alias string, anchor, recursive Selectable) WithStep {
+	return s.WithCTE(RecursiveCTE(alias, anchor, recursive))
+}
+
+func (s *selection) WithCTE(ctes ...CommonTableExpression) WithStep {
+	s = s.clone()
+	s.with.addCTEs(ctes...)
+	return s
+}
+
+func (s *selection) InsertInto(t Table) InsertSetStep {
+	return &insert{with: s.with.clone(), table: t}
+}
+
+func (s *selection) Update(t Table) UpdateSetStep {
+	return &update{with: s.with.clone(), table: t}
+}
+
+func (s *selection) Delete(t Table) DeleteUsingStep {
+	return &deletion{with: s.with.clone(), table: t}
+}
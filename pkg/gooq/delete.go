@@ -19,7 +19,15 @@ type DeleteOnStep interface {
 
 type DeleteWhereStep interface {
 	DeleteResultStep
-	Where(...Expression) DeleteReturningStep
+	Where(...Expression) DeleteConditionStep
+	WhereIf(bool, ...Expression) DeleteConditionStep
+}
+
+type DeleteConditionStep interface {
+	DeleteReturningStep
+	AndWhere(...Expression) DeleteConditionStep
+	OrWhere(...Expression) DeleteConditionStep
+	WhereIf(bool, ...Expression) DeleteConditionStep
 }
 
 type DeleteReturningStep interface {
@@ -28,11 +36,14 @@ type DeleteReturningStep interface {
 }
 
 type DeleteResultStep interface {
+	Buildable
 	Fetchable
+	RowFetchable
 	Renderable
 }
 
 type DeleteFinalStep interface {
+	Buildable
 	Executable
 	Renderable
 }
@@ -44,6 +55,7 @@ type DeleteFinalStep interface {
 // https://www.postgresql.org/docs/11/sql-delete.html
 
 type deletion struct {
+	with           withClause
 	table          Table
 	using          Selectable
 	conditions     []Expression
@@ -55,22 +67,57 @@ func Delete(t Table) DeleteUsingStep {
 	return &deletion{table: t}
 }
 
+// clone returns a copy of d for a step to modify, see selection.clone
+func (d *deletion) clone() *deletion {
+	c := *d
+	c.with = d.with.clone()
+	c.conditions = c.conditions[:len(c.conditions):len(c.conditions)]
+	return &c
+}
+
 func (d *deletion) Using(s Selectable) DeleteOnStep {
+	d = d.clone()
 	d.using = s
 	return d
 }
 
 func (d *deletion) On(c ...Expression) DeleteWhereStep {
+	d = d.clone()
 	d.usingPredicate = c
 	return d
 }
 
-func (d *deletion) Where(c ...Expression) DeleteReturningStep {
-	d.conditions = c
+// Where adds conditions to the WHERE clause, they are combined with AND with
+// each other and with the conditions given before
+func (d *deletion) Where(c ...Expression) DeleteConditionStep {
+	return d.AndWhere(c...)
+}
+
+func (d *deletion) AndWhere(c ...Expression) DeleteConditionStep {
+	d = d.clone()
+	d.conditions = append(d.conditions, c...)
 	return d
 }
 
+// OrWhere replaces the WHERE clause by (<conditions given before> OR
+// <conditions>)
+func (d *deletion) OrWhere(c ...Expression) DeleteConditionStep {
+	d = d.clone()
+	d.conditions = orConditions(d.conditions, c)
+	return d
+}
+
+// WhereIf adds conditions to the WHERE clause like Where only when cond is
+// true
+func (d *deletion) WhereIf(cond bool, c ...Expression) DeleteConditionStep {
+	if !cond {
+		return d
+	}
+	return d.AndWhere(c...)
+}
+
 func (d *deletion) Returning(f ...Expression) DeleteResultStep {
+	d = d.clone()
 	d.returning = f
 	return d
 }
@@ -81,13 +128,19 @@ func (d *deletion) Returning(f ...Expression) DeleteResultStep {
 
 func (d *deletion) Exec(dl Dialect, db DBInterface) (sql.Result, error) {
 	builder := d.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
 	return db.Exec(builder.String(), builder.arguments...)
 }
 
 func (d *deletion) ExecWithContext(
 	ctx context.Context, dl Dialect, db DBInterface) (sql.Result, error) {
 	builder := d.Build(dl)
-	return db.ExecContext(ctx, builder.String(), builder.arguments...)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return withContextHooks(ctx, db).ExecContext(ctx, builder.String(), builder.arguments...)
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -96,6 +149,9 @@ func (d *deletion) ExecWithContext(
 
 func (d *deletion) Fetch(dl Dialect, db DBInterface) (*sqlx.Rows, error) {
 	builder := d.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
 	return db.Queryx(builder.String(), builder.arguments...)
 }
 
@@ -104,16 +160,36 @@ func (d *deletion) FetchRow(dl Dialect, db DBInterface) *sqlx.Row {
 	return db.QueryRowx(builder.String(), builder.arguments...)
 }
 
+func (d *deletion) FetchOne(dl Dialect, db DBInterface) (*sqlx.Row, error) {
+	builder := d.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return db.QueryRowx(builder.String(), builder.arguments...), nil
+}
+
 func (d *deletion) FetchWithContext(
 	ctx context.Context, dl Dialect, db DBInterface) (*sqlx.Rows, error) {
 	builder := d.Build(dl)
-	return db.QueryxContext(ctx, builder.String(), builder.arguments...)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return withContextHooks(ctx, db).QueryxContext(ctx, builder.String(), builder.arguments...)
 }
 
 func (d *deletion) FetchRowWithContext(
 	ctx context.Context, dl Dialect, db DBInterface) *sqlx.Row {
 	builder := d.Build(dl)
-	return db.QueryRowxContext(ctx, builder.String(), builder.arguments...)
+	return withContextHooks(ctx, db).QueryRowxContext(ctx, builder.String(), builder.arguments...)
+}
+
+func (d *deletion) FetchOneWithContext(
+	ctx context.Context, dl Dialect, db DBInterface) (*sqlx.Row, error) {
+	builder := d.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return withContextHooks(ctx, db).QueryRowxContext(ctx, builder.String(), builder.arguments...), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -121,9 +197,9 @@ func (d *deletion) FetchRowWithContext(
 ///////////////////////////////////////////////////////////////////////////////
 
 func (d *deletion) Build(dl Dialect) *Builder {
-	builder := Builder{}
-	d.Render(&builder)
-	return &builder
+	builder := NewBuilder(dl)
+	d.Render(builder)
+	return builder
 }
 
 // https://www.postgresql.org/docs/10/sql-delete.html
@@ -131,27 +207,31 @@ func (d *deletion) Render(
 	builder *Builder,
 ) {
 
+	// [ WITH [ RECURSIVE ] with_query [, ...] ]
+	d.with.render(builder)
+
 	// DELETE FROM table_name
-	builder.Printf("DELETE FROM %s", d.table.GetQualifiedName())
+	builder.Printf("DELETE FROM %s", builder.quoteTableName(d.table))
 
 	conditions := d.conditions
-	if d.using != nil {
+	if d.using != nil && builder.requireFeature(DialectFeatureDeleteUsing) {
 		// render USING clause
 		builder.Printf(" USING ")
 		d.using.Render(builder)
 		// there is no "ON" clause in postgres, this is pattern is from jOOQ.
 		// https://www.jooq.org/doc/3.12/manual-single-page/#delete-statement
-		conditions = append(d.usingPredicate, conditions...)
+		conditions = append(d.usingPredicate[:len(d.usingPredicate):len(d.usingPredicate)],
+			conditions...)
 	}
 
-	if len(conditions) > 0 {
+	if conditions = withoutNoops(conditions); len(conditions) > 0 {
 		// [ WHERE condition ]
 		builder.Print(" WHERE ")
 		builder.RenderConditions(conditions)
 	}
 
 	// [ RETURNING output_expression ]
-	if d.returning != nil {
+	if d.returning != nil && builder.requireFeature(DialectFeatureReturning) {
 		builder.Print(" RETURNING ")
 		builder.RenderExpressions(d.returning)
 	}
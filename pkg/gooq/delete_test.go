@@ -5,26 +5,49 @@ import "testing"
 var deleteTestCases = []TestCase{
 	{
 		Constructed:  Delete(Table1).Where(Table1.Column1.Eq(String("foo"))),
-		ExpectedStmt: `DELETE FROM public.table1 WHERE "table1".column1 = $1`,
+		ExpectedStmt: `DELETE FROM public.table1 WHERE table1.column1 = $1`,
 	},
 	{
 		Constructed:  Delete(Table1).Using(Table2).On(Table1.Column1.Eq(Table2.Column2)),
-		ExpectedStmt: `DELETE FROM public.table1 USING public.table2 WHERE "table1".column1 = "table2".column2`,
+		ExpectedStmt: `DELETE FROM public.table1 USING public.table2 WHERE table1.column1 = table2.column2`,
 	},
 	{
 		Constructed:  Delete(Table1).Using(Table2).On(Table1.Column1.Eq(Table2.Column2)).Where(Table1.Column1.Eq(String("foo"))),
-		ExpectedStmt: `DELETE FROM public.table1 USING public.table2 WHERE "table1".column1 = "table2".column2 AND "table1".column1 = $1`,
+		ExpectedStmt: `DELETE FROM public.table1 USING public.table2 WHERE table1.column1 = table2.column2 AND table1.column1 = $1`,
 	},
 	{
 		Constructed:  Delete(Table1).Using(Select().From(Table2).As("foo")).On(Table1.Column1.Eq(Table2.Column2)),
-		ExpectedStmt: `DELETE FROM public.table1 USING (SELECT * FROM public.table2) AS "foo" WHERE "table1".column1 = "table2".column2`,
+		ExpectedStmt: `DELETE FROM public.table1 USING (SELECT * FROM public.table2) AS foo WHERE table1.column1 = table2.column2`,
+	},
+	{
+		Constructed: With("stale", Select(Table2.Column1).From(Table2).Where(Table2.BoolColumn.IsEq(false))).
+			Delete(Table1).Where(Table1.Column1.In(Select(NewStringField(NewTable("", "stale"), "column1")).From(NewTable("", "stale")))),
+		ExpectedStmt: `WITH stale AS (SELECT table2.column1 FROM public.table2 WHERE table2.bool_column = $1) DELETE FROM public.table1 WHERE table1.column1 IN (SELECT stale.column1 FROM stale)`,
 	},
 	{
 		Constructed:  Delete(Table1).Where(Table1.Column1.Eq(String("foo"))).Returning(Table1.Column1),
-		ExpectedStmt: `DELETE FROM public.table1 WHERE "table1".column1 = $1 RETURNING "table1".column1`,
+		ExpectedStmt: `DELETE FROM public.table1 WHERE table1.column1 = $1 RETURNING table1.column1`,
 	},
 }
 
 func TestDelete(t *testing.T) {
 	runTestCases(t, deleteTestCases)
 }
+
+func TestDerivedDeletes(t *testing.T) {
+	base := Delete(Table1).Where(Table1.Column1.Eq(String("foo")))
+	runTestCases(t, []TestCase{
+		{
+			Constructed:  base.AndWhere(Table1.Column3.Gt(Int64(1))),
+			ExpectedStmt: `DELETE FROM public.table1 WHERE table1.column1 = $1 AND table1.column3 > $2`,
+		},
+		{
+			Constructed:  base.OrWhere(Table1.Column2.Eq(String("bar")), Table1.Column3.Gt(Int64(1))),
+			ExpectedStmt: `DELETE FROM public.table1 WHERE (table1.column1 = $1 OR (table1.column2 = $2 AND table1.column3 > $3))`,
+		},
+		{
+			Constructed:  base,
+			ExpectedStmt: `DELETE FROM public.table1 WHERE table1.column1 = $1`,
+		},
+	})
+}
@@ -0,0 +1,277 @@
+package gooq
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DialectFeature identifies a construct whose availability depends on the
+// SQL dialect a statement is rendered for.
+type DialectFeature int
+
+const (
+	DialectFeatureILike DialectFeature = iota
+	DialectFeatureIsDistinctFrom
+	DialectFeatureSqrtOperator
+	DialectFeatureDistinctOn
+	DialectFeatureAggregateFilter
+	DialectFeatureReturning
+	DialectFeatureOnConflictOnConstraint
+	DialectFeatureUpdateFrom
+	DialectFeatureDeleteUsing
+	DialectFeatureRowLocking
+	DialectFeatureKeyLocking
+	DialectFeatureWindowFrameGroups
+	DialectFeatureWindowFrameExclusion
+	DialectFeatureCTEMaterialization
+	DialectFeatureDataModifyingCTE
+	DialectFeatureFullOuterJoin
+	DialectFeatureLateralJoin
+	DialectFeatureIntersectExceptAll
+	DialectFeatureParenthesizedSetOperand
+	DialectFeatureArray
+	DialectFeatureJsonb
+	DialectFeatureRowValueAssignment
+	DialectFeatureWhereCurrentOf
+	DialectFeatureMerge
+	DialectFeatureMaterializedView
+	DialectFeatureTableFunction
+	DialectFeatureCall
+)
+
+func (f DialectFeature) String() string {
+	switch f {
+	case DialectFeatureILike:
+		return "ILIKE"
+	case DialectFeatureIsDistinctFrom:
+		return "IS DISTINCT FROM"
+	case DialectFeatureSqrtOperator:
+		return "|/ (square root operator)"
+	case DialectFeatureDistinctOn:
+		return "DISTINCT ON"
+	case DialectFeatureAggregateFilter:
+		return "FILTER (WHERE ...)"
+	case DialectFeatureReturning:
+		return "RETURNING"
+	case DialectFeatureOnConflictOnConstraint:
+		return "ON CONFLICT ON CONSTRAINT"
+	case DialectFeatureUpdateFrom:
+		return "UPDATE ... FROM"
+	case DialectFeatureDeleteUsing:
+		return "DELETE ... USING"
+	case DialectFeatureRowLocking:
+		return "row locking clause"
+	case DialectFeatureKeyLocking:
+		return "FOR NO KEY UPDATE / FOR KEY SHARE"
+	case DialectFeatureWindowFrameGroups:
+		return "GROUPS window frame"
+	case DialectFeatureWindowFrameExclusion:
+		return "window frame EXCLUDE"
+	case DialectFeatureCTEMaterialization:
+		return "[NOT] MATERIALIZED common table expression"
+	case DialectFeatureDataModifyingCTE:
+		return "data-modifying statement in WITH"
+	case DialectFeatureFullOuterJoin:
+		return "FULL OUTER JOIN"
+	case DialectFeatureLateralJoin:
+		return "LATERAL join"
+	case DialectFeatureIntersectExceptAll:
+		return "INTERSECT ALL / EXCEPT ALL"
+	case DialectFeatureParenthesizedSetOperand:
+		return "parenthesized operand of a set operation"
+	case DialectFeatureArray:
+		return "array type"
+	case DialectFeatureJsonb:
+		return "jsonb type"
+	case DialectFeatureRowValueAssignment:
+		return "SET (...) = (SELECT ...)"
+	case DialectFeatureWhereCurrentOf:
+		return "WHERE CURRENT OF"
+	case DialectFeatureMerge:
+		return "MERGE"
+	case DialectFeatureMaterializedView:
+		return "REFRESH MATERIALIZED VIEW"
+	case DialectFeatureTableFunction:
+		return "a function in FROM"
+	case DialectFeatureCall:
+		return "CALL"
+	default:
+		return fmt.Sprintf("DialectFeature(%d)", int(f))
+	}
+}
+
+// UnsupportedFeatureError is reported when a statement uses a construct that
+// cannot be expressed in the dialect it is being rendered for.
+type UnsupportedFeatureError struct {
+	Dialect Dialect
+	Feature DialectFeature
+}
+
+func (e *UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("%s is not supported in the %s dialect", e.Feature, e.Dialect)
+}
+
+type upsertStyle int
+
+const (
+	// INSERT ... ON CONFLICT [target] DO NOTHING | DO UPDATE SET ...
+	upsertStyleOnConflict upsertStyle = iota
+	// INSERT IGNORE ... | INSERT ... ON DUPLICATE KEY UPDATE ...
+	upsertStyleOnDuplicateKey
+)
+
+type dialectSpec struct {
+	dialect         Dialect
+	name            string
+	identifierQuote string
+	// plainIdentifier matches the identifiers that are parsed as is (e.g.
+	// not case folded) without quotes, unless they are reservedWords
+	plainIdentifier *regexp.Regexp
+	reservedWords   keywordSet
+	// numberedPlaceholders renders $1, $2, ... instead of ?
+	numberedPlaceholders bool
+	upsertStyle          upsertStyle
+	// offsetWithoutLimit is rendered before OFFSET when no LIMIT is given
+	// for dialects that do not accept a bare OFFSET clause
+	offsetWithoutLimit string
+	// parenthesizedSelect is true when a SELECT may be wrapped in parentheses
+	// as an operand of UNION or as the source of INSERT ... SELECT
+	parenthesizedSelect bool
+	// backslashEscapes is true when backslashes are escape characters in
+	// string constants
+	backslashEscapes bool
+	// bytesFormat formats the hex digits of a binary string constant
+	bytesFormat string
+	features    map[DialectFeature]bool
+}
+
+var dialectSpecs = map[Dialect]*dialectSpec{
+	Postgres: {
+		dialect:              Postgres,
+		name:                 "postgres",
+		identifierQuote:      `"`,
+		plainIdentifier:      regexp.MustCompile(`^[a-z_][a-z0-9_$]*$`),
+		reservedWords:        postgresReservedWords,
+		numberedPlaceholders: true,
+		upsertStyle:          upsertStyleOnConflict,
+		parenthesizedSelect:  true,
+		bytesFormat:          `'\x%s'`,
+		features: map[DialectFeature]bool{
+			DialectFeatureILike:                   true,
+			DialectFeatureIsDistinctFrom:          true,
+			DialectFeatureSqrtOperator:            true,
+			DialectFeatureDistinctOn:              true,
+			DialectFeatureAggregateFilter:         true,
+			DialectFeatureReturning:               true,
+			DialectFeatureOnConflictOnConstraint:  true,
+			DialectFeatureUpdateFrom:              true,
+			DialectFeatureDeleteUsing:             true,
+			DialectFeatureRowLocking:              true,
+			DialectFeatureKeyLocking:              true,
+			DialectFeatureWindowFrameGroups:       true,
+			DialectFeatureWindowFrameExclusion:    true,
+			DialectFeatureCTEMaterialization:      true,
+			DialectFeatureDataModifyingCTE:        true,
+			DialectFeatureFullOuterJoin:           true,
+			DialectFeatureLateralJoin:             true,
+			DialectFeatureIntersectExceptAll:      true,
+			DialectFeatureParenthesizedSetOperand: true,
+			DialectFeatureArray:                   true,
+			DialectFeatureJsonb:                   true,
+			DialectFeatureRowValueAssignment:      true,
+			DialectFeatureWhereCurrentOf:          true,
+			DialectFeatureMaterializedView:        true,
+			DialectFeatureTableFunction:           true,
+			// 11+
+			DialectFeatureCall: true,
+			// 15+
+			DialectFeatureMerge: true,
+		},
+	},
+	// https://www.sqlite.org/lang.html (3.35+ for RETURNING)
+	Sqlite: {
+		dialect:            Sqlite,
+		name:               "sqlite",
+		identifierQuote:    `"`,
+		plainIdentifier:    regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`),
+		reservedWords:      sqliteReservedWords,
+		upsertStyle:        upsertStyleOnConflict,
+		offsetWithoutLimit: "LIMIT -1",
+		bytesFormat:        "X'%s'",
+		features: map[DialectFeature]bool{
+			DialectFeatureIsDistinctFrom:  true,
+			DialectFeatureAggregateFilter: true,
+			DialectFeatureReturning:       true,
+			DialectFeatureUpdateFrom:      true,
+			// https://www.sqlite.org/windowfunctions.html
+			DialectFeatureWindowFrameGroups:    true,
+			DialectFeatureWindowFrameExclusion: true,
+			DialectFeatureCTEMaterialization:   true,
+			// 3.39+
+			DialectFeatureFullOuterJoin: true,
+			// 3.15+
+			DialectFeatureRowValueAssignment: true,
+		},
+	},
+	// https://dev.mysql.com/doc/refman/8.0/en/sql-statements.html
+	MySQL: {
+		dialect:             MySQL,
+		name:                "mysql",
+		identifierQuote:     "`",
+		plainIdentifier:     regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`),
+		reservedWords:       mysqlReservedWords,
+		upsertStyle:         upsertStyleOnDuplicateKey,
+		offsetWithoutLimit:  "LIMIT 18446744073709551615",
+		parenthesizedSelect: true,
+		backslashEscapes:    true,
+		bytesFormat:         "X'%s'",
+		features: map[DialectFeature]bool{
+			DialectFeatureRowLocking: true,
+			DialectFeatureCall:       true,
+			// 8.0.14+
+			DialectFeatureLateralJoin: true,
+			// 8.0.31+
+			DialectFeatureIntersectExceptAll:      true,
+			DialectFeatureParenthesizedSetOperand: true,
+		},
+	},
+}
+
+// operatorFeatures lists the operators that are not portable across dialects
+var operatorFeatures = map[Operator]DialectFeature{
+	OperatorILike:          DialectFeatureILike,
+	OperatorIsDistinctFrom: DialectFeatureIsDistinctFrom,
+	OperatorSqrt:           DialectFeatureSqrtOperator,
+}
+
+func (d Dialect) String() string {
+	if spec, ok := dialectSpecs[d]; ok {
+		return spec.name
+	}
+	return fmt.Sprintf("Dialect(%d)", int(d))
+}
+
+// Supports reports whether the dialect can render the given feature
+func (d Dialect) Supports(feature DialectFeature) bool {
+	spec, ok := dialectSpecs[d]
+	return ok && spec.features[feature]
+}
+
+func (spec *dialectSpec) placeholder(index int) string {
+	if spec.numberedPlaceholders {
+		return fmt.Sprintf("$%d", index)
+	}
+	return "?"
+}
+
+// needsQuoting reports whether name has to be quoted to be parsed as is
+func (spec *dialectSpec) needsQuoting(name string) bool {
+	return !spec.plainIdentifier.MatchString(name) || spec.reservedWords.contains(name)
+}
+
+func (spec *dialectSpec) quoteIdentifier(name string) string {
+	quote := spec.identifierQuote
+	escaped := strings.Replace(name, quote, quote+quote, -1)
+	return quote + escaped + quote
+}
@@ -0,0 +1,419 @@
+package gooq
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type DialectTestCase struct {
+	Constructed  Buildable
+	Dialect      Dialect
+	ExpectedStmt string
+	Errors       []error
+}
+
+var dialectTestCases = []DialectTestCase{
+	{
+		Constructed:  Select(Table1.Column1.As("result")).From(Table1).Where(Table1.Column2.Eq(String("foo"))),
+		Dialect:      Postgres,
+		ExpectedStmt: `SELECT table1.column1 AS result FROM public.table1 WHERE table1.column2 = $1`,
+	},
+	{
+		Constructed:  Select(Table1.Column1.As("result")).From(Table1).Where(Table1.Column2.Eq(String("foo"))),
+		Dialect:      MySQL,
+		ExpectedStmt: "SELECT table1.column1 AS result FROM public.table1 WHERE table1.column2 = ?",
+	},
+	{
+		Constructed:  Select(Table1.Column1).From(Table1).Where(Table1.Column2.IsIn("foo", "bar")),
+		Dialect:      Sqlite,
+		ExpectedStmt: `SELECT table1.column1 FROM public.table1 WHERE table1.column2 IN (?, ?)`,
+	},
+	{
+		Constructed:  Select().From(Table1).Offset(10),
+		Dialect:      Sqlite,
+		ExpectedStmt: `SELECT * FROM public.table1 LIMIT -1 OFFSET 10`,
+	},
+	{
+		Constructed:  Select().From(Table1).Offset(10),
+		Dialect:      MySQL,
+		ExpectedStmt: `SELECT * FROM public.table1 LIMIT 18446744073709551615 OFFSET 10`,
+	},
+	{
+		Constructed:  Select().From(Table1).Union(Select().From(Table2)),
+		Dialect:      Sqlite,
+		ExpectedStmt: `SELECT * FROM public.table1 UNION SELECT * FROM public.table2`,
+	},
+	{
+		Constructed:  Select().From(Table1).ExceptAll(Select().From(Table2)).OrderBy(Table1.Column1).Limit(3),
+		Dialect:      Sqlite,
+		ExpectedStmt: `SELECT * FROM public.table1 EXCEPT ALL SELECT * FROM public.table2 ORDER BY table1.column1 LIMIT 3`,
+		Errors:       []error{&UnsupportedFeatureError{Dialect: Sqlite, Feature: DialectFeatureIntersectExceptAll}},
+	},
+	{
+		Constructed:  Select().From(Table1).Union(Select().From(Table2).Limit(1)),
+		Dialect:      Sqlite,
+		ExpectedStmt: `SELECT * FROM public.table1 UNION SELECT * FROM public.table2 LIMIT 1`,
+		Errors:       []error{&UnsupportedFeatureError{Dialect: Sqlite, Feature: DialectFeatureParenthesizedSetOperand}},
+	},
+	{
+		Constructed:  Select().From(Table1).Intersect(Select().From(Table2)),
+		Dialect:      MySQL,
+		ExpectedStmt: "SELECT * FROM public.table1 INTERSECT (SELECT * FROM public.table2)",
+	},
+	{
+		Constructed:  Select().From(Table1).Where(Table1.Tags.Overlaps(Table2.Tags)),
+		Dialect:      MySQL,
+		ExpectedStmt: "SELECT * FROM public.table1 WHERE table1.tags && table2.tags",
+		Errors:       []error{&UnsupportedFeatureError{Dialect: MySQL, Feature: DialectFeatureArray}},
+	},
+	{
+		Constructed:  Select(Table1.Data.GetText("a")).From(Table1).Where(Table1.Data.Contains(Table2.Data)),
+		Dialect:      Sqlite,
+		ExpectedStmt: `SELECT table1.data ->> ? FROM public.table1 WHERE table1.data @> table2.data`,
+		Errors: []error{
+			&UnsupportedFeatureError{Dialect: Sqlite, Feature: DialectFeatureJsonb},
+			&UnsupportedFeatureError{Dialect: Sqlite, Feature: DialectFeatureJsonb},
+		},
+	},
+	{
+		Constructed:  Select().From(Table1).Where(Table1.Column1.ILike("foo%")),
+		Dialect:      MySQL,
+		ExpectedStmt: "SELECT * FROM public.table1 WHERE table1.column1 ILIKE ?",
+		Errors:       []error{&UnsupportedFeatureError{Dialect: MySQL, Feature: DialectFeatureILike}},
+	},
+	{
+		Constructed:  Select(Table1.Column1).DistinctOn(Table1.Column2).From(Table1),
+		Dialect:      Sqlite,
+		ExpectedStmt: `SELECT table1.column1 FROM public.table1`,
+		Errors:       []error{&UnsupportedFeatureError{Dialect: Sqlite, Feature: DialectFeatureDistinctOn}},
+	},
+	{
+		Constructed:  Select().From(Table1).For(LockingTypeUpdate, LockingOptionSkipLocked),
+		Dialect:      MySQL,
+		ExpectedStmt: `SELECT * FROM public.table1 FOR UPDATE SKIP LOCKED`,
+	},
+	{
+		Constructed:  Select().From(Table1).For(LockingTypeNoKeyUpdate, LockingOptionNone),
+		Dialect:      MySQL,
+		ExpectedStmt: `SELECT * FROM public.table1`,
+		Errors:       []error{&UnsupportedFeatureError{Dialect: MySQL, Feature: DialectFeatureKeyLocking}},
+	},
+	{
+		Constructed:  Select().From(Table1).For(LockingTypeUpdate, LockingOptionNone),
+		Dialect:      Sqlite,
+		ExpectedStmt: `SELECT * FROM public.table1`,
+		Errors:       []error{&UnsupportedFeatureError{Dialect: Sqlite, Feature: DialectFeatureRowLocking}},
+	},
+	{
+		Constructed: Select(Sum(Table1.Column3).Over(Window().OrderBy(Table1.Column3).
+			GroupsBetween(UnboundedPreceding, CurrentRow))).From(Table1),
+		Dialect:      MySQL,
+		ExpectedStmt: "SELECT SUM(table1.column3) OVER (ORDER BY table1.column3 GROUPS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) FROM public.table1",
+		Errors:       []error{&UnsupportedFeatureError{Dialect: MySQL, Feature: DialectFeatureWindowFrameGroups}},
+	},
+	{
+		Constructed:  WithCTE(CTE("a", Select().From(Table1)).Materialized()).Select().From(NewTable("", "a")),
+		Dialect:      MySQL,
+		ExpectedStmt: "WITH a AS (SELECT * FROM public.table1) SELECT * FROM a",
+		Errors:       []error{&UnsupportedFeatureError{Dialect: MySQL, Feature: DialectFeatureCTEMaterialization}},
+	},
+	{
+		Constructed:  With("a", Delete(Table1).Where(Table1.BoolColumn.IsEq(true)).Returning(Table1.ID)).Select().From(NewTable("", "a")),
+		Dialect:      Sqlite,
+		ExpectedStmt: `WITH a AS (DELETE FROM public.table1 WHERE table1.bool_column = ? RETURNING table1.id) SELECT * FROM a`,
+		Errors:       []error{&UnsupportedFeatureError{Dialect: Sqlite, Feature: DialectFeatureDataModifyingCTE}},
+	},
+	{
+		Constructed:  Select().From(Table1).FullOuterJoin(Table2).Using(Table1.Column1),
+		Dialect:      MySQL,
+		ExpectedStmt: "SELECT * FROM public.table1 FULL OUTER JOIN public.table2 USING (column1)",
+		Errors:       []error{&UnsupportedFeatureError{Dialect: MySQL, Feature: DialectFeatureFullOuterJoin}},
+	},
+	{
+		Constructed:  Select().From(Table1).CrossJoinLateral(Select().From(Table2).As("t")),
+		Dialect:      Sqlite,
+		ExpectedStmt: `SELECT * FROM public.table1 CROSS JOIN (SELECT * FROM public.table2) AS t`,
+		Errors:       []error{&UnsupportedFeatureError{Dialect: Sqlite, Feature: DialectFeatureLateralJoin}},
+	},
+	{
+		Constructed:  InsertInto(Table1).Select(Select(Table1.Column1).From(Table2)),
+		Dialect:      Sqlite,
+		ExpectedStmt: `INSERT INTO public.table1 SELECT table1.column1 FROM public.table2`,
+	},
+	{
+		Constructed:  InsertInto(Table1).Set(Table1.Column1, "foo").OnConflictDoNothing(),
+		Dialect:      MySQL,
+		ExpectedStmt: "INSERT IGNORE INTO public.table1 (column1) VALUES (?)",
+	},
+	{
+		Constructed: InsertInto(Table1).
+			Set(Table1.Column1, "foo").Set(Table1.Column2, "bar").
+			OnConflictDoUpdate(&Table1Constraint).
+			SetUpdateColumns(Table1.Column2),
+		Dialect:      MySQL,
+		ExpectedStmt: "INSERT INTO public.table1 (column1, column2) VALUES (?, ?) ON DUPLICATE KEY UPDATE column2 = VALUES(column2)",
+	},
+	{
+		Constructed: InsertInto(Table1).
+			Set(Table1.Column1, "foo").Set(Table1.Column2, "bar").
+			OnConflictDoUpdate(&Table1Constraint).
+			SetUpdateColumns(Table1.Column2),
+		Dialect:      Sqlite,
+		ExpectedStmt: `INSERT INTO public.table1 (column1, column2) VALUES (?, ?) ON CONFLICT DO UPDATE SET column2 = excluded.column2`,
+		Errors:       []error{&UnsupportedFeatureError{Dialect: Sqlite, Feature: DialectFeatureOnConflictOnConstraint}},
+	},
+	{
+		Constructed: InsertInto(Table2).
+			Set(Table2.Column1, "foo").
+			Set(Table2.Column2, "bar").
+			OnConflictDoUpdate(&Table2Constraint).
+			SetUpdateColumns(Table2.Column3),
+		Dialect:      Sqlite,
+		ExpectedStmt: `INSERT INTO public.table2 (column1, column2) VALUES (?, ?) ON CONFLICT (column1, column2) WHERE ((bool_column)::bool <> 'true'::bool) DO UPDATE SET column3 = excluded.column3`,
+	},
+	{
+		Constructed: InsertInto(Table1).
+			Set(Table1.Column1, "foo").Set(Table1.Column3, 1).
+			OnConflict(Table1.Column1).DoUpdate().
+			SetUpdates(Table1.Column3, Table1.Column3.Add(ExcludedNumeric(Table1.Column3))).
+			Where(Table1.Column3.Lt(ExcludedNumeric(Table1.Column3))),
+		Dialect:      MySQL,
+		ExpectedStmt: "INSERT INTO public.table1 (column1, column3) VALUES (?, ?) ON DUPLICATE KEY UPDATE column3 = table1.column3 + VALUES(column3)",
+		Errors: []error{&UnsupportedClauseError{
+			Statement: "INSERT ... ON DUPLICATE KEY UPDATE",
+			Clause:    "WHERE",
+		}},
+	},
+	{
+		Constructed: InsertInto(Table1).
+			Set(Table1.Column1, "foo").
+			OnConflict(Table1.Column1).DoNothing(),
+		Dialect:      Sqlite,
+		ExpectedStmt: `INSERT INTO public.table1 (column1) VALUES (?) ON CONFLICT (column1) DO NOTHING`,
+	},
+	{
+		Constructed:  InsertInto(Table1).Set(Table1.Column1, "foo").Returning(Table1.Column1),
+		Dialect:      MySQL,
+		ExpectedStmt: "INSERT INTO public.table1 (column1) VALUES (?)",
+		Errors:       []error{&UnsupportedFeatureError{Dialect: MySQL, Feature: DialectFeatureReturning}},
+	},
+	{
+		Constructed: Update(Table1).Set(Table1.Column1, Table2.Column1).
+			From(Table2).Where(Table1.Column2.Eq(Table2.Column2)),
+		Dialect:      MySQL,
+		ExpectedStmt: "UPDATE public.table1 SET column1 = table2.column1 WHERE table1.column2 = table2.column2",
+		Errors:       []error{&UnsupportedFeatureError{Dialect: MySQL, Feature: DialectFeatureUpdateFrom}},
+	},
+	{
+		Constructed:  Delete(Table1).Using(Table2).On(Table1.Column1.Eq(Table2.Column2)),
+		Dialect:      Sqlite,
+		ExpectedStmt: `DELETE FROM public.table1`,
+		Errors:       []error{&UnsupportedFeatureError{Dialect: Sqlite, Feature: DialectFeatureDeleteUsing}},
+	},
+	{
+		Constructed:  Delete(Table1).Where(Table1.Column1.Eq(String("foo"))).Returning(Table1.Column1),
+		Dialect:      Sqlite,
+		ExpectedStmt: `DELETE FROM public.table1 WHERE table1.column1 = ? RETURNING table1.column1`,
+	},
+	{
+		Constructed:  Update(Table1).SetRow([]Field{Table1.Column1, Table1.Column2}, Select(Table2.Column1, Table2.Column2).From(Table2)),
+		Dialect:      Sqlite,
+		ExpectedStmt: `UPDATE public.table1 SET (column1, column2) = (SELECT table2.column1, table2.column2 FROM public.table2)`,
+	},
+	{
+		Constructed:  Update(Table1).SetRow([]Field{Table1.Column1, Table1.Column2}, Select(Table2.Column1, Table2.Column2).From(Table2)),
+		Dialect:      MySQL,
+		ExpectedStmt: "UPDATE public.table1 SET (column1, column2) = (SELECT table2.column1, table2.column2 FROM public.table2)",
+		Errors:       []error{&UnsupportedFeatureError{Dialect: MySQL, Feature: DialectFeatureRowValueAssignment}},
+	},
+	{
+		Constructed: MergeInto(Table1).Using(Table2).On(Table1.ID.Eq(Table2.ID)).
+			WhenMatched().ThenUpdate().Set(Table1.Column1, Table2.Column1).
+			WhenNotMatched().ThenInsert(Table1.ID, Table1.Column1).Values(Table2.ID, Table2.Column1),
+		Dialect:      Sqlite,
+		ExpectedStmt: `INSERT INTO public.table1 (id, column1) SELECT table2.id, table2.column1 FROM public.table2 WHERE TRUE ON CONFLICT (id) DO UPDATE SET column1 = excluded.column1`,
+	},
+	{
+		Constructed: MergeInto(Table1).Using(Table2).On(Table1.ID.Eq(Table2.ID)).
+			WhenMatched().ThenUpdate().Set(Table1.Column1, Table2.Column1).
+			WhenNotMatched().ThenInsert(Table1.ID, Table1.Column1).Values(Table2.ID, Table2.Column1),
+		Dialect:      MySQL,
+		ExpectedStmt: "INSERT INTO public.table1 (id, column1) SELECT table2.id, table2.column1 FROM public.table2 WHERE TRUE ON DUPLICATE KEY UPDATE column1 = VALUES(column1)",
+	},
+	{
+		Constructed: MergeInto(Table1).Using(Table2).On(Table1.ID.Eq(Table2.ID)).
+			WhenNotMatched().ThenInsert(Table1.ID).Values(Table2.ID),
+		Dialect:      MySQL,
+		ExpectedStmt: "INSERT IGNORE INTO public.table1 (id) SELECT table2.id FROM public.table2 WHERE TRUE",
+	},
+	{
+		Constructed: MergeInto(Table1).Using(Table2).On(Table1.ID.Eq(Table2.ID), Table1.Column3.Gt(Int64(1))).
+			WhenNotMatched().ThenInsert(Table1.ID).Values(Table2.ID),
+		Dialect:      MySQL,
+		ExpectedStmt: "INSERT IGNORE INTO public.table1 (id) SELECT table2.id FROM public.table2 WHERE TRUE",
+		Errors: []error{&UnsupportedClauseError{
+			Statement: "INSERT ... ON DUPLICATE KEY UPDATE",
+			Clause:    "an ON condition that does not compare a column of the target for equality",
+		}},
+	},
+	{
+		Constructed:  Update(Table1).Set(Table1.Column1, "foo").WhereCurrentOf("c"),
+		Dialect:      Sqlite,
+		ExpectedStmt: `UPDATE public.table1 SET column1 = ?`,
+		Errors:       []error{&UnsupportedFeatureError{Dialect: Sqlite, Feature: DialectFeatureWhereCurrentOf}},
+	},
+	{
+		Constructed:  Update(Table1).OldValues(oldTable1, Table1.ID).Set(Table1.Column1, "bar").Where(Table1.Column2.Eq(String("foo"))).Returning(oldTable1.Column1),
+		Dialect:      Sqlite,
+		ExpectedStmt: `WITH old AS (SELECT * FROM public.table1 WHERE table1.column2 = ?) UPDATE public.table1 SET column1 = ? FROM old WHERE table1.id = old.id AND table1.column2 = ? RETURNING old.column1`,
+	},
+}
+
+func TestDialects(t *testing.T) {
+	for _, testCase := range dialectTestCases {
+		t.Run(testCase.Dialect.String()+" "+testCase.ExpectedStmt, func(t *testing.T) {
+			builder := testCase.Constructed.Build(testCase.Dialect)
+			require.Equal(t, testCase.ExpectedStmt, builder.String())
+			require.Equal(t, testCase.Errors, builder.Errors())
+		})
+	}
+}
+
+func TestUnknownDialect(t *testing.T) {
+	builder := Select().From(Table1).Build(Dialect(42))
+	require.Equal(t, `SELECT * FROM public.table1`, builder.String())
+	require.EqualError(t, builder.Err(), "unknown dialect Dialect(42)")
+}
+
+func TestExecReportsBuildErrors(t *testing.T) {
+	_, err := Update(Table1).Set(Table1.Column1, []string{"foo"}).Exec(Postgres, nil)
+	require.Equal(t, &InvalidLiteralError{Value: []string{"foo"}}, err)
+	_, err = Select().From(Table1).Seek("foo").Fetch(Postgres, nil)
+	var arityErr *SeekArityError
+	require.True(t, errors.As(err, &arityErr))
+}
+
+func TestFetchOneReportsBuildErrors(t *testing.T) {
+	_, err := Select().From(Table1).Where(Table1.Column1.ILike("foo%")).FetchOne(Sqlite, nil)
+	require.Equal(t, &UnsupportedFeatureError{Dialect: Sqlite, Feature: DialectFeatureILike}, err)
+	_, err = Delete(Table1).Where(Table1.ID.IsNotNull()).Returning(Table1.ID).FetchOneWithContext(context.Background(), MySQL, nil)
+	require.Equal(t, &UnsupportedFeatureError{Dialect: MySQL, Feature: DialectFeatureReturning}, err)
+	var result string
+	require.Equal(t, &SeekArityError{Values: 1, Ordering: 0},
+		ScanRow(nil, Select().From(Table1).Seek("foo"), &result))
+	_, err = ScanCountWithContext(context.Background(), nil, Select().From(Table1).Seek("foo"))
+	require.Equal(t, &SeekArityError{Values: 1, Ordering: 0}, err)
+}
+
+type keywordTable struct {
+	TableImpl
+	User  StringField
+	Order IntField
+	Name  StringField
+}
+
+func newKeywordTable() *keywordTable {
+	instance := &keywordTable{}
+	instance.TableImpl.Initialize("My Schema", "group")
+	instance.User = NewStringField(instance, "user")
+	instance.Order = NewIntField(instance, "order")
+	instance.Name = NewStringField(instance, "DisplayName")
+	return instance
+}
+
+func TestQuotePolicy(t *testing.T) {
+	keywords := newKeywordTable()
+	constraint := DatabaseConstraint{Name: "group_User_key", Columns: []Field{keywords.User}}
+	aliased := Table1.As("t1").(*testTable)
+	keywordAliased := Table1.As("Order").(*testTable)
+	var testCases = []struct {
+		constructed Renderable
+		dialect     Dialect
+		policy      QuotePolicy
+		expected    string
+	}{
+		{
+			constructed: Select(keywords.User, keywords.Name.As("select")).From(keywords).
+				OrderBy(keywords.Order),
+			dialect:  Postgres,
+			expected: `SELECT "group"."user", "group"."DisplayName" AS "select" FROM "My Schema"."group" ORDER BY "group"."order"`,
+		},
+		{
+			constructed: Select(aliased.Column1.As("result")).From(aliased),
+			dialect:     Postgres,
+			policy:      QuoteAlways,
+			expected:    `SELECT "t1"."column1" AS "result" FROM "public"."table1" AS "t1"`,
+		},
+		{
+			constructed: Select(keywords.User).From(keywords),
+			dialect:     Postgres,
+			policy:      QuoteNever,
+			expected:    `SELECT group.user FROM My Schema.group`,
+		},
+		{
+			constructed: Select(keywords.User, keywords.Name).From(keywords),
+			dialect:     MySQL,
+			expected:    "SELECT `group`.user, `group`.DisplayName FROM `My Schema`.`group`",
+		},
+		{
+			constructed: Select(keywordAliased.Column1).From(keywordAliased),
+			dialect:     Sqlite,
+			expected:    `SELECT "Order".column1 FROM public.table1 AS "Order"`,
+		},
+		{
+			constructed: InsertInto(keywords).Set(keywords.User, "foo").Set(keywords.Order, 1).
+				OnConflictDoUpdate(&constraint).SetUpdateColumns(keywords.Order),
+			dialect:  Postgres,
+			expected: `INSERT INTO "My Schema"."group" ("user", "order") VALUES ($1, $2) ON CONFLICT ON CONSTRAINT "group_User_key" DO UPDATE SET "order" = excluded."order"`,
+		},
+		{
+			constructed: Update(keywords).Set(keywords.Name, "foo").Where(keywords.User.Eq(String("bar"))),
+			dialect:     Postgres,
+			expected:    `UPDATE "My Schema"."group" SET "DisplayName" = $1 WHERE "group"."user" = $2`,
+		},
+		{
+			constructed: Delete(keywords).Where(keywords.Order.Eq(Int64(1))),
+			dialect:     Postgres,
+			expected:    `DELETE FROM "My Schema"."group" WHERE "group"."order" = $1`,
+		},
+		{
+			constructed: WithCTE(CTE("Recent", Select(keywords.User).From(keywords)).Columns("user")).
+				Select().From(Table1),
+			dialect:  Postgres,
+			expected: `WITH "Recent" ("user") AS (SELECT "group"."user" FROM "My Schema"."group") SELECT * FROM public.table1`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.expected, func(t *testing.T) {
+			builder := NewBuilder(testCase.dialect).WithQuotePolicy(testCase.policy)
+			testCase.constructed.Render(builder)
+			require.Equal(t, testCase.expected, builder.String())
+			require.NoError(t, builder.Err())
+		})
+	}
+}
+
+func TestDialectQuotePolicy(t *testing.T) {
+	keywords := newKeywordTable()
+	stmt := Select(keywords.User, Table1.Column1).From(keywords).Join(Table1).On(Table1.Column1.Eq(keywords.Name)).
+		Where(Table1.Column2.Eq(String("foo")))
+
+	builder := NewBuilder(Postgres).WithQuotePolicy(QuoteAlways)
+	stmt.Render(builder)
+	require.Equal(t, `SELECT "group"."user", "table1"."column1" FROM "My Schema"."group" JOIN "public"."table1" ON "table1"."column1" = "group"."DisplayName" WHERE "table1"."column2" = $1`, builder.String())
+	require.Equal(t, []interface{}{"foo"}, builder.Arguments())
+	require.Equal(t, Postgres, builder.Dialect())
+	require.NoError(t, builder.Err())
+
+	builder = NewBuilder(MySQL).WithQuotePolicy(QuoteNever)
+	stmt.Render(builder)
+	require.Equal(t, "SELECT group.user, table1.column1 FROM My Schema.group JOIN public.table1 ON table1.column1 = group.DisplayName WHERE table1.column2 = ?", builder.String())
+
+	// the statements are built with the default policy
+	require.Equal(t, `SELECT "group"."user", table1.column1 FROM "My Schema"."group" JOIN public.table1 ON table1.column1 = "group"."DisplayName" WHERE table1.column2 = $1`,
+		stmt.Build(Postgres).String())
+	require.Equal(t, Postgres, NewBuilder(Postgres).Dialect())
+}
@@ -0,0 +1,122 @@
+package gooq
+
+import (
+	"fmt"
+)
+
+// The errors below are collected by the Builder while a statement is
+// rendered instead of panicking. They are returned by Builder.Err() and
+// Builder.Errors(), and by the Fetch and Exec methods of the statements which
+// do not send a statement that failed to build to the database.
+
+// SeekArityError is reported when Seek(...) is not given a value for every
+// ORDER BY expression
+type SeekArityError struct {
+	Values   int
+	Ordering int
+}
+
+func (e *SeekArityError) Error() string {
+	return fmt.Sprintf("seek has %d values but the ORDER BY clause has %d expressions",
+		e.Values, e.Ordering)
+}
+
+// UnsupportedSeekOperatorError is reported when an ORDER BY expression of a
+// seek is not a plain, ASC or DESC expression
+type UnsupportedSeekOperatorError struct {
+	Operator Operator
+}
+
+func (e *UnsupportedSeekOperatorError) Error() string {
+	return fmt.Sprintf("seek does not support operator %s", e.Operator)
+}
+
+// MissingFromError is reported when a SELECT * has no FROM clause
+type MissingFromError struct{}
+
+func (e *MissingFromError) Error() string {
+	return "SELECT * requires a FROM clause"
+}
+
+// EmptyInListError is reported when IN or NOT IN is given no values, which
+// Postgres does not accept
+type EmptyInListError struct {
+	Operator Operator
+}
+
+func (e *EmptyInListError) Error() string {
+	return fmt.Sprintf("%s requires at least one value", e.Operator)
+}
+
+// InvalidLiteralError is reported when a literal cannot be passed as an
+// argument, e.g. a slice which has to be wrapped in an array literal
+type InvalidLiteralError struct {
+	Value interface{}
+}
+
+func (e *InvalidLiteralError) Error() string {
+	return fmt.Sprintf("literal value cannot be of kind slice (%T)", e.Value)
+}
+
+// ArgumentCountError is reported when a function is given too many arguments
+type ArgumentCountError struct {
+	Function string
+	Max      int
+	Actual   int
+}
+
+func (e *ArgumentCountError) Error() string {
+	return fmt.Sprintf("%s takes at most %d arguments, got %d", e.Function, e.Max, e.Actual)
+}
+
+// UnboundParamError is reported when a prepared statement is executed
+// without a binding for one of its parameters, or when a statement with
+// parameters is executed without being prepared
+type UnboundParamError struct {
+	Name string
+}
+
+func (e *UnboundParamError) Error() string {
+	return fmt.Sprintf("parameter %q is not bound", e.Name)
+}
+
+// UnsupportedClauseError is reported when a clause cannot be used in a
+// statement, e.g. a seek on a compound select
+type UnsupportedClauseError struct {
+	Statement string
+	Clause    string
+}
+
+func (e *UnsupportedClauseError) Error() string {
+	return fmt.Sprintf("%s is not supported on %s", e.Clause, e.Statement)
+}
+
+// UnknownSortKeyError is reported when a sort key is not one of the
+// SortFields, e.g. when a client asks to order by an arbitrary column
+type UnknownSortKeyError struct {
+	Key string
+}
+
+func (e *UnknownSortKeyError) Error() string {
+	return fmt.Sprintf("unknown sort key %q", e.Key)
+}
+
+// MissingOldValuesKeyError is reported when OldValues is given no keys, which
+// would join every updated row with every old row
+type MissingOldValuesKeyError struct {
+	Table string
+}
+
+func (e *MissingOldValuesKeyError) Error() string {
+	return fmt.Sprintf("old values of %s require at least one key", e.Table)
+}
+
+// MissingJoinConditionError is reported when a join that requires ON or USING
+// is given neither conditions nor columns, which is not valid SQL
+type MissingJoinConditionError struct {
+	JoinType JoinType
+}
+
+func (e *MissingJoinConditionError) Error() string {
+	return fmt.Sprintf("%s requires at least one condition", e.JoinType)
+}
@@ -1,11 +1,16 @@
 package gooq
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"regexp"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gopkg.in/guregu/null.v3"
 )
 
 type Expression interface {
@@ -33,6 +38,13 @@ type Expression interface {
 	// https://www.postgresql.org/docs/12/sql-expressions.html
 	Filter(...Expression) Expression
 
+	// 4.2.8 Window Function Calls
+	// https://www.postgresql.org/docs/12/sql-expressions.html
+	// Over() renders OVER () and Over(Window()...) renders OVER (...), it
+	// takes at most one window specification
+	Over(...WindowSpecification) Expression
+	OverWindow(name string) Expression
+
 	// IMPORTANT: these are for internal use only.
 	getExpressions() []Expression
 	getOperator() Operator
@@ -60,6 +72,8 @@ const (
 	ExpressionTypeBinary
 	// https://en.wikipedia.org/wiki/Plural_quantification
 	ExpressionTypeMultigrade
+	// see Noop()
+	ExpressionTypeNoop
 )
 
 type expressionImpl struct {
@@ -69,6 +83,7 @@ type expressionImpl struct {
 	expressions        []Expression // can be operands or function arguments
 	value              interface{}
 	hasParentheses     bool
+	requiredFeatures   []DialectFeature
 }
 
 type ExpressionImplOption func(*expressionImpl)
@@ -79,6 +94,14 @@ func HasParentheses(hasParentheses bool) ExpressionImplOption {
 	}
 }
 
+// requiresFeature marks operators that share their symbol with portable
+// operators, e.g. @> of arrays and jsonb, as dialect specific
+func requiresFeature(feature DialectFeature) ExpressionImplOption {
+	return func(impl *expressionImpl) {
+		impl.requiredFeatures = append(impl.requiredFeatures, feature)
+	}
+}
+
 func newKeywordExpression(
 	value string,
 ) Expression {
@@ -287,9 +310,31 @@ func (expr *expressionImpl) Filter(
 	return newFilterWhereFunction(expr.getOriginal(), expressions...)
 }
 
+func (expr *expressionImpl) Over(
+	specification ...WindowSpecification,
+) Expression {
+	return newOverFunction(expr.getOriginal(), specification)
+}
+
+func (expr *expressionImpl) OverWindow(
+	name string,
+) Expression {
+	return newOverWindowFunction(expr.getOriginal(), name)
+}
+
 func (expr *expressionImpl) Render(
 	builder *Builder,
 ) {
+	if feature, ok := operatorFeatures[expr.operator]; ok {
+		builder.requireFeature(feature)
+	}
+	for _, feature := range expr.requiredFeatures {
+		builder.requireFeature(feature)
+	}
+	if expr.isNoop() {
+		builder.Print("TRUE")
+		return
+	}
 	if expr.hasParentheses {
 		builder.Print("(")
 	}
@@ -314,25 +359,46 @@ func (expr *expressionImpl) Render(
 	case ExpressionTypeBinary:
 		lhs := expr.expressions[0]
 		rhs := expr.expressions[1]
-		builder.RenderExpression(lhs).
-			Print(" ").Print(expr.operator.String()).Print(" ").
-			RenderExpression(rhs)
+		if (expr.operator == OperatorIn || expr.operator == OperatorNotIn) &&
+			isEmptyExpressionArray(rhs) {
+			builder.errors = append(builder.errors, &EmptyInListError{Operator: expr.operator})
+		}
+		switch {
+		case isLogicalOperator(expr.operator) && isNoop(lhs):
+			builder.RenderExpression(rhs)
+		case isLogicalOperator(expr.operator) && isNoop(rhs):
+			builder.RenderExpression(lhs)
+		default:
+			builder.RenderExpression(lhs).
+				Print(" ").Print(expr.operator.String()).Print(" ").
+				RenderExpression(rhs)
+		}
 	case ExpressionTypeMultigrade:
-		for index, expression := range expr.expressions {
-			isLast := index == len(expr.expressions)-1
+		expressions := withoutNoops(expr.expressions)
+		for index, expression := range expressions {
+			isLast := index == len(expressions)-1
 			builder.RenderExpression(expression)
 			if !isLast {
 				builder.Print(" ").Print(expr.operator.String()).Print(" ")
 			}
 		}
 	default:
-		panic(fmt.Errorf("invalid operatorType=%v", expr.operator))
+		builder.errors = append(builder.errors,
+			fmt.Errorf("invalid expression type %v", expr.expressionType))
 	}
 	if expr.hasParentheses {
 		builder.Print(")")
 	}
 }
 
+func isEmptyExpressionArray(
+	expression Expression,
+) bool {
+	array, ok := expression.(*expressionImpl)
+	return ok && array.expressionType == ExpressionTypeExpressionArray &&
+		len(array.value.([]Expression)) == 0
+}
+
 func (expr *expressionImpl) getExpressions() []Expression {
 	return expr.expressions
 }
@@ -348,6 +414,11 @@ func (expr *expressionImpl) getOperator() Operator {
 	return expr.operator
 }
 
+// getLiteral returns the value of a literal expression
+func (expr *expressionImpl) getLiteral() (interface{}, bool) {
+	return expr.value, expr.expressionType == ExpressionTypeLiteral
+}
+
 func getOriginalExpressions(
 	expressions []Expression,
 ) []Expression {
@@ -411,6 +482,14 @@ func newMultigradeBooleanExpressionImpl(
 	return instance
 }
 
+func newUnaryPrefixBooleanExpressionImpl(
+	operator Operator, expr Expression, options ...ExpressionImplOption,
+) *boolExpressionImpl {
+	instance := &boolExpressionImpl{}
+	instance.expressionImpl.initUnaryPrefixExpression(operator, expr, options...)
+	return instance
+}
+
 func newUnaryPostfixBooleanExpressionImpl(
 	operator Operator, expr Expression,
 ) *boolExpressionImpl {
@@ -726,10 +805,13 @@ type DateTimeExpression interface {
 
 	// Table 9.29. Date/Time Operators
 	// https://www.postgresql.org/docs/11/functions-datetime.html
-	Add(rhs DateTimeExpression) DateTimeExpression
-	Sub(rhs DateTimeExpression) DateTimeExpression
-	Mult(rhs DateTimeExpression) DateTimeExpression
-	Div(rhs DateTimeExpression) DateTimeExpression
+	Add(rhs IntervalExpression) DateTimeExpression
+	Sub(rhs IntervalExpression) DateTimeExpression
+	// SubDateTime subtracts two timestamps, e.g. Now().SubDateTime(Table1.TimeColumn)
+	SubDateTime(rhs DateTimeExpression) IntervalExpression
+
+	// 9.9.3. AT TIME ZONE
+	AtTimeZone(zone string) DateTimeExpression
 }
 
 type dateTimeExpressionImpl struct {
@@ -737,7 +819,7 @@ type dateTimeExpressionImpl struct {
 }
 
 func newDateTimeExpressionImpl(
-	operator Operator, lhs, rhs DateTimeExpression,
+	operator Operator, lhs, rhs Expression,
 ) DateTimeExpression {
 	instance := &dateTimeExpressionImpl{}
 	instance.expressionImpl.initBinaryExpression(operator, lhs, rhs)
@@ -802,20 +884,169 @@ func (expr *dateTimeExpressionImpl) IsNotIn(value ...time.Time) BoolExpression {
 	return expr.expressionImpl.notInArray(expressions)
 }
 
-func (expr *dateTimeExpressionImpl) Add(rhs DateTimeExpression) DateTimeExpression {
+func (expr *dateTimeExpressionImpl) Add(rhs IntervalExpression) DateTimeExpression {
 	return newDateTimeExpressionImpl(OperatorAdd, expr, rhs)
 }
 
-func (expr *dateTimeExpressionImpl) Sub(rhs DateTimeExpression) DateTimeExpression {
+func (expr *dateTimeExpressionImpl) Sub(rhs IntervalExpression) DateTimeExpression {
 	return newDateTimeExpressionImpl(OperatorSub, expr, rhs)
 }
 
-func (expr *dateTimeExpressionImpl) Mult(rhs DateTimeExpression) DateTimeExpression {
-	return newDateTimeExpressionImpl(OperatorMult, expr, rhs)
+func (expr *dateTimeExpressionImpl) SubDateTime(rhs DateTimeExpression) IntervalExpression {
+	return newIntervalExpressionImpl(OperatorSub, expr, rhs)
+}
+
+func (expr *dateTimeExpressionImpl) AtTimeZone(zone string) DateTimeExpression {
+	return newDateTimeExpressionImpl(OperatorAtTimeZone, expr, String(zone))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Interval
+// https://www.postgresql.org/docs/11/datatype-datetime.html#DATATYPE-INTERVAL-INPUT
+///////////////////////////////////////////////////////////////////////////////
+
+type IntervalExpression interface {
+	Expression
+
+	// comparison operators helper methods
+	// https://www.postgresql.org/docs/11/functions-comparison.html
+	Lt(rhs IntervalExpression) BoolExpression
+	Lte(rhs IntervalExpression) BoolExpression
+	Gt(rhs IntervalExpression) BoolExpression
+	Gte(rhs IntervalExpression) BoolExpression
+	Eq(rhs IntervalExpression) BoolExpression
+	NotEq(rhs IntervalExpression) BoolExpression
+	IsLt(rhs time.Duration) BoolExpression
+	IsLte(rhs time.Duration) BoolExpression
+	IsGt(rhs time.Duration) BoolExpression
+	IsGte(rhs time.Duration) BoolExpression
+	IsEq(rhs time.Duration) BoolExpression
+	IsNotEq(rhs time.Duration) BoolExpression
+
+	// Table 9.29. Date/Time Operators
+	// https://www.postgresql.org/docs/11/functions-datetime.html
+	Add(rhs IntervalExpression) IntervalExpression
+	Sub(rhs IntervalExpression) IntervalExpression
+	Mult(rhs NumericExpression) IntervalExpression
+	Div(rhs NumericExpression) IntervalExpression
+}
+
+type intervalExpressionImpl struct {
+	expressionImpl
+}
+
+func newIntervalExpressionImpl(
+	operator Operator, lhs, rhs Expression,
+) IntervalExpression {
+	instance := &intervalExpressionImpl{}
+	instance.expressionImpl.initBinaryExpression(operator, lhs, rhs)
+	return instance
+}
+
+func (expr *intervalExpressionImpl) Lt(rhs IntervalExpression) BoolExpression {
+	return expr.expressionImpl.lt(rhs)
+}
+
+func (expr *intervalExpressionImpl) Lte(rhs IntervalExpression) BoolExpression {
+	return expr.expressionImpl.lte(rhs)
+}
+
+func (expr *intervalExpressionImpl) Gt(rhs IntervalExpression) BoolExpression {
+	return expr.expressionImpl.gt(rhs)
+}
+
+func (expr *intervalExpressionImpl) Gte(rhs IntervalExpression) BoolExpression {
+	return expr.expressionImpl.gte(rhs)
+}
+
+func (expr *intervalExpressionImpl) Eq(rhs IntervalExpression) BoolExpression {
+	return expr.expressionImpl.eq(rhs)
+}
+
+func (expr *intervalExpressionImpl) NotEq(rhs IntervalExpression) BoolExpression {
+	return expr.expressionImpl.notEq(rhs)
+}
+
+func (expr *intervalExpressionImpl) IsLt(rhs time.Duration) BoolExpression {
+	return expr.expressionImpl.lt(Interval(rhs))
+}
+
+func (expr *intervalExpressionImpl) IsLte(rhs time.Duration) BoolExpression {
+	return expr.expressionImpl.lte(Interval(rhs))
+}
+
+func (expr *intervalExpressionImpl) IsGt(rhs time.Duration) BoolExpression {
+	return expr.expressionImpl.gt(Interval(rhs))
+}
+
+func (expr *intervalExpressionImpl) IsGte(rhs time.Duration) BoolExpression {
+	return expr.expressionImpl.gte(Interval(rhs))
+}
+
+func (expr *intervalExpressionImpl) IsEq(rhs time.Duration) BoolExpression {
+	return expr.expressionImpl.eq(Interval(rhs))
+}
+
+func (expr *intervalExpressionImpl) IsNotEq(rhs time.Duration) BoolExpression {
+	return expr.expressionImpl.notEq(Interval(rhs))
+}
+
+func (expr *intervalExpressionImpl) Add(rhs IntervalExpression) IntervalExpression {
+	return newIntervalExpressionImpl(OperatorAdd, expr, rhs)
+}
+
+func (expr *intervalExpressionImpl) Sub(rhs IntervalExpression) IntervalExpression {
+	return newIntervalExpressionImpl(OperatorSub, expr, rhs)
 }
 
-func (expr *dateTimeExpressionImpl) Div(rhs DateTimeExpression) DateTimeExpression {
-	return newDateTimeExpressionImpl(OperatorDiv, expr, rhs)
+func (expr *intervalExpressionImpl) Mult(rhs NumericExpression) IntervalExpression {
+	return newIntervalExpressionImpl(OperatorMult, expr, rhs)
+}
+
+func (expr *intervalExpressionImpl) Div(rhs NumericExpression) IntervalExpression {
+	return newIntervalExpressionImpl(OperatorDiv, expr, rhs)
+}
+
+// intervalLiteral casts its parameter since an untyped parameter added to a
+// timestamp would be resolved as a timestamp rather than an interval
+type intervalLiteral struct {
+	intervalExpressionImpl
+	isISO bool
+}
+
+var isoIntervalPattern = regexp.MustCompile(
+	`^P(?:[-+]?\d+(?:\.\d+)?[YMWD])*(?:T(?:[-+]?\d+(?:\.\d+)?[HMS])+)?$`)
+
+func (expr *intervalLiteral) getLiteral() (interface{}, bool) {
+	return nil, false
+}
+
+func (expr *intervalLiteral) Render(
+	builder *Builder,
+) {
+	value := expr.value.(string)
+	if expr.isISO && (value == "P" || !isoIntervalPattern.MatchString(value)) {
+		builder.errors = append(builder.errors,
+			fmt.Errorf("invalid ISO 8601 interval %q", value))
+	}
+	builder.RenderLiteral(value)
+	builder.Print("::interval")
+}
+
+// formatISOInterval formats d as an ISO 8601 duration with microsecond
+// precision, the resolution of the interval type
+func formatISOInterval(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	seconds := d / time.Second
+	micros := (d % time.Second) / time.Microsecond
+	if micros == 0 {
+		return fmt.Sprintf("PT%s%dS", sign, seconds)
+	}
+	return fmt.Sprintf("PT%s%d.%06dS", sign, seconds, micros)
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -868,6 +1099,530 @@ func (expr *uuidExpressionImpl) IsNotIn(value ...uuid.UUID) BoolExpression {
 	return expr.expressionImpl.notInArray(expressions)
 }
 
+///////////////////////////////////////////////////////////////////////////////
+// Array
+// https://www.postgresql.org/docs/11/arrays.html
+///////////////////////////////////////////////////////////////////////////////
+
+// ArrayExpression is implemented by every typed array expression so array
+// functions such as ArrayLength(...) accept text[], int[] and uuid[] alike
+type ArrayExpression interface {
+	Expression
+	isArray()
+}
+
+type arrayExpressionImpl struct {
+	expressionImpl
+}
+
+func (expr *arrayExpressionImpl) isArray() {}
+
+// Table 9.48. Array Operators
+// https://www.postgresql.org/docs/11/functions-array.html
+
+func (expr *arrayExpressionImpl) contains(rhs Expression) BoolExpression {
+	return newBinaryBooleanExpressionImpl(OperatorContains, expr.getOriginal(), rhs,
+		requiresFeature(DialectFeatureArray))
+}
+
+func (expr *arrayExpressionImpl) containedBy(rhs Expression) BoolExpression {
+	return newBinaryBooleanExpressionImpl(OperatorContainedBy, expr.getOriginal(), rhs,
+		requiresFeature(DialectFeatureArray))
+}
+
+func (expr *arrayExpressionImpl) overlaps(rhs Expression) BoolExpression {
+	return newBinaryBooleanExpressionImpl(OperatorOverlaps, expr.getOriginal(), rhs,
+		requiresFeature(DialectFeatureArray))
+}
+
+// arraySubscript renders array[index] or array[lower:upper]. Subscripts are
+// 1-based. Anything but a column reference is parenthesized since
+// PostgreSQL only accepts subscripts directly after a column or parameter.
+type arraySubscript struct {
+	array Expression
+	lower int
+	upper null.Int
+}
+
+func (s *arraySubscript) render(
+	builder *Builder,
+) {
+	_, isField := s.array.(Field)
+	if !isField {
+		builder.Print("(")
+	}
+	builder.RenderExpression(s.array)
+	if !isField {
+		builder.Print(")")
+	}
+	if s.upper.Valid {
+		builder.Printf("[%d:%d]", s.lower, s.upper.Int64)
+	} else {
+		builder.Printf("[%d]", s.lower)
+	}
+}
+
+// String Array
+
+type StringArrayExpression interface {
+	ArrayExpression
+
+	Eq(rhs StringArrayExpression) BoolExpression
+	NotEq(rhs StringArrayExpression) BoolExpression
+
+	Contains(rhs StringArrayExpression) BoolExpression
+	ContainedBy(rhs StringArrayExpression) BoolExpression
+	Overlaps(rhs StringArrayExpression) BoolExpression
+
+	// 9.23.3. ANY/SOME (array), e.g. String("foo").Eq(Table1.Tags.Any())
+	// renders $1 = ANY(table1.tags)
+	Any() StringExpression
+	All() StringExpression
+
+	Index(index int) StringExpression
+	Slice(lower, upper int) StringArrayExpression
+	Append(value StringExpression) StringArrayExpression
+	Unnest() StringExpression
+}
+
+type stringArrayExpressionImpl struct {
+	arrayExpressionImpl
+}
+
+func (expr *stringArrayExpressionImpl) Eq(rhs StringArrayExpression) BoolExpression {
+	return expr.expressionImpl.eq(rhs)
+}
+
+func (expr *stringArrayExpressionImpl) NotEq(rhs StringArrayExpression) BoolExpression {
+	return expr.expressionImpl.notEq(rhs)
+}
+
+func (expr *stringArrayExpressionImpl) Contains(rhs StringArrayExpression) BoolExpression {
+	return expr.arrayExpressionImpl.contains(rhs)
+}
+
+func (expr *stringArrayExpressionImpl) ContainedBy(rhs StringArrayExpression) BoolExpression {
+	return expr.arrayExpressionImpl.containedBy(rhs)
+}
+
+func (expr *stringArrayExpressionImpl) Overlaps(rhs StringArrayExpression) BoolExpression {
+	return expr.arrayExpressionImpl.overlaps(rhs)
+}
+
+func (expr *stringArrayExpressionImpl) Any() StringExpression {
+	return NewStringExpressionFunction("ANY", expr.getOriginal())
+}
+
+func (expr *stringArrayExpressionImpl) All() StringExpression {
+	return NewStringExpressionFunction("ALL", expr.getOriginal())
+}
+
+func (expr *stringArrayExpressionImpl) Index(index int) StringExpression {
+	subscript := &stringSubscriptExpression{arraySubscript: arraySubscript{
+		array: expr.getOriginal(), lower: index}}
+	subscript.expressionImpl.initFunctionExpression(subscript)
+	return subscript
+}
+
+func (expr *stringArrayExpressionImpl) Slice(lower, upper int) StringArrayExpression {
+	subscript := &stringArraySubscriptExpression{arraySubscript: arraySubscript{
+		array: expr.getOriginal(), lower: lower, upper: null.IntFrom(int64(upper))}}
+	subscript.expressionImpl.initFunctionExpression(subscript)
+	return subscript
+}
+
+func (expr *stringArrayExpressionImpl) Append(value StringExpression) StringArrayExpression {
+	return NewStringArrayExpressionFunction("ARRAY_APPEND", expr.getOriginal(), value)
+}
+
+func (expr *stringArrayExpressionImpl) Unnest() StringExpression {
+	return NewStringExpressionFunction("UNNEST", expr.getOriginal())
+}
+
+type stringSubscriptExpression struct {
+	stringExpressionImpl
+	arraySubscript
+}
+
+func (expr *stringSubscriptExpression) Render(builder *Builder) {
+	expr.arraySubscript.render(builder)
+}
+
+type stringArraySubscriptExpression struct {
+	stringArrayExpressionImpl
+	arraySubscript
+}
+
+func (expr *stringArraySubscriptExpression) Render(builder *Builder) {
+	expr.arraySubscript.render(builder)
+}
+
+// Numeric Array
+
+type NumericArrayExpression interface {
+	ArrayExpression
+
+	Eq(rhs NumericArrayExpression) BoolExpression
+	NotEq(rhs NumericArrayExpression) BoolExpression
+
+	Contains(rhs NumericArrayExpression) BoolExpression
+	ContainedBy(rhs NumericArrayExpression) BoolExpression
+	Overlaps(rhs NumericArrayExpression) BoolExpression
+
+	// 9.23.3. ANY/SOME (array), e.g. Int64(1).Eq(Table1.Scores.Any())
+	// renders $1 = ANY(table1.scores)
+	Any() NumericExpression
+	All() NumericExpression
+
+	Index(index int) NumericExpression
+	Slice(lower, upper int) NumericArrayExpression
+	Append(value NumericExpression) NumericArrayExpression
+	Unnest() NumericExpression
+}
+
+type numericArrayExpressionImpl struct {
+	arrayExpressionImpl
+}
+
+func (expr *numericArrayExpressionImpl) Eq(rhs NumericArrayExpression) BoolExpression {
+	return expr.expressionImpl.eq(rhs)
+}
+
+func (expr *numericArrayExpressionImpl) NotEq(rhs NumericArrayExpression) BoolExpression {
+	return expr.expressionImpl.notEq(rhs)
+}
+
+func (expr *numericArrayExpressionImpl) Contains(rhs NumericArrayExpression) BoolExpression {
+	return expr.arrayExpressionImpl.contains(rhs)
+}
+
+func (expr *numericArrayExpressionImpl) ContainedBy(rhs NumericArrayExpression) BoolExpression {
+	return expr.arrayExpressionImpl.containedBy(rhs)
+}
+
+func (expr *numericArrayExpressionImpl) Overlaps(rhs NumericArrayExpression) BoolExpression {
+	return expr.arrayExpressionImpl.overlaps(rhs)
+}
+
+func (expr *numericArrayExpressionImpl) Any() NumericExpression {
+	return NewNumericExpressionFunction("ANY", expr.getOriginal())
+}
+
+func (expr *numericArrayExpressionImpl) All() NumericExpression {
+	return NewNumericExpressionFunction("ALL", expr.getOriginal())
+}
+
+func (expr *numericArrayExpressionImpl) Index(index int) NumericExpression {
+	subscript := &numericSubscriptExpression{arraySubscript: arraySubscript{
+		array: expr.getOriginal(), lower: index}}
+	subscript.expressionImpl.initFunctionExpression(subscript)
+	return subscript
+}
+
+func (expr *numericArrayExpressionImpl) Slice(lower, upper int) NumericArrayExpression {
+	subscript := &numericArraySubscriptExpression{arraySubscript: arraySubscript{
+		array: expr.getOriginal(), lower: lower, upper: null.IntFrom(int64(upper))}}
+	subscript.expressionImpl.initFunctionExpression(subscript)
+	return subscript
+}
+
+func (expr *numericArrayExpressionImpl) Append(value NumericExpression) NumericArrayExpression {
+	return NewNumericArrayExpressionFunction("ARRAY_APPEND", expr.getOriginal(), value)
+}
+
+func (expr *numericArrayExpressionImpl) Unnest() NumericExpression {
+	return NewNumericExpressionFunction("UNNEST", expr.getOriginal())
+}
+
+type numericSubscriptExpression struct {
+	numericExpressionImpl
+	arraySubscript
+}
+
+func (expr *numericSubscriptExpression) Render(builder *Builder) {
+	expr.arraySubscript.render(builder)
+}
+
+type numericArraySubscriptExpression struct {
+	numericArrayExpressionImpl
+	arraySubscript
+}
+
+func (expr *numericArraySubscriptExpression) Render(builder *Builder) {
+	expr.arraySubscript.render(builder)
+}
+
+// UUID Array
+
+type UUIDArrayExpression interface {
+	ArrayExpression
+
+	Eq(rhs UUIDArrayExpression) BoolExpression
+	NotEq(rhs UUIDArrayExpression) BoolExpression
+
+	Contains(rhs UUIDArrayExpression) BoolExpression
+	ContainedBy(rhs UUIDArrayExpression) BoolExpression
+	Overlaps(rhs UUIDArrayExpression) BoolExpression
+
+	// 9.23.3. ANY/SOME (array), e.g. Table2.ID.Eq(Table1.Owners.Any())
+	// renders table2.id = ANY(table1.owners)
+	Any() UUIDExpression
+	All() UUIDExpression
+
+	Index(index int) UUIDExpression
+	Slice(lower, upper int) UUIDArrayExpression
+	Append(value UUIDExpression) UUIDArrayExpression
+	Unnest() UUIDExpression
+}
+
+type uuidArrayExpressionImpl struct {
+	arrayExpressionImpl
+}
+
+func (expr *uuidArrayExpressionImpl) Eq(rhs UUIDArrayExpression) BoolExpression {
+	return expr.expressionImpl.eq(rhs)
+}
+
+func (expr *uuidArrayExpressionImpl) NotEq(rhs UUIDArrayExpression) BoolExpression {
+	return expr.expressionImpl.notEq(rhs)
+}
+
+func (expr *uuidArrayExpressionImpl) Contains(rhs UUIDArrayExpression) BoolExpression {
+	return expr.arrayExpressionImpl.contains(rhs)
+}
+
+func (expr *uuidArrayExpressionImpl) ContainedBy(rhs UUIDArrayExpression) BoolExpression {
+	return expr.arrayExpressionImpl.containedBy(rhs)
+}
+
+func (expr *uuidArrayExpressionImpl) Overlaps(rhs UUIDArrayExpression) BoolExpression {
+	return expr.arrayExpressionImpl.overlaps(rhs)
+}
+
+func (expr *uuidArrayExpressionImpl) Any() UUIDExpression {
+	return NewUUIDExpressionFunction("ANY", expr.getOriginal())
+}
+
+func (expr *uuidArrayExpressionImpl) All() UUIDExpression {
+	return NewUUIDExpressionFunction("ALL", expr.getOriginal())
+}
+
+func (expr *uuidArrayExpressionImpl) Index(index int) UUIDExpression {
+	subscript := &uuidSubscriptExpression{arraySubscript: arraySubscript{
+		array: expr.getOriginal(), lower: index}}
+	subscript.expressionImpl.initFunctionExpression(subscript)
+	return subscript
+}
+
+func (expr *uuidArrayExpressionImpl) Slice(lower, upper int) UUIDArrayExpression {
+	subscript := &uuidArraySubscriptExpression{arraySubscript: arraySubscript{
+		array: expr.getOriginal(), lower: lower, upper: null.IntFrom(int64(upper))}}
+	subscript.expressionImpl.initFunctionExpression(subscript)
+	return subscript
+}
+
+func (expr *uuidArrayExpressionImpl) Append(value UUIDExpression) UUIDArrayExpression {
+	return NewUUIDArrayExpressionFunction("ARRAY_APPEND", expr.getOriginal(), value)
+}
+
+func (expr *uuidArrayExpressionImpl) Unnest() UUIDExpression {
+	return NewUUIDExpressionFunction("UNNEST", expr.getOriginal())
+}
+
+type uuidSubscriptExpression struct {
+	uuidExpressionImpl
+	arraySubscript
+}
+
+func (expr *uuidSubscriptExpression) Render(builder *Builder) {
+	expr.arraySubscript.render(builder)
+}
+
+type uuidArraySubscriptExpression struct {
+	uuidArrayExpressionImpl
+	arraySubscript
+}
+
+func (expr *uuidArraySubscriptExpression) Render(builder *Builder) {
+	expr.arraySubscript.render(builder)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Jsonb
+// https://www.postgresql.org/docs/12/functions-json.html
+///////////////////////////////////////////////////////////////////////////////
+
+type JsonbExpression interface {
+	Expression
+
+	Eq(rhs JsonbExpression) BoolExpression
+	NotEq(rhs JsonbExpression) BoolExpression
+
+	// Table 9.44. json and jsonb Operators. Array indexes are 0-based and
+	// negative indexes count from the end.
+	Get(key string) JsonbExpression              // ->
+	GetIndex(index int) JsonbExpression          // ->
+	GetText(key string) StringExpression         // ->>
+	GetIndexText(index int) StringExpression     // ->>
+	GetPath(path ...string) JsonbExpression      // #>
+	GetPathText(path ...string) StringExpression // #>>
+
+	// Table 9.45. Additional jsonb Operators
+	Contains(rhs JsonbExpression) BoolExpression    // @>
+	ContainedBy(rhs JsonbExpression) BoolExpression // <@
+	HasKey(key string) BoolExpression               // ?
+	HasAnyKey(keys ...string) BoolExpression        // ?|
+	HasAllKeys(keys ...string) BoolExpression       // ?&
+	Concat(rhs JsonbExpression) JsonbExpression     // ||
+	DeleteKey(key string) JsonbExpression           // -
+	DeleteIndex(index int) JsonbExpression          // -
+	DeletePath(path ...string) JsonbExpression      // #-
+	PathExists(jsonPath string) BoolExpression      // @?
+	PathMatch(jsonPath string) BoolExpression       // @@
+}
+
+type jsonbExpressionImpl struct {
+	expressionImpl
+}
+
+func newBinaryJsonbExpressionImpl(
+	operator Operator, lhs, rhs Expression,
+) *jsonbExpressionImpl {
+	instance := &jsonbExpressionImpl{}
+	instance.expressionImpl.initBinaryExpression(operator, lhs, rhs,
+		requiresFeature(DialectFeatureJsonb))
+	return instance
+}
+
+func newBinaryJsonbTextExpressionImpl(
+	operator Operator, lhs, rhs Expression,
+) *stringExpressionImpl {
+	instance := &stringExpressionImpl{}
+	instance.expressionImpl.initBinaryExpression(operator, lhs, rhs,
+		requiresFeature(DialectFeatureJsonb))
+	return instance
+}
+
+func newBinaryJsonbBooleanExpressionImpl(
+	operator Operator, lhs, rhs Expression,
+) *boolExpressionImpl {
+	return newBinaryBooleanExpressionImpl(operator, lhs, rhs,
+		requiresFeature(DialectFeatureJsonb))
+}
+
+// jsonbIndex renders array indexes inline. A parameter would be sent
+// untyped and resolve to the text (object key) form of the operator.
+func jsonbIndex(index int) Expression {
+	return keyword(strconv.Itoa(index))
+}
+
+func (expr *jsonbExpressionImpl) Eq(rhs JsonbExpression) BoolExpression {
+	return expr.expressionImpl.eq(rhs)
+}
+
+func (expr *jsonbExpressionImpl) NotEq(rhs JsonbExpression) BoolExpression {
+	return expr.expressionImpl.notEq(rhs)
+}
+
+func (expr *jsonbExpressionImpl) Get(key string) JsonbExpression {
+	return newBinaryJsonbExpressionImpl(OperatorJsonGet, expr.getOriginal(), String(key))
+}
+
+func (expr *jsonbExpressionImpl) GetIndex(index int) JsonbExpression {
+	return newBinaryJsonbExpressionImpl(OperatorJsonGet, expr.getOriginal(), jsonbIndex(index))
+}
+
+func (expr *jsonbExpressionImpl) GetText(key string) StringExpression {
+	return newBinaryJsonbTextExpressionImpl(OperatorJsonGetText, expr.getOriginal(), String(key))
+}
+
+func (expr *jsonbExpressionImpl) GetIndexText(index int) StringExpression {
+	return newBinaryJsonbTextExpressionImpl(OperatorJsonGetText, expr.getOriginal(), jsonbIndex(index))
+}
+
+func (expr *jsonbExpressionImpl) GetPath(path ...string) JsonbExpression {
+	return newBinaryJsonbExpressionImpl(OperatorJsonGetPath, expr.getOriginal(), Literal(pq.StringArray(path)))
+}
+
+func (expr *jsonbExpressionImpl) GetPathText(path ...string) StringExpression {
+	return newBinaryJsonbTextExpressionImpl(OperatorJsonGetPathText, expr.getOriginal(), Literal(pq.StringArray(path)))
+}
+
+func (expr *jsonbExpressionImpl) Contains(rhs JsonbExpression) BoolExpression {
+	return newBinaryJsonbBooleanExpressionImpl(OperatorContains, expr.getOriginal(), rhs)
+}
+
+func (expr *jsonbExpressionImpl) ContainedBy(rhs JsonbExpression) BoolExpression {
+	return newBinaryJsonbBooleanExpressionImpl(OperatorContainedBy, expr.getOriginal(), rhs)
+}
+
+func (expr *jsonbExpressionImpl) HasKey(key string) BoolExpression {
+	return newBinaryJsonbBooleanExpressionImpl(OperatorJsonHasKey, expr.getOriginal(), String(key))
+}
+
+func (expr *jsonbExpressionImpl) HasAnyKey(keys ...string) BoolExpression {
+	return newBinaryJsonbBooleanExpressionImpl(OperatorJsonHasAnyKey, expr.getOriginal(), Literal(pq.StringArray(keys)))
+}
+
+func (expr *jsonbExpressionImpl) HasAllKeys(keys ...string) BoolExpression {
+	return newBinaryJsonbBooleanExpressionImpl(OperatorJsonHasAllKeys, expr.getOriginal(), Literal(pq.StringArray(keys)))
+}
+
+func (expr *jsonbExpressionImpl) Concat(rhs JsonbExpression) JsonbExpression {
+	return newBinaryJsonbExpressionImpl(OperatorConcat, expr.getOriginal(), rhs)
+}
+
+func (expr *jsonbExpressionImpl) DeleteKey(key string) JsonbExpression {
+	return newBinaryJsonbExpressionImpl(OperatorSub, expr.getOriginal(), String(key))
+}
+
+func (expr *jsonbExpressionImpl) DeleteIndex(index int) JsonbExpression {
+	return newBinaryJsonbExpressionImpl(OperatorSub, expr.getOriginal(), jsonbIndex(index))
+}
+
+func (expr *jsonbExpressionImpl) DeletePath(path ...string) JsonbExpression {
+	return newBinaryJsonbExpressionImpl(OperatorJsonDeletePath, expr.getOriginal(), Literal(pq.StringArray(path)))
+}
+
+func (expr *jsonbExpressionImpl) PathExists(jsonPath string) BoolExpression {
+	return newBinaryJsonbBooleanExpressionImpl(OperatorJsonPathExists, expr.getOriginal(), String(jsonPath))
+}
+
+func (expr *jsonbExpressionImpl) PathMatch(jsonPath string) BoolExpression {
+	return newBinaryJsonbBooleanExpressionImpl(OperatorJsonPathMatch, expr.getOriginal(), String(jsonPath))
+}
+
+// jsonbLiteral marshals its value when rendered so that marshalling errors
+// are reported by the builder
+type jsonbLiteral struct {
+	jsonbExpressionImpl
+}
+
+// getLiteral hides the Go value since the parameter is already cast to jsonb
+func (expr *jsonbLiteral) getLiteral() (interface{}, bool) {
+	return nil, false
+}
+
+func (expr *jsonbLiteral) Render(
+	builder *Builder,
+) {
+	var document string
+	switch value := expr.value.(type) {
+	case json.RawMessage:
+		document = string(value)
+	case []byte:
+		document = string(value)
+	default:
+		marshalled, err := json.Marshal(value)
+		if err != nil {
+			builder.errors = append(builder.errors, err)
+		}
+		document = string(marshalled)
+	}
+	builder.RenderLiteral(document)
+	builder.Print("::jsonb")
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // Other Data Types
 // https://www.postgresql.org/docs/11/datatype.html
@@ -884,3 +1639,25 @@ func getExpressionSlice(
 	}
 	return result
 }
+
+// orConditions combines two lists of conditions, which are each implicitly
+// combined with AND, into the single condition (lhs OR rhs). rhs alone is
+// returned when lhs is empty.
+func orConditions(lhs, rhs []Expression) []Expression {
+	if len(lhs) == 0 {
+		return rhs
+	}
+	if len(rhs) == 0 {
+		return lhs
+	}
+	return []Expression{newMultigradeBooleanExpressionImpl(OperatorOr,
+		[]Expression{conjunction(lhs), conjunction(rhs)}, HasParentheses(true))}
+}
+
+func conjunction(conditions []Expression) Expression {
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+	return newMultigradeBooleanExpressionImpl(OperatorAnd, conditions,
+		HasParentheses(true))
+}
@@ -1,215 +1,384 @@
 package gooq
 
 import (
+	"encoding/json"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 var expressionTestCases = []TestCase{
 	{
 		Constructed:  Count(Table1.Column1),
-		ExpectedStmt: `COUNT("table1".column1)`,
+		ExpectedStmt: `COUNT(table1.column1)`,
+	},
+	{
+		Constructed:  Count(Table1.Column1, Table1.Column2),
+		ExpectedStmt: `COUNT(table1.column1, table1.column2)`,
+		Errors:       []error{&ArgumentCountError{Function: "COUNT", Max: 1, Actual: 2}},
+	},
+	{
+		Constructed:  Table1.Column1.IsIn(),
+		ExpectedStmt: `table1.column1 IN ()`,
+		Errors:       []error{&EmptyInListError{Operator: OperatorIn}},
+	},
+	{
+		Constructed:  Table1.Column3.IsNotIn(),
+		ExpectedStmt: `table1.column3 NOT IN ()`,
+		Errors:       []error{&EmptyInListError{Operator: OperatorNotIn}},
+	},
+	{
+		Constructed:  Coalesce(Table1.Column3, newLiteralExpression([]int{1})),
+		ExpectedStmt: `COALESCE(table1.column3, $1)`,
+		Errors:       []error{&InvalidLiteralError{Value: []int{1}}},
 	},
 	{
 		Constructed:  Count(Table1.Column1).IsGt(5),
-		ExpectedStmt: `COUNT("table1".column1) > $1`,
+		ExpectedStmt: `COUNT(table1.column1) > $1`,
 		Arguments:    []interface{}{float64(5)},
 	},
 	{
 		Constructed:  Table1.Column1.Asc(),
-		ExpectedStmt: `"table1".column1 ASC`,
+		ExpectedStmt: `table1.column1 ASC`,
 	},
 	{
 		Constructed:  Table1.Column1.Desc(),
-		ExpectedStmt: `"table1".column1 DESC`,
+		ExpectedStmt: `table1.column1 DESC`,
 	},
 	{
 		Constructed:  Table1.Column1.IsNull(),
-		ExpectedStmt: `"table1".column1 IS NULL`,
+		ExpectedStmt: `table1.column1 IS NULL`,
 	},
 	{
 		Constructed:  Table1.Column1.IsNotNull(),
-		ExpectedStmt: `"table1".column1 IS NOT NULL`,
+		ExpectedStmt: `table1.column1 IS NOT NULL`,
 	},
 	{
 		Constructed:  Table1.Column1.Eq(Table2.Column1).Or(Table1.Column2.Eq(Table2.Column2)),
-		ExpectedStmt: `("table1".column1 = "table2".column1 OR "table1".column2 = "table2".column2)`,
+		ExpectedStmt: `(table1.column1 = table2.column1 OR table1.column2 = table2.column2)`,
 	},
 	{
 		Constructed:  Table1.Column1.Eq(Table2.Column1).And(Table1.Column2.Eq(Table2.Column2)),
-		ExpectedStmt: `("table1".column1 = "table2".column1 AND "table1".column2 = "table2".column2)`,
+		ExpectedStmt: `(table1.column1 = table2.column1 AND table1.column2 = table2.column2)`,
 	},
 	{
 		Constructed:  Table1.Column1.Eq(Table2.Column1).And(Table1.Column2.Eq(Table2.Column2)).And(Table1.Column2.Eq(Table2.Column2)),
-		ExpectedStmt: `(("table1".column1 = "table2".column1 AND "table1".column2 = "table2".column2) AND "table1".column2 = "table2".column2)`,
+		ExpectedStmt: `((table1.column1 = table2.column1 AND table1.column2 = table2.column2) AND table1.column2 = table2.column2)`,
 	},
 	{
 		Constructed:  Table1.Column1.Eq(Table2.Column1).And(Table1.Column2.Eq(Table2.Column2)).Or(Table1.Column2.Eq(Table2.Column2)),
-		ExpectedStmt: `(("table1".column1 = "table2".column1 AND "table1".column2 = "table2".column2) OR "table1".column2 = "table2".column2)`,
+		ExpectedStmt: `((table1.column1 = table2.column1 AND table1.column2 = table2.column2) OR table1.column2 = table2.column2)`,
 	},
 	{
 		Constructed:  Table1.BoolColumn.IsEq(true),
-		ExpectedStmt: `"table1".bool_column = $1`,
+		ExpectedStmt: `table1.bool_column = $1`,
 	},
 	{
 		Constructed:  Table1.BoolColumn.IsNotEq(true),
-		ExpectedStmt: `"table1".bool_column != $1`,
+		ExpectedStmt: `table1.bool_column != $1`,
 	},
 	{
 		Constructed:  Table1.DecimalColumn.IsGt(1.0),
-		ExpectedStmt: `"table1".decimal_column > $1`,
+		ExpectedStmt: `table1.decimal_column > $1`,
 	},
 	{
 		Constructed:  Table1.DecimalColumn.IsGte(1.0),
-		ExpectedStmt: `"table1".decimal_column >= $1`,
+		ExpectedStmt: `table1.decimal_column >= $1`,
 	},
 	{
 		Constructed:  Table1.DecimalColumn.IsLt(1.0),
-		ExpectedStmt: `"table1".decimal_column < $1`,
+		ExpectedStmt: `table1.decimal_column < $1`,
 	},
 	{
 		Constructed:  Table1.DecimalColumn.IsLte(1.0),
-		ExpectedStmt: `"table1".decimal_column <= $1`,
+		ExpectedStmt: `table1.decimal_column <= $1`,
 	},
 	{
 		Constructed:  Table1.DecimalColumn.IsEq(1.0),
-		ExpectedStmt: `"table1".decimal_column = $1`,
+		ExpectedStmt: `table1.decimal_column = $1`,
 	},
 	{
 		Constructed:  Table1.DecimalColumn.IsNotEq(1.0),
-		ExpectedStmt: `"table1".decimal_column != $1`,
+		ExpectedStmt: `table1.decimal_column != $1`,
 	},
 	{
 		Constructed:  Table1.DecimalColumn.Add(Int64(42)),
-		ExpectedStmt: `"table1".decimal_column + $1`,
+		ExpectedStmt: `table1.decimal_column + $1`,
 	},
 	{
 		Constructed:  Table1.DecimalColumn.Sub(Int64(-42)),
-		ExpectedStmt: `"table1".decimal_column - $1`,
+		ExpectedStmt: `table1.decimal_column - $1`,
 	},
 	{
 		Constructed:  Table1.DecimalColumn.Mult(Int64(42)),
-		ExpectedStmt: `"table1".decimal_column * $1`,
+		ExpectedStmt: `table1.decimal_column * $1`,
 	},
 	{
 		Constructed:  Table1.DecimalColumn.Div(Int64(0)),
-		ExpectedStmt: `"table1".decimal_column / $1`,
+		ExpectedStmt: `table1.decimal_column / $1`,
 	},
 	{
 		Constructed:  Table1.DecimalColumn.Sqrt(),
-		ExpectedStmt: `|/ "table1".decimal_column`,
+		ExpectedStmt: `|/ table1.decimal_column`,
 	},
 	{
 		Constructed:  Table1.StringColumn.Lt(Table2.StringColumn),
-		ExpectedStmt: `"table1".string_column < "table2".string_column`,
+		ExpectedStmt: `table1.string_column < table2.string_column`,
 	},
 	{
 		Constructed:  Table1.StringColumn.Lte(Table2.StringColumn),
-		ExpectedStmt: `"table1".string_column <= "table2".string_column`,
+		ExpectedStmt: `table1.string_column <= table2.string_column`,
 	},
 	{
 		Constructed:  Table1.StringColumn.Gt(Table2.StringColumn),
-		ExpectedStmt: `"table1".string_column > "table2".string_column`,
+		ExpectedStmt: `table1.string_column > table2.string_column`,
 	},
 	{
 		Constructed:  Table1.StringColumn.Gte(Table2.StringColumn),
-		ExpectedStmt: `"table1".string_column >= "table2".string_column`,
+		ExpectedStmt: `table1.string_column >= table2.string_column`,
 	},
 	{
 		Constructed:  Table1.StringColumn.Eq(Table2.StringColumn),
-		ExpectedStmt: `"table1".string_column = "table2".string_column`,
+		ExpectedStmt: `table1.string_column = table2.string_column`,
 	},
 	{
 		Constructed:  Table1.StringColumn.NotEq(Table2.StringColumn),
-		ExpectedStmt: `"table1".string_column != "table2".string_column`,
+		ExpectedStmt: `table1.string_column != table2.string_column`,
 	},
 	{
 		Constructed:  Table1.StringColumn.IsLt("foo"),
-		ExpectedStmt: `"table1".string_column < $1`,
+		ExpectedStmt: `table1.string_column < $1`,
 	},
 	{
 		Constructed:  Table1.StringColumn.IsLte("foo"),
-		ExpectedStmt: `"table1".string_column <= $1`,
+		ExpectedStmt: `table1.string_column <= $1`,
 	},
 	{
 		Constructed:  Table1.StringColumn.IsGt("foo"),
-		ExpectedStmt: `"table1".string_column > $1`,
+		ExpectedStmt: `table1.string_column > $1`,
 	},
 	{
 		Constructed:  Table1.StringColumn.IsGte("foo"),
-		ExpectedStmt: `"table1".string_column >= $1`,
+		ExpectedStmt: `table1.string_column >= $1`,
 	},
 	{
 		Constructed:  Table1.StringColumn.IsEq("foo"),
-		ExpectedStmt: `"table1".string_column = $1`,
+		ExpectedStmt: `table1.string_column = $1`,
 	},
 	{
 		Constructed:  Table1.StringColumn.IsNotEq("foo"),
-		ExpectedStmt: `"table1".string_column != $1`,
+		ExpectedStmt: `table1.string_column != $1`,
 	},
 	{
 		Constructed:  Table1.StringColumn.Like("foo%"),
-		ExpectedStmt: `"table1".string_column LIKE $1`,
+		ExpectedStmt: `table1.string_column LIKE $1`,
 	},
 	{
 		Constructed:  Table1.StringColumn.ILike("%foo%"),
-		ExpectedStmt: `"table1".string_column ILIKE $1`,
+		ExpectedStmt: `table1.string_column ILIKE $1`,
 	},
 	{
 		Constructed:  Table1.TimeColumn.IsGt(time.Now()),
-		ExpectedStmt: `"table1".time_column > $1`,
+		ExpectedStmt: `table1.time_column > $1`,
 	},
 	{
 		Constructed:  Table1.TimeColumn.IsGte(time.Now()),
-		ExpectedStmt: `"table1".time_column >= $1`,
+		ExpectedStmt: `table1.time_column >= $1`,
 	},
 	{
 		Constructed:  Table1.TimeColumn.IsLt(time.Now()),
-		ExpectedStmt: `"table1".time_column < $1`,
+		ExpectedStmt: `table1.time_column < $1`,
 	},
 	{
 		Constructed:  Table1.TimeColumn.IsLte(time.Now()),
-		ExpectedStmt: `"table1".time_column <= $1`,
+		ExpectedStmt: `table1.time_column <= $1`,
 	},
 	{
 		Constructed:  Table1.TimeColumn.IsEq(time.Now()),
-		ExpectedStmt: `"table1".time_column = $1`,
+		ExpectedStmt: `table1.time_column = $1`,
 	},
 	{
 		Constructed:  Table1.TimeColumn.IsNotEq(time.Now()),
-		ExpectedStmt: `"table1".time_column != $1`,
+		ExpectedStmt: `table1.time_column != $1`,
 	},
 	{
 		Constructed:  Table1.ID.IsEq(uuid.Nil),
-		ExpectedStmt: `"table1".id = $1`,
+		ExpectedStmt: `table1.id = $1`,
 	},
 	{
 		Constructed:  Table1.ID.IsNotEq(uuid.Nil),
-		ExpectedStmt: `"table1".id != $1`,
+		ExpectedStmt: `table1.id != $1`,
 	},
 	{
 		Constructed:  Table1.ID.In(Select().From(Table1)),
-		ExpectedStmt: `"table1".id IN (SELECT * FROM public.table1)`,
+		ExpectedStmt: `table1.id IN (SELECT * FROM public.table1)`,
 	},
 	{
 		Constructed:  Table1.ID.NotIn(Select().From(Table1)),
-		ExpectedStmt: `"table1".id NOT IN (SELECT * FROM public.table1)`,
+		ExpectedStmt: `table1.id NOT IN (SELECT * FROM public.table1)`,
 	},
 	{
 		Constructed:  Table1.ID.IsIn(uuid.Nil, uuid.Nil),
-		ExpectedStmt: `"table1".id IN ($1, $2)`,
+		ExpectedStmt: `table1.id IN ($1, $2)`,
 		Arguments:    []interface{}{uuid.Nil, uuid.Nil},
 	},
 	{
 		Constructed:  Table1.ID.IsNotIn(uuid.Nil, uuid.Nil),
-		ExpectedStmt: `"table1".id NOT IN ($1, $2)`,
+		ExpectedStmt: `table1.id NOT IN ($1, $2)`,
 		Arguments:    []interface{}{uuid.Nil, uuid.Nil},
 	},
 	{
 		Constructed:  Coalesce(Sum(Table1.Column1).Filter(Table1.Column2.IsDistinctFrom("str1")), Float64(0)).As("new_name"),
-		ExpectedStmt: `COALESCE(SUM("table1".column1) FILTER (WHERE "table1".column2 IS DISTINCT FROM $1), $2) AS "new_name"`,
+		ExpectedStmt: `COALESCE(SUM(table1.column1) FILTER (WHERE table1.column2 IS DISTINCT FROM $1), $2) AS new_name`,
+	},
+	{
+		Constructed:  Table1.Tags.Contains(StringArray("foo", "bar")),
+		ExpectedStmt: `table1.tags @> $1`,
+		Arguments:    []interface{}{pq.StringArray{"foo", "bar"}},
+	},
+	{
+		Constructed:  Table1.Scores.ContainedBy(Table2.Scores),
+		ExpectedStmt: `table1.scores <@ table2.scores`,
+	},
+	{
+		Constructed:  Table1.Owners.Overlaps(UUIDArray(uuid.Nil)),
+		ExpectedStmt: `table1.owners && $1`,
+		Arguments:    []interface{}{pq.GenericArray{A: []uuid.UUID{uuid.Nil}}},
+	},
+	{
+		Constructed:  Table1.Scores.Eq(Int64Array(1, 2)),
+		ExpectedStmt: `table1.scores = $1`,
+		Arguments:    []interface{}{pq.Int64Array{1, 2}},
+	},
+	{
+		Constructed:  Table1.Column3.Eq(IntArray(1, 2).Any()),
+		ExpectedStmt: `table1.column3 = ANY($1)`,
+		Arguments:    []interface{}{pq.Int64Array{1, 2}},
+	},
+	{
+		Constructed:  String("foo").Eq(Table1.Tags.Any()),
+		ExpectedStmt: `$1 = ANY(table1.tags)`,
+	},
+	{
+		Constructed:  Table1.Column3.Gt(Table1.Scores.All()),
+		ExpectedStmt: `table1.column3 > ALL(table1.scores)`,
+	},
+	{
+		Constructed:  Table2.ID.Eq(Table1.Owners.Any()),
+		ExpectedStmt: `table2.id = ANY(table1.owners)`,
+	},
+	{
+		Constructed:  Table1.Tags.Index(1).IsEq("foo"),
+		ExpectedStmt: `table1.tags[1] = $1`,
+	},
+	{
+		Constructed:  Table1.Scores.Slice(2, 3).Index(1),
+		ExpectedStmt: `(table1.scores[2:3])[1]`,
+	},
+	{
+		Constructed:  Table1.Tags.Append(Table1.Column1).Contains(StringArray("foo")),
+		ExpectedStmt: `ARRAY_APPEND(table1.tags, table1.column1) @> $1`,
+	},
+	{
+		Constructed:  ArrayLength(Table1.Tags, 1).IsGt(0),
+		ExpectedStmt: `ARRAY_LENGTH(table1.tags, $1) > $2`,
+	},
+	{
+		Constructed:  Cardinality(Table1.Owners),
+		ExpectedStmt: `CARDINALITY(table1.owners)`,
+	},
+	{
+		Constructed:  Unnest(Table1.Tags).As("tag"),
+		ExpectedStmt: `UNNEST(table1.tags) AS tag`,
+	},
+	{
+		Constructed:  ArrayAppend(Table1.Owners, Table1.ID),
+		ExpectedStmt: `ARRAY_APPEND(table1.owners, table1.id)`,
+	},
+	{
+		Constructed:  ArrayAggString(Table1.Column1).Overlaps(Table2.Tags),
+		ExpectedStmt: `ARRAY_AGG(table1.column1) && table2.tags`,
+	},
+	{
+		Constructed:  ArrayAggUUID(Table1.ID).Index(1),
+		ExpectedStmt: `(ARRAY_AGG(table1.id))[1]`,
+	},
+	{
+		Constructed:  Table1.Data.Get("address").GetText("city").IsEq("Paris"),
+		ExpectedStmt: `table1.data -> $1 ->> $2 = $3`,
+		Arguments:    []interface{}{"address", "city", "Paris"},
+	},
+	{
+		Constructed:  Table1.Data.GetIndex(-1).GetIndexText(0),
+		ExpectedStmt: `table1.data -> -1 ->> 0`,
+	},
+	{
+		Constructed:  Table1.Data.GetPath("a", "b").Eq(Table2.Data.GetPath("c")),
+		ExpectedStmt: `table1.data #> $1 = table2.data #> $2`,
+		Arguments:    []interface{}{pq.StringArray{"a", "b"}, pq.StringArray{"c"}},
+	},
+	{
+		Constructed:  Table1.Data.GetPathText("a", "b"),
+		ExpectedStmt: `table1.data #>> $1`,
+	},
+	{
+		Constructed:  Table1.Data.Contains(Jsonb(map[string]interface{}{"tags": []string{"a"}})),
+		ExpectedStmt: `table1.data @> $1::jsonb`,
+		Arguments:    []interface{}{`{"tags":["a"]}`},
+	},
+	{
+		Constructed:  Table1.Data.ContainedBy(Jsonb(json.RawMessage(`{"a": 1}`))),
+		ExpectedStmt: `table1.data <@ $1::jsonb`,
+		Arguments:    []interface{}{`{"a": 1}`},
+	},
+	{
+		Constructed:  Table1.Data.Concat(Jsonb([]int{1, 2})),
+		ExpectedStmt: `table1.data || $1::jsonb`,
+		Arguments:    []interface{}{`[1,2]`},
+	},
+	{
+		Constructed:  Jsonb(func() {}),
+		ExpectedStmt: `$1::jsonb`,
+		Errors:       []error{&json.UnsupportedTypeError{Type: reflect.TypeOf(func() {})}},
+	},
+	{
+		Constructed: And(Table1.Data.HasKey("a"), Table1.Data.HasAnyKey("b", "c"),
+			Table1.Data.HasAllKeys("d")),
+		ExpectedStmt: `(table1.data ? $1 AND table1.data ?| $2 AND table1.data ?& $3)`,
+		Arguments:    []interface{}{"a", pq.StringArray{"b", "c"}, pq.StringArray{"d"}},
+	},
+	{
+		Constructed:  Table1.Data.DeleteKey("a").DeleteIndex(0).DeletePath("b", "c"),
+		ExpectedStmt: `table1.data - $1 - 0 #- $2`,
+	},
+	{
+		Constructed:  Table1.Data.PathExists("$.a[*] ? (@ > 2)").Or(Table1.Data.PathMatch("$.b == 1")),
+		ExpectedStmt: `(table1.data @? $1 OR table1.data @@ $2)`,
+	},
+	{
+		Constructed:  JsonbSet(Table1.Data, []string{"a", "b"}, Jsonb(1), Bool(false)),
+		ExpectedStmt: `JSONB_SET(table1.data, $1, $2::jsonb, $3)`,
+		Arguments:    []interface{}{pq.StringArray{"a", "b"}, "1", false},
+	},
+	{
+		Constructed: JsonbBuildObject(
+			JsonbKey("id", Table1.ID),
+			JsonbKey("name", String("foo")),
+			JsonbKey("count", Int64(1)),
+			JsonbKey("data", Jsonb(nil))),
+		ExpectedStmt: `JSONB_BUILD_OBJECT($1::text, table1.id, $2::text, $3::text, $4::text, $5::bigint, $6::text, $7::jsonb)`,
+		Arguments:    []interface{}{"id", "name", "foo", "count", int64(1), "data", "null"},
+	},
+	{
+		Constructed:  JsonbAgg(JsonbBuildObject(JsonbKey("id", Table1.ID))),
+		ExpectedStmt: `JSONB_AGG(JSONB_BUILD_OBJECT($1::text, table1.id))`,
+	},
+	{
+		Constructed:  JsonbArrayElements(Table1.Data.Get("items")).GetText("name"),
+		ExpectedStmt: `JSONB_ARRAY_ELEMENTS(table1.data -> $1) ->> $2`,
 	},
 }
 
@@ -25,9 +25,18 @@ func (field *fieldImpl) GetName() string {
 }
 
 func (field *fieldImpl) GetQualifiedName() string {
+	selectableName := field.getSelectableName()
+	if selectableName == "" {
+		return field.name
+	} else {
+		return fmt.Sprintf("%s.%s", selectableName, field.name)
+	}
+}
+
+func (field *fieldImpl) getSelectableName() string {
 	var selectableName string
 	switch selectable := field.selectable.(type) {
-	case Table:
+	case View:
 		selectableName = selectable.GetUnqualifiedName()
 	case *selection:
 		if selectable.GetAlias().Valid {
@@ -35,17 +44,16 @@ func (field *fieldImpl) GetQualifiedName() string {
 		}
 		// TODO(Peter): can selectable be a anonymous select statement?
 	}
-	if selectableName == "" {
-		return field.name
-	} else {
-		return fmt.Sprintf("\"%s\".%s", selectableName, field.name)
-	}
+	return selectableName
 }
 
 func (field *fieldImpl) Render(
 	builder *Builder,
 ) {
-	builder.Print(field.GetQualifiedName())
+	if selectableName := field.getSelectableName(); selectableName != "" {
+		builder.Printf("%s.", builder.QuoteIdentifier(selectableName))
+	}
+	builder.Print(builder.QuoteIdentifier(field.name))
 }
 
 // BoolField
@@ -61,7 +69,7 @@ type defaultBoolField struct {
 }
 
 func NewBoolField(
-	table Table, name string,
+	table View, name string,
 ) BoolField {
 	field := &defaultBoolField{}
 	field.expressionImpl.initFieldExpressionImpl(field)
@@ -82,7 +90,7 @@ type defaultDecimalField struct {
 }
 
 func NewDecimalField(
-	table Table, name string,
+	table View, name string,
 ) DecimalField {
 	field := &defaultDecimalField{}
 	field.expressionImpl.initFieldExpressionImpl(field)
@@ -103,7 +111,7 @@ type defaultIntField struct {
 }
 
 func NewIntField(
-	table Table, name string,
+	table View, name string,
 ) IntField {
 	field := &defaultIntField{}
 	field.expressionImpl.initFieldExpressionImpl(field)
@@ -111,20 +119,41 @@ func NewIntField(
 	return field
 }
 
+// IntervalField
+
+type IntervalField interface {
+	IntervalExpression
+	Field
+}
+
+type defaultIntervalField struct {
+	intervalExpressionImpl
+	fieldImpl
+}
+
+func NewIntervalField(
+	table View, name string,
+) IntervalField {
+	field := &defaultIntervalField{}
+	field.expressionImpl.initFieldExpressionImpl(field)
+	field.fieldImpl.initFieldImpl(field, table, name)
+	return field
+}
+
 // JsonbField
 
 type JsonbField interface {
-	StringExpression
+	JsonbExpression
 	Field
 }
 
 type defaultJsonbField struct {
-	stringExpressionImpl
+	jsonbExpressionImpl
 	fieldImpl
 }
 
 func NewJsonbField(
-	table Table, name string,
+	table View, name string,
 ) JsonbField {
 	field := &defaultJsonbField{}
 	field.expressionImpl.initFieldExpressionImpl(field)
@@ -156,19 +185,61 @@ func NewStringField(
 // StringArrayField
 
 type StringArrayField interface {
-	StringExpression
+	StringArrayExpression
 	Field
 }
 
 type defaultStringArrayField struct {
-	stringExpressionImpl
+	stringArrayExpressionImpl
 	fieldImpl
 }
 
 func NewStringArrayField(
-	table Table, name string,
+	table View, name string,
 ) StringArrayField {
-	field := &defaultStringField{}
+	field := &defaultStringArrayField{}
+	field.expressionImpl.initFieldExpressionImpl(field)
+	field.fieldImpl.initFieldImpl(field, table, name)
+	return field
+}
+
+// IntArrayField
+
+type IntArrayField interface {
+	NumericArrayExpression
+	Field
+}
+
+type defaultIntArrayField struct {
+	numericArrayExpressionImpl
+	fieldImpl
+}
+
+func NewIntArrayField(
+	table View, name string,
+) IntArrayField {
+	field := &defaultIntArrayField{}
+	field.expressionImpl.initFieldExpressionImpl(field)
+	field.fieldImpl.initFieldImpl(field, table, name)
+	return field
+}
+
+// UUIDArrayField
+
+type UUIDArrayField interface {
+	UUIDArrayExpression
+	Field
+}
+
+type defaultUUIDArrayField struct {
+	uuidArrayExpressionImpl
+	fieldImpl
+}
+
+func NewUUIDArrayField(
+	table View, name string,
+) UUIDArrayField {
+	field := &defaultUUIDArrayField{}
 	field.expressionImpl.initFieldExpressionImpl(field)
 	field.fieldImpl.initFieldImpl(field, table, name)
 	return field
@@ -187,7 +258,7 @@ type defaultUUIDField struct {
 }
 
 func NewUUIDField(
-	table Table, name string,
+	table View, name string,
 ) UUIDField {
 	field := &defaultUUIDField{}
 	field.expressionImpl.initFieldExpressionImpl(field)
@@ -208,7 +279,7 @@ type defaultTimeField struct {
 }
 
 func NewTimeField(
-	table Table, name string,
+	table View, name string,
 ) TimeField {
 	field := &defaultTimeField{}
 	field.expressionImpl.initFieldExpressionImpl(field)
@@ -1,5 +1,12 @@
 package gooq
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
 func Count(
 	expr ...Expression,
 ) NumericExpression {
@@ -7,11 +14,36 @@ func Count(
 	if len(expr) == 1 {
 		expression = expr[0]
 	} else if len(expr) > 1 {
-		panic("only support 1 arg")
+		return newInvalidNumericFunction("COUNT", &ArgumentCountError{
+			Function: "COUNT", Max: 1, Actual: len(expr),
+		}, expr...)
 	}
 	return NewNumericExpressionFunction("COUNT", expression)
 }
 
+// invalidNumericFunction is returned by constructors that are misused. It
+// renders as a regular function and reports err to the builder.
+type invalidNumericFunction struct {
+	numericExpressionFunctionImpl
+	err error
+}
+
+func newInvalidNumericFunction(
+	name string, err error, arguments ...Expression,
+) NumericExpression {
+	function := &invalidNumericFunction{err: err}
+	function.name = name
+	function.expressionImpl.initFunctionExpression(function, arguments...)
+	return function
+}
+
+func (expr *invalidNumericFunction) Render(
+	builder *Builder,
+) {
+	builder.errors = append(builder.errors, expr.err)
+	expr.numericExpressionFunctionImpl.Render(builder)
+}
+
 func Distinct(expr Expression) Expression {
 	return NewExpressionFunction("DISTINCT", expr)
 }
@@ -44,7 +76,7 @@ func (expr *aliasFunction) Render(
 	builder *Builder,
 ) {
 	builder.RenderExpression(expr.expression)
-	builder.Printf(" AS \"%s\"", expr.alias)
+	builder.Printf(" AS %s", builder.QuoteIdentifier(expr.alias))
 }
 
 type filterWhereFunction struct {
@@ -64,6 +96,9 @@ func (expr *filterWhereFunction) Render(
 	builder *Builder,
 ) {
 	builder.RenderExpression(expr.expression)
+	if !builder.requireFeature(DialectFeatureAggregateFilter) {
+		return
+	}
 	builder.Print(" FILTER (WHERE ")
 	builder.RenderConditions(expr.expressions)
 	builder.Printf(")")
@@ -179,6 +214,188 @@ func (expr *boolExpressionFunctionImpl) Render(
 	builder.Printf(")")
 }
 
+type uuidExpressionFunctionImpl struct {
+	uuidExpressionImpl
+	name string
+}
+
+func NewUUIDExpressionFunction(
+	name string, arguments ...Expression,
+) UUIDExpression {
+	function := &uuidExpressionFunctionImpl{name: name}
+	function.expressionImpl.initFunctionExpression(function, arguments...)
+	return function
+}
+
+func (expr *uuidExpressionFunctionImpl) Render(
+	builder *Builder,
+) {
+	builder.Printf("%s(", expr.name)
+	for index, argument := range expr.expressions {
+		argument.Render(builder)
+		if index != len(expr.expressions)-1 {
+			builder.Print(", ")
+		}
+	}
+	builder.Printf(")")
+}
+
+type stringArrayExpressionFunctionImpl struct {
+	stringArrayExpressionImpl
+	name string
+}
+
+func NewStringArrayExpressionFunction(
+	name string, arguments ...Expression,
+) StringArrayExpression {
+	function := &stringArrayExpressionFunctionImpl{name: name}
+	function.expressionImpl.initFunctionExpression(function, arguments...)
+	return function
+}
+
+func (expr *stringArrayExpressionFunctionImpl) Render(
+	builder *Builder,
+) {
+	builder.Printf("%s(", expr.name)
+	for index, argument := range expr.expressions {
+		argument.Render(builder)
+		if index != len(expr.expressions)-1 {
+			builder.Print(", ")
+		}
+	}
+	builder.Printf(")")
+}
+
+type numericArrayExpressionFunctionImpl struct {
+	numericArrayExpressionImpl
+	name string
+}
+
+func NewNumericArrayExpressionFunction(
+	name string, arguments ...Expression,
+) NumericArrayExpression {
+	function := &numericArrayExpressionFunctionImpl{name: name}
+	function.expressionImpl.initFunctionExpression(function, arguments...)
+	return function
+}
+
+func (expr *numericArrayExpressionFunctionImpl) Render(
+	builder *Builder,
+) {
+	builder.Printf("%s(", expr.name)
+	for index, argument := range expr.expressions {
+		argument.Render(builder)
+		if index != len(expr.expressions)-1 {
+			builder.Print(", ")
+		}
+	}
+	builder.Printf(")")
+}
+
+type uuidArrayExpressionFunctionImpl struct {
+	uuidArrayExpressionImpl
+	name string
+}
+
+func NewUUIDArrayExpressionFunction(
+	name string, arguments ...Expression,
+) UUIDArrayExpression {
+	function := &uuidArrayExpressionFunctionImpl{name: name}
+	function.expressionImpl.initFunctionExpression(function, arguments...)
+	return function
+}
+
+func (expr *uuidArrayExpressionFunctionImpl) Render(
+	builder *Builder,
+) {
+	builder.Printf("%s(", expr.name)
+	for index, argument := range expr.expressions {
+		argument.Render(builder)
+		if index != len(expr.expressions)-1 {
+			builder.Print(", ")
+		}
+	}
+	builder.Printf(")")
+}
+
+type jsonbExpressionFunctionImpl struct {
+	jsonbExpressionImpl
+	name string
+}
+
+func NewJsonbExpressionFunction(
+	name string, arguments ...Expression,
+) JsonbExpression {
+	function := &jsonbExpressionFunctionImpl{name: name}
+	function.expressionImpl.initFunctionExpression(function, arguments...)
+	return function
+}
+
+func (expr *jsonbExpressionFunctionImpl) Render(
+	builder *Builder,
+) {
+	builder.Printf("%s(", expr.name)
+	for index, argument := range expr.expressions {
+		argument.Render(builder)
+		if index != len(expr.expressions)-1 {
+			builder.Print(", ")
+		}
+	}
+	builder.Printf(")")
+}
+
+type dateTimeExpressionFunctionImpl struct {
+	dateTimeExpressionImpl
+	name string
+}
+
+func NewDateTimeExpressionFunction(
+	name string, arguments ...Expression,
+) DateTimeExpression {
+	function := &dateTimeExpressionFunctionImpl{name: name}
+	function.expressionImpl.initFunctionExpression(function, arguments...)
+	return function
+}
+
+func (expr *dateTimeExpressionFunctionImpl) Render(
+	builder *Builder,
+) {
+	builder.Printf("%s(", expr.name)
+	for index, argument := range expr.expressions {
+		argument.Render(builder)
+		if index != len(expr.expressions)-1 {
+			builder.Print(", ")
+		}
+	}
+	builder.Printf(")")
+}
+
+type intervalExpressionFunctionImpl struct {
+	intervalExpressionImpl
+	name string
+}
+
+func NewIntervalExpressionFunction(
+	name string, arguments ...Expression,
+) IntervalExpression {
+	function := &intervalExpressionFunctionImpl{name: name}
+	function.expressionImpl.initFunctionExpression(function, arguments...)
+	return function
+}
+
+func (expr *intervalExpressionFunctionImpl) Render(
+	builder *Builder,
+) {
+	builder.Printf("%s(", expr.name)
+	for index, argument := range expr.expressions {
+		argument.Render(builder)
+		if index != len(expr.expressions)-1 {
+			builder.Print(", ")
+		}
+	}
+	builder.Printf(")")
+}
+
 // Multigrade AND, OR expressions
 // And(expr1, expr2, expr3) produces (expr1 AND expr2 AND expr3)
 // where as expr1.And(expr2).And(expr3) produces ((expr1 AND expr2) AND expr3)
@@ -206,6 +423,14 @@ func Or(
 		HasParentheses(true))
 }
 
+// Not(expr) produces (NOT expr)
+func Not(
+	boolExpression BoolExpression,
+) BoolExpression {
+	return newUnaryPrefixBooleanExpressionImpl(OperatorNot, boolExpression,
+		HasParentheses(true))
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // Table 9.3. Comparison Functions
 // https://www.postgresql.org/docs/11/functions-comparison.html
@@ -462,20 +687,256 @@ func Translate(
 ///////////////////////////////////////////////////////////////////////////////
 // Table 9.23. Formatting Functions
 // https://www.postgresql.org/docs/11/functions-formatting.html
-// [Good First Issue][Help Wanted] TODO: implement remaining functions
 ////////////////////////////////////////////////////////////////////////////////
 
+// ToChar formats a timestamp, interval or number, e.g.
+// ToChar(Table1.TimeColumn, "YYYY-MM-DD")
+func ToChar(
+	value Expression, format string,
+) StringExpression {
+	return NewStringExpressionFunction("TO_CHAR", value, String(format))
+}
+
+func ToDate(
+	text StringExpression, format string,
+) DateTimeExpression {
+	return NewDateTimeExpressionFunction("TO_DATE", text, String(format))
+}
+
+func ToNumber(
+	text StringExpression, format string,
+) NumericExpression {
+	return NewNumericExpressionFunction("TO_NUMBER", text, String(format))
+}
+
+func ToTimestamp(
+	text StringExpression, format string,
+) DateTimeExpression {
+	return NewDateTimeExpressionFunction("TO_TIMESTAMP", text, String(format))
+}
+
+// ToTimestampFromEpoch converts Unix epoch seconds to a timestamp with time zone
+func ToTimestampFromEpoch(
+	epoch NumericExpression,
+) DateTimeExpression {
+	return NewDateTimeExpressionFunction("TO_TIMESTAMP", epoch)
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // Table 9.30. Date/Time Functions
 // https://www.postgresql.org/docs/11/functions-datetime.html
-// [Good First Issue][Help Wanted] TODO: implement remaining functions
+// CURRENT_DATE, CURRENT_TIME, ... are declared in literal.go
 ////////////////////////////////////////////////////////////////////////////////
 
+// DateField is a field of EXTRACT(field FROM source) and DatePart
+// https://www.postgresql.org/docs/11/functions-datetime.html#FUNCTIONS-DATETIME-EXTRACT
+type DateField string
+
+const (
+	DateFieldCentury        = DateField("CENTURY")
+	DateFieldDay            = DateField("DAY")
+	DateFieldDecade         = DateField("DECADE")
+	DateFieldDow            = DateField("DOW")
+	DateFieldDoy            = DateField("DOY")
+	DateFieldEpoch          = DateField("EPOCH")
+	DateFieldHour           = DateField("HOUR")
+	DateFieldIsoDow         = DateField("ISODOW")
+	DateFieldIsoYear        = DateField("ISOYEAR")
+	DateFieldMicroseconds   = DateField("MICROSECONDS")
+	DateFieldMillennium     = DateField("MILLENNIUM")
+	DateFieldMilliseconds   = DateField("MILLISECONDS")
+	DateFieldMinute         = DateField("MINUTE")
+	DateFieldMonth          = DateField("MONTH")
+	DateFieldQuarter        = DateField("QUARTER")
+	DateFieldSecond         = DateField("SECOND")
+	DateFieldTimezone       = DateField("TIMEZONE")
+	DateFieldTimezoneHour   = DateField("TIMEZONE_HOUR")
+	DateFieldTimezoneMinute = DateField("TIMEZONE_MINUTE")
+	DateFieldWeek           = DateField("WEEK")
+	DateFieldYear           = DateField("YEAR")
+)
+
+// Age(end) renders AGE(end) which is measured from midnight of the current
+// date. Age(end, start) renders AGE(end, start).
+func Age(
+	end DateTimeExpression, start ...DateTimeExpression,
+) IntervalExpression {
+	expressions := []Expression{end}
+	for _, expression := range start {
+		expressions = append(expressions, expression)
+	}
+	return NewIntervalExpressionFunction("AGE", expressions...)
+}
+
+func ClockTimestamp() DateTimeExpression {
+	return NewDateTimeExpressionFunction("CLOCK_TIMESTAMP")
+}
+
+// DatePart accepts a timestamp or an interval source
+func DatePart(
+	field DateField, source Expression,
+) NumericExpression {
+	return NewNumericExpressionFunction("DATE_PART", String(string(field)), source)
+}
+
 func DateTrunc(
 	text string, timestamp DateTimeExpression,
-) Expression {
+) DateTimeExpression {
 	expressions := []Expression{String(text), timestamp}
-	return NewExpressionFunction("DATE_TRUNC", expressions...)
+	return NewDateTimeExpressionFunction("DATE_TRUNC", expressions...)
+}
+
+type extractFunction struct {
+	numericExpressionImpl
+	field DateField
+}
+
+// Extract accepts a timestamp or an interval source. The field is rendered
+// inline as the syntax does not accept a parameter.
+func Extract(
+	field DateField, source Expression,
+) NumericExpression {
+	function := &extractFunction{field: field}
+	function.expressionImpl.initFunctionExpression(function, source)
+	return function
+}
+
+func (expr *extractFunction) Render(
+	builder *Builder,
+) {
+	builder.Printf("EXTRACT(%s FROM ", expr.field)
+	builder.RenderExpression(expr.expressions[0])
+	builder.Print(")")
+}
+
+// IsFinite accepts a date, timestamp or interval
+func IsFinite(
+	value Expression,
+) BoolExpression {
+	return NewBoolExpressionFunction("ISFINITE", value)
+}
+
+func JustifyDays(
+	interval IntervalExpression,
+) IntervalExpression {
+	return NewIntervalExpressionFunction("JUSTIFY_DAYS", interval)
+}
+
+func JustifyHours(
+	interval IntervalExpression,
+) IntervalExpression {
+	return NewIntervalExpressionFunction("JUSTIFY_HOURS", interval)
+}
+
+func JustifyInterval(
+	interval IntervalExpression,
+) IntervalExpression {
+	return NewIntervalExpressionFunction("JUSTIFY_INTERVAL", interval)
+}
+
+func MakeDate(
+	year, month, day NumericExpression,
+) DateTimeExpression {
+	return NewDateTimeExpressionFunction("MAKE_DATE", year, month, day)
+}
+
+// IntervalFields are the arguments of MakeInterval, nil fields are omitted
+type IntervalFields struct {
+	Years  NumericExpression
+	Months NumericExpression
+	Weeks  NumericExpression
+	Days   NumericExpression
+	Hours  NumericExpression
+	Mins   NumericExpression
+	Secs   NumericExpression
+}
+
+type makeIntervalFunction struct {
+	intervalExpressionImpl
+	names []string
+}
+
+// MakeInterval renders MAKE_INTERVAL with named arguments, e.g.
+// MakeInterval(IntervalFields{Days: Table1.Column3}) renders
+// MAKE_INTERVAL(days => table1.column3)
+func MakeInterval(
+	fields IntervalFields,
+) IntervalExpression {
+	function := &makeIntervalFunction{}
+	var arguments []Expression
+	for _, field := range []struct {
+		name  string
+		value NumericExpression
+	}{
+		{"years", fields.Years},
+		{"months", fields.Months},
+		{"weeks", fields.Weeks},
+		{"days", fields.Days},
+		{"hours", fields.Hours},
+		{"mins", fields.Mins},
+		{"secs", fields.Secs},
+	} {
+		if field.value != nil {
+			function.names = append(function.names, field.name)
+			arguments = append(arguments, field.value)
+		}
+	}
+	function.expressionImpl.initFunctionExpression(function, arguments...)
+	return function
+}
+
+func (expr *makeIntervalFunction) Render(
+	builder *Builder,
+) {
+	builder.Print("MAKE_INTERVAL(")
+	for index, argument := range expr.expressions {
+		builder.Printf("%s => ", expr.names[index])
+		argument.Render(builder)
+		if index != len(expr.expressions)-1 {
+			builder.Print(", ")
+		}
+	}
+	builder.Print(")")
+}
+
+func MakeTime(
+	hour, min, sec NumericExpression,
+) DateTimeExpression {
+	return NewDateTimeExpressionFunction("MAKE_TIME", hour, min, sec)
+}
+
+func MakeTimestamp(
+	year, month, day, hour, min, sec NumericExpression,
+) DateTimeExpression {
+	return NewDateTimeExpressionFunction("MAKE_TIMESTAMP",
+		year, month, day, hour, min, sec)
+}
+
+// MakeTimestampTz uses the current time zone unless a time zone is given
+func MakeTimestampTz(
+	year, month, day, hour, min, sec NumericExpression, timezone ...StringExpression,
+) DateTimeExpression {
+	expressions := []Expression{year, month, day, hour, min, sec}
+	for _, expression := range timezone {
+		expressions = append(expressions, expression)
+	}
+	return NewDateTimeExpressionFunction("MAKE_TIMESTAMPTZ", expressions...)
+}
+
+func Now() DateTimeExpression {
+	return NewDateTimeExpressionFunction("NOW")
+}
+
+func StatementTimestamp() DateTimeExpression {
+	return NewDateTimeExpressionFunction("STATEMENT_TIMESTAMP")
+}
+
+func TimeOfDay() StringExpression {
+	return NewStringExpressionFunction("TIMEOFDAY")
+}
+
+func TransactionTimestamp() DateTimeExpression {
+	return NewDateTimeExpressionFunction("TRANSACTION_TIMESTAMP")
 }
 
 func Greatest(
@@ -492,7 +953,17 @@ func Least(
 	return NewExpressionFunction("LEAST", expressions...)
 }
 
-// TODO(Peter): implement Case When
+// Case starts a searched CASE WHEN condition THEN result ... END expression
+func Case() CaseStep {
+	return &caseStep{}
+}
+
+// CaseOf starts a simple CASE operand WHEN value THEN result ... END expression
+func CaseOf(
+	operand Expression,
+) CaseStep {
+	return &caseStep{operand: operand.getOriginal()}
+}
 
 func Coalesce(
 	expr Expression, rests ...Expression,
@@ -514,6 +985,167 @@ func NullIf(
 // [Help Wanted] TODO: implement remaining functions
 ///////////////////////////////////////////////////////////////////////////////
 
+// ArrayLength returns the length of the requested array dimension
+func ArrayLength(
+	array ArrayExpression, dimension int,
+) NumericExpression {
+	return NewNumericExpressionFunction("ARRAY_LENGTH", array, Int64(int64(dimension)))
+}
+
+func Cardinality(
+	array ArrayExpression,
+) NumericExpression {
+	return NewNumericExpressionFunction("CARDINALITY", array)
+}
+
+// ArrayAppend is the untyped form of StringArrayExpression.Append(...) etc.
+func ArrayAppend(
+	array ArrayExpression, value Expression,
+) Expression {
+	return NewExpressionFunction("ARRAY_APPEND", array, value)
+}
+
+// Unnest is the untyped form of StringArrayExpression.Unnest() etc. It
+// expands the array to a set of rows.
+func Unnest(
+	array ArrayExpression,
+) Expression {
+	return NewExpressionFunction("UNNEST", array)
+}
+
+func ArrayAgg(
+	expr Expression,
+) Expression {
+	return NewExpressionFunction("ARRAY_AGG", expr)
+}
+
+func ArrayAggString(
+	expr StringExpression,
+) StringArrayExpression {
+	return NewStringArrayExpressionFunction("ARRAY_AGG", expr)
+}
+
+func ArrayAggNumeric(
+	expr NumericExpression,
+) NumericArrayExpression {
+	return NewNumericArrayExpressionFunction("ARRAY_AGG", expr)
+}
+
+func ArrayAggUUID(
+	expr UUIDExpression,
+) UUIDArrayExpression {
+	return NewUUIDArrayExpressionFunction("ARRAY_AGG", expr)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Table 9.47. JSON Creation Functions
+// Table 9.49. JSON Processing Functions
+// https://www.postgresql.org/docs/12/functions-json.html
+// [Help Wanted] TODO: implement remaining functions
+///////////////////////////////////////////////////////////////////////////////
+
+type JsonbObjectEntry struct {
+	Key   string
+	Value Expression
+}
+
+func JsonbKey(key string, value Expression) JsonbObjectEntry {
+	return JsonbObjectEntry{Key: key, Value: value}
+}
+
+type jsonbBuildObjectFunction struct {
+	jsonbExpressionImpl
+	entries []JsonbObjectEntry
+}
+
+// JsonbBuildObject renders jsonb_build_object(key, value, ...). Its arguments
+// are of type "any" so literal keys and values are rendered with a cast,
+// otherwise PostgreSQL cannot determine the type of their parameters.
+func JsonbBuildObject(
+	entries ...JsonbObjectEntry,
+) JsonbExpression {
+	function := &jsonbBuildObjectFunction{entries: entries}
+	function.expressionImpl.initFunctionExpression(function)
+	return function
+}
+
+func (expr *jsonbBuildObjectFunction) Render(
+	builder *Builder,
+) {
+	builder.requireFeature(DialectFeatureJsonb)
+	builder.Print("JSONB_BUILD_OBJECT(")
+	for index, entry := range expr.entries {
+		builder.RenderLiteral(entry.Key)
+		builder.Print("::text, ")
+		value := entry.Value.getOriginal()
+		builder.RenderExpression(value)
+		if cast := literalCast(value); cast != "" {
+			builder.Printf("::%s", cast)
+		}
+		if index != len(expr.entries)-1 {
+			builder.Print(", ")
+		}
+	}
+	builder.Print(")")
+}
+
+// literalCast returns the type a literal parameter is cast to when
+// PostgreSQL cannot infer it from the context of the parameter
+func literalCast(expression Expression) string {
+	literal, ok := expression.(interface{ getLiteral() (interface{}, bool) })
+	if !ok {
+		return ""
+	}
+	value, ok := literal.getLiteral()
+	if !ok {
+		return ""
+	}
+	switch value.(type) {
+	case string:
+		return "text"
+	case bool:
+		return "boolean"
+	case int, int32, int64:
+		return "bigint"
+	case float32, float64:
+		return "double precision"
+	case time.Time:
+		return "timestamptz"
+	case uuid.UUID:
+		return "uuid"
+	case pq.StringArray:
+		return "text[]"
+	case pq.Int64Array:
+		return "bigint[]"
+	}
+	return ""
+}
+
+// JsonbSet renders jsonb_set(target, path, value [, create_missing])
+func JsonbSet(
+	target JsonbExpression, path []string, value JsonbExpression,
+	createMissing ...BoolExpression,
+) JsonbExpression {
+	arguments := []Expression{target, Literal(pq.StringArray(path)), value}
+	for _, argument := range createMissing {
+		arguments = append(arguments, argument)
+	}
+	return NewJsonbExpressionFunction("JSONB_SET", arguments...)
+}
+
+// JsonbArrayElements expands a jsonb array to a set of jsonb values
+func JsonbArrayElements(
+	array JsonbExpression,
+) JsonbExpression {
+	return NewJsonbExpressionFunction("JSONB_ARRAY_ELEMENTS", array)
+}
+
+func JsonbAgg(
+	expr Expression,
+) JsonbExpression {
+	return NewJsonbExpressionFunction("JSONB_AGG", expr)
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // Range Functions and Operators
 // https://www.postgresql.org/docs/11/functions-range.html
@@ -535,9 +1167,205 @@ func NullIf(
 ///////////////////////////////////////////////////////////////////////////////
 // Window Functions
 // https://www.postgresql.org/docs/11/functions-window.html
-// [Help Wanted] TODO: implement remaining functions
+// These must be followed by .Over(...) or .OverWindow(...)
 ///////////////////////////////////////////////////////////////////////////////
 
+func RowNumber() NumericWindowFunction {
+	return newNumericWindowFunction("ROW_NUMBER")
+}
+
+func Rank() NumericWindowFunction {
+	return newNumericWindowFunction("RANK")
+}
+
+func DenseRank() NumericWindowFunction {
+	return newNumericWindowFunction("DENSE_RANK")
+}
+
+func PercentRank() NumericWindowFunction {
+	return newNumericWindowFunction("PERCENT_RANK")
+}
+
+func CumeDist() NumericWindowFunction {
+	return newNumericWindowFunction("CUME_DIST")
+}
+
+func Ntile(
+	buckets NumericExpression,
+) NumericWindowFunction {
+	return newNumericWindowFunction("NTILE", buckets)
+}
+
+// Lag(value [, offset [, default]])
+func Lag(
+	value Expression, offsetAndDefault ...Expression,
+) Expression {
+	arguments := append([]Expression{value}, offsetAndDefault...)
+	return NewExpressionFunction("LAG", arguments...)
+}
+
+// Lead(value [, offset [, default]])
+func Lead(
+	value Expression, offsetAndDefault ...Expression,
+) Expression {
+	arguments := append([]Expression{value}, offsetAndDefault...)
+	return NewExpressionFunction("LEAD", arguments...)
+}
+
+func FirstValue(
+	value Expression,
+) Expression {
+	return NewExpressionFunction("FIRST_VALUE", value)
+}
+
+func LastValue(
+	value Expression,
+) Expression {
+	return NewExpressionFunction("LAST_VALUE", value)
+}
+
+func NthValue(
+	value Expression, n NumericExpression,
+) Expression {
+	return NewExpressionFunction("NTH_VALUE", value, n)
+}
+
+// LagBool is Lag with the boolean type of the value
+func LagBool(
+	value BoolExpression, offsetAndDefault ...Expression,
+) BoolWindowFunction {
+	arguments := append([]Expression{value}, offsetAndDefault...)
+	return newBoolWindowFunction("LAG", arguments...)
+}
+
+// LeadBool is Lead with the boolean type of the value
+func LeadBool(
+	value BoolExpression, offsetAndDefault ...Expression,
+) BoolWindowFunction {
+	arguments := append([]Expression{value}, offsetAndDefault...)
+	return newBoolWindowFunction("LEAD", arguments...)
+}
+
+func FirstValueBool(
+	value BoolExpression,
+) BoolWindowFunction {
+	return newBoolWindowFunction("FIRST_VALUE", value)
+}
+
+func LastValueBool(
+	value BoolExpression,
+) BoolWindowFunction {
+	return newBoolWindowFunction("LAST_VALUE", value)
+}
+
+func NthValueBool(
+	value BoolExpression, n NumericExpression,
+) BoolWindowFunction {
+	return newBoolWindowFunction("NTH_VALUE", value, n)
+}
+
+// LagDateTime is Lag with the date/time type of the value
+func LagDateTime(
+	value DateTimeExpression, offsetAndDefault ...Expression,
+) DateTimeWindowFunction {
+	arguments := append([]Expression{value}, offsetAndDefault...)
+	return newDateTimeWindowFunction("LAG", arguments...)
+}
+
+// LeadDateTime is Lead with the date/time type of the value
+func LeadDateTime(
+	value DateTimeExpression, offsetAndDefault ...Expression,
+) DateTimeWindowFunction {
+	arguments := append([]Expression{value}, offsetAndDefault...)
+	return newDateTimeWindowFunction("LEAD", arguments...)
+}
+
+func FirstValueDateTime(
+	value DateTimeExpression,
+) DateTimeWindowFunction {
+	return newDateTimeWindowFunction("FIRST_VALUE", value)
+}
+
+func LastValueDateTime(
+	value DateTimeExpression,
+) DateTimeWindowFunction {
+	return newDateTimeWindowFunction("LAST_VALUE", value)
+}
+
+func NthValueDateTime(
+	value DateTimeExpression, n NumericExpression,
+) DateTimeWindowFunction {
+	return newDateTimeWindowFunction("NTH_VALUE", value, n)
+}
+
+// LagNumeric is Lag with the numeric type of the value
+func LagNumeric(
+	value NumericExpression, offsetAndDefault ...Expression,
+) NumericWindowFunction {
+	arguments := append([]Expression{value}, offsetAndDefault...)
+	return newNumericWindowFunction("LAG", arguments...)
+}
+
+// LeadNumeric is Lead with the numeric type of the value
+func LeadNumeric(
+	value NumericExpression, offsetAndDefault ...Expression,
+) NumericWindowFunction {
+	arguments := append([]Expression{value}, offsetAndDefault...)
+	return newNumericWindowFunction("LEAD", arguments...)
+}
+
+func FirstValueNumeric(
+	value NumericExpression,
+) NumericWindowFunction {
+	return newNumericWindowFunction("FIRST_VALUE", value)
+}
+
+func LastValueNumeric(
+	value NumericExpression,
+) NumericWindowFunction {
+	return newNumericWindowFunction("LAST_VALUE", value)
+}
+
+func NthValueNumeric(
+	value NumericExpression, n NumericExpression,
+) NumericWindowFunction {
+	return newNumericWindowFunction("NTH_VALUE", value, n)
+}
+
+// LagString is Lag with the string type of the value
+func LagString(
+	value StringExpression, offsetAndDefault ...Expression,
+) StringWindowFunction {
+	arguments := append([]Expression{value}, offsetAndDefault...)
+	return newStringWindowFunction("LAG", arguments...)
+}
+
+// LeadString is Lead with the string type of the value
+func LeadString(
+	value StringExpression, offsetAndDefault ...Expression,
+) StringWindowFunction {
+	arguments := append([]Expression{value}, offsetAndDefault...)
+	return newStringWindowFunction("LEAD", arguments...)
+}
+
+func FirstValueString(
+	value StringExpression,
+) StringWindowFunction {
+	return newStringWindowFunction("FIRST_VALUE", value)
+}
+
+func LastValueString(
+	value StringExpression,
+) StringWindowFunction {
+	return newStringWindowFunction("LAST_VALUE", value)
+}
+
+func NthValueString(
+	value StringExpression, n NumericExpression,
+) StringWindowFunction {
+	return newStringWindowFunction("NTH_VALUE", value, n)
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // Set Returning Functions
 // https://www.postgresql.org/docs/11/functions-srf.html
@@ -1,23 +1,27 @@
 package gooq
 
-import "testing"
+import (
+	"fmt"
+	"testing"
+	"time"
+)
 
 var functionTestCases = []TestCase{
 	{
 		Constructed:  And(Table1.Column1.Eq(Table2.Column1), Table1.Column2.Eq(Table2.Column2), Table1.Column2.Eq(Table2.Column2)),
-		ExpectedStmt: `("table1".column1 = "table2".column1 AND "table1".column2 = "table2".column2 AND "table1".column2 = "table2".column2)`,
+		ExpectedStmt: `(table1.column1 = table2.column1 AND table1.column2 = table2.column2 AND table1.column2 = table2.column2)`,
 	},
 	{
 		Constructed:  Or(Table1.Column1.Eq(Table2.Column1), Table1.Column2.Eq(Table2.Column2), Table1.Column2.Eq(Table2.Column2)),
-		ExpectedStmt: `("table1".column1 = "table2".column1 OR "table1".column2 = "table2".column2 OR "table1".column2 = "table2".column2)`,
+		ExpectedStmt: `(table1.column1 = table2.column1 OR table1.column2 = table2.column2 OR table1.column2 = table2.column2)`,
 	},
 	{
 		Constructed:  Select(Coalesce(Table1.Column1, Table1.Column2)).From(Table1),
-		ExpectedStmt: `SELECT COALESCE("table1".column1, "table1".column2) FROM public.table1`,
+		ExpectedStmt: `SELECT COALESCE(table1.column1, table1.column2) FROM public.table1`,
 	},
 	{
 		Constructed:  Select(Coalesce(Table1.Column1, Int64(0))).From(Table1),
-		ExpectedStmt: `SELECT COALESCE("table1".column1, $1) FROM public.table1`,
+		ExpectedStmt: `SELECT COALESCE(table1.column1, $1) FROM public.table1`,
 	},
 	{
 		Constructed:  Select(Count()).From(Table1),
@@ -29,7 +33,7 @@ var functionTestCases = []TestCase{
 	},
 	{
 		Constructed:  Select(Distinct(Table1.Column1)).From(Table1),
-		ExpectedStmt: `SELECT DISTINCT("table1".column1) FROM public.table1`,
+		ExpectedStmt: `SELECT DISTINCT(table1.column1) FROM public.table1`,
 	},
 	{
 		Constructed:  Greatest(Int64(10), Int64(2), Int64(23)),
@@ -45,7 +49,7 @@ var functionTestCases = []TestCase{
 	},
 	{
 		Constructed:  Ascii(Table1.Column1),
-		ExpectedStmt: `ASCII("table1".column1)`,
+		ExpectedStmt: `ASCII(table1.column1)`,
 	},
 	{
 		Constructed:  BTrim(String("    abc    ")),
@@ -53,27 +57,27 @@ var functionTestCases = []TestCase{
 	},
 	{
 		Constructed:  LTrim(Table1.Column1, String("xyz")),
-		ExpectedStmt: `LTRIM("table1".column1, $1)`,
+		ExpectedStmt: `LTRIM(table1.column1, $1)`,
 	},
 	{
 		Constructed:  RTrim(String("xyzxyzabcxyz"), Table1.Column1),
-		ExpectedStmt: `RTRIM($1, "table1".column1)`,
+		ExpectedStmt: `RTRIM($1, table1.column1)`,
 	},
 	{
 		Constructed:  Chr(Table1.Column3),
-		ExpectedStmt: `CHR("table1".column3)`,
+		ExpectedStmt: `CHR(table1.column3)`,
 	},
 	{
 		Constructed:  Concat(String("xyzxyzabcxyz"), Table1.Column3, Int64(3)),
-		ExpectedStmt: `CONCAT($1, "table1".column3, $2)`,
+		ExpectedStmt: `CONCAT($1, table1.column3, $2)`,
 	},
 	{
 		Constructed:  ConcatWs(String("x"), Table1.Column3, Int64(3), String("four")),
-		ExpectedStmt: `CONCAT_WS($1, "table1".column3, $2, $3)`,
+		ExpectedStmt: `CONCAT_WS($1, table1.column3, $2, $3)`,
 	},
 	{
 		Constructed:  Format(String("Hello %s, %1$s"), Table1.Column3),
-		ExpectedStmt: `FORMAT($1, "table1".column3)`,
+		ExpectedStmt: `FORMAT($1, table1.column3)`,
 	},
 	{
 		Constructed:  Format(String("no formatting to be done")),
@@ -85,15 +89,15 @@ var functionTestCases = []TestCase{
 	},
 	{
 		Constructed:  Left(Table1.Column1, Int64(3)),
-		ExpectedStmt: `LEFT("table1".column1, $1)`,
+		ExpectedStmt: `LEFT(table1.column1, $1)`,
 	},
 	{
 		Constructed:  Right(String("take my right chars"), Table1.Column3),
-		ExpectedStmt: `RIGHT($1, "table1".column3)`,
+		ExpectedStmt: `RIGHT($1, table1.column3)`,
 	},
 	{
 		Constructed:  Length(Table1.Column1),
-		ExpectedStmt: `LENGTH("table1".column1)`,
+		ExpectedStmt: `LENGTH(table1.column1)`,
 	},
 	{
 		Constructed:  Length(String("jose"), String("UTF8")),
@@ -105,11 +109,11 @@ var functionTestCases = []TestCase{
 	},
 	{
 		Constructed:  RPad(Table1.Column2, Table1.Column3, Table1.Column1),
-		ExpectedStmt: `RPAD("table1".column2, "table1".column3, "table1".column1)`,
+		ExpectedStmt: `RPAD(table1.column2, table1.column3, table1.column1)`,
 	},
 	{
 		Constructed:  Md5(Table1.Column2),
-		ExpectedStmt: `MD5("table1".column2)`,
+		ExpectedStmt: `MD5(table1.column2)`,
 	},
 	{
 		Constructed:  PgClientEncoding(),
@@ -129,15 +133,15 @@ var functionTestCases = []TestCase{
 	},
 	{
 		Constructed:  QuoteNullable(Table3.ID),
-		ExpectedStmt: `QUOTE_NULLABLE("table3".id)`,
+		ExpectedStmt: `QUOTE_NULLABLE(table3.id)`,
 	},
 	{
 		Constructed:  Repeat(String("abc"), Table1.Column3),
-		ExpectedStmt: `REPEAT($1, "table1".column3)`,
+		ExpectedStmt: `REPEAT($1, table1.column3)`,
 	},
 	{
 		Constructed:  Replace(Table1.Column1, String("ab"), String("CD")),
-		ExpectedStmt: `REPLACE("table1".column1, $1, $2)`,
+		ExpectedStmt: `REPLACE(table1.column1, $1, $2)`,
 	},
 	{
 		Constructed:  Reverse(String("reversable")),
@@ -149,11 +153,11 @@ var functionTestCases = []TestCase{
 	},
 	{
 		Constructed:  Strpos(Table1.Column1, String("ab")),
-		ExpectedStmt: `STRPOS("table1".column1, $1)`,
+		ExpectedStmt: `STRPOS(table1.column1, $1)`,
 	},
 	{
 		Constructed:  Substr(Table1.Column1, Int64(2), Int64(5)),
-		ExpectedStmt: `SUBSTR("table1".column1, $1, $2)`,
+		ExpectedStmt: `SUBSTR(table1.column1, $1, $2)`,
 	},
 	{
 		Constructed:  StartsWith(String("alphabet"), String("alph")),
@@ -161,7 +165,7 @@ var functionTestCases = []TestCase{
 	},
 	{
 		Constructed:  ToAscii(Table1.Column1),
-		ExpectedStmt: `TO_ASCII("table1".column1)`,
+		ExpectedStmt: `TO_ASCII(table1.column1)`,
 	},
 	{
 		Constructed:  ToAscii(String("Karel"), String("WIN1250")),
@@ -169,12 +173,226 @@ var functionTestCases = []TestCase{
 	},
 	{
 		Constructed:  ToHex(Table1.Column3),
-		ExpectedStmt: `TO_HEX("table1".column3)`,
+		ExpectedStmt: `TO_HEX(table1.column3)`,
 	},
 	{
 		Constructed:  Translate(String("12345"), String("143"), String("ax")),
 		ExpectedStmt: `TRANSLATE($1, $2, $3)`,
 	},
+	{
+		Constructed:  Case().When(Table1.BoolColumn, Table1.Column1).Else(Table1.Column2),
+		ExpectedStmt: `CASE WHEN table1.bool_column THEN table1.column1 ELSE table1.column2 END`,
+	},
+	{
+		Constructed: Case().
+			WhenString(Table1.Column3.IsGt(10), String("large")).
+			When(Table1.Column3.IsGt(5), String("medium")).
+			Else(String("small")),
+		ExpectedStmt: `CASE WHEN table1.column3 > $1 THEN $2 WHEN table1.column3 > $3 THEN $4 ELSE $5 END`,
+		Arguments:    []interface{}{float64(10), "large", float64(5), "medium", "small"},
+	},
+	{
+		Constructed:  CaseOf(Table1.Column1).WhenNumeric(String("a"), Int64(1)).When(String("b"), Int64(2)),
+		ExpectedStmt: `CASE table1.column1 WHEN $1 THEN $2 WHEN $3 THEN $4 END`,
+	},
+	{
+		Constructed:  Sum(Case().WhenNumeric(Table1.BoolColumn, Table1.Column3).Else(Int64(0))).As("total"),
+		ExpectedStmt: `SUM(CASE WHEN table1.bool_column THEN table1.column3 ELSE $1 END) AS total`,
+	},
+	{
+		Constructed:  Case().WhenNumeric(Table1.BoolColumn, Table1.Column3).Else(Int64(0)).Add(Table1.Column4).IsGt(1),
+		ExpectedStmt: `CASE WHEN table1.bool_column THEN table1.column3 ELSE $1 END + table1.column4 > $2`,
+	},
+	{
+		Constructed:  Case().WhenBool(Table1.Column1.IsEq("x"), Table1.BoolColumn).Else(Bool(false)).IsEq(true),
+		ExpectedStmt: `CASE WHEN table1.column1 = $1 THEN table1.bool_column ELSE $2 END = $3`,
+	},
+	{
+		Constructed:  Case().WhenDateTime(Table1.BoolColumn, Table1.TimeColumn).Else(Table2.TimeColumn).Desc(),
+		ExpectedStmt: `CASE WHEN table1.bool_column THEN table1.time_column ELSE table2.time_column END DESC`,
+	},
+	{
+		Constructed:  Count().Filter(Case().WhenBool(Table1.Column3.IsGt(0), Bool(true)).Else(Bool(false))),
+		ExpectedStmt: `COUNT(*) FILTER (WHERE CASE WHEN table1.column3 > $1 THEN $2 ELSE $3 END)`,
+	},
+	{
+		Constructed:  RowNumber().Over(),
+		ExpectedStmt: `ROW_NUMBER() OVER ()`,
+	},
+	{
+		Constructed:  RowNumber().Over(Window().OrderBy(Table1.Column3)).Lte(Int64(3)),
+		ExpectedStmt: `ROW_NUMBER() OVER (ORDER BY table1.column3) <= $1`,
+		Arguments:    []interface{}{int64(3)},
+	},
+	{
+		Constructed:  Sum(Table1.Column3).Over(Window().PartitionBy(Table1.Column1), Window().OrderBy(Table1.Column3)),
+		ExpectedStmt: `SUM(table1.column3) OVER (PARTITION BY table1.column1)`,
+		Errors:       []error{&ArgumentCountError{Function: "OVER", Max: 1, Actual: 2}},
+	},
+	{
+		Constructed:  Rank().Over(Window(), Window()),
+		ExpectedStmt: `RANK() OVER ()`,
+		Errors:       []error{&ArgumentCountError{Function: "OVER", Max: 1, Actual: 2}},
+	},
+	{
+		Constructed:  Rank().Over(Window().OrderBy(Table1.Column3.Desc())),
+		ExpectedStmt: `RANK() OVER (ORDER BY table1.column3 DESC)`,
+	},
+	{
+		Constructed:  DenseRank().Over(Window().PartitionBy(Table1.Column1, Table1.Column2).OrderBy(Table1.Column3)),
+		ExpectedStmt: `DENSE_RANK() OVER (PARTITION BY table1.column1, table1.column2 ORDER BY table1.column3)`,
+	},
+	{
+		Constructed:  Ntile(Int64(4)).Over(Window().OrderBy(Table1.Column3)),
+		ExpectedStmt: `NTILE($1) OVER (ORDER BY table1.column3)`,
+	},
+	{
+		Constructed:  Lag(Table1.Column3).Over(Window().OrderBy(Table1.TimeColumn)),
+		ExpectedStmt: `LAG(table1.column3) OVER (ORDER BY table1.time_column)`,
+	},
+	{
+		Constructed:  Lead(Table1.Column3, Int64(2), Int64(0)).Over(Window().OrderBy(Table1.TimeColumn)),
+		ExpectedStmt: `LEAD(table1.column3, $1, $2) OVER (ORDER BY table1.time_column)`,
+		Arguments:    []interface{}{int64(2), int64(0)},
+	},
+	{
+		Constructed:  FirstValue(Table1.Column1).Over(Window().PartitionBy(Table1.Column2).OrderBy(Table1.Column3)),
+		ExpectedStmt: `FIRST_VALUE(table1.column1) OVER (PARTITION BY table1.column2 ORDER BY table1.column3)`,
+	},
+	{
+		Constructed: LastValue(Table1.Column1).Over(Window().OrderBy(Table1.Column3).
+			RowsBetween(UnboundedPreceding, UnboundedFollowing)),
+		ExpectedStmt: `LAST_VALUE(table1.column1) OVER (ORDER BY table1.column3 ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING)`,
+	},
+	{
+		Constructed:  NthValue(Table1.Column1, Int64(2)).OverWindow("w"),
+		ExpectedStmt: `NTH_VALUE(table1.column1, $1) OVER w`,
+	},
+	{
+		Constructed:  LagNumeric(Table1.Column3).Over(Window().OrderBy(Table1.TimeColumn)).Lt(Table1.Column3),
+		ExpectedStmt: `LAG(table1.column3) OVER (ORDER BY table1.time_column) < table1.column3`,
+	},
+	{
+		Constructed:  LeadDateTime(Table1.TimeColumn).Over(Window().OrderBy(Table1.TimeColumn)).Sub(Interval(time.Hour)).IsGt(time.Time{}),
+		ExpectedStmt: `LEAD(table1.time_column) OVER (ORDER BY table1.time_column) - $1::interval > $2`,
+		Arguments:    []interface{}{"PT3600S", time.Time{}},
+	},
+	{
+		Constructed:  FirstValueString(Table1.Column1).Over(Window().PartitionBy(Table1.Column2)).IsEq("str1"),
+		ExpectedStmt: `FIRST_VALUE(table1.column1) OVER (PARTITION BY table1.column2) = $1`,
+		Arguments:    []interface{}{"str1"},
+	},
+	{
+		Constructed:  LastValueBool(Table1.BoolColumn).OverWindow("w").IsEq(true),
+		ExpectedStmt: `LAST_VALUE(table1.bool_column) OVER w = $1`,
+		Arguments:    []interface{}{true},
+	},
+	{
+		Constructed:  NthValueNumeric(Table1.Column3, Int64(2)).OverWindow("w").IsGt(1),
+		ExpectedStmt: `NTH_VALUE(table1.column3, $1) OVER w > $2`,
+		Arguments:    []interface{}{int64(2), float64(1)},
+	},
+	{
+		Constructed: Sum(Table1.Column3).Over(Window().OrderBy(Table1.TimeColumn).
+			RowsBetween(Preceding(Int64(3)), CurrentRow)),
+		ExpectedStmt: `SUM(table1.column3) OVER (ORDER BY table1.time_column ROWS BETWEEN $1 PRECEDING AND CURRENT ROW)`,
+	},
+	{
+		Constructed:  Sum(Table1.Column3).Over(Window().OrderBy(Table1.Column3).Range(UnboundedPreceding)),
+		ExpectedStmt: `SUM(table1.column3) OVER (ORDER BY table1.column3 RANGE UNBOUNDED PRECEDING)`,
+	},
+	{
+		Constructed: Count(Table1.Column1).Over(Window().OrderBy(Table1.Column3).
+			GroupsBetween(CurrentRow, Following(Int64(1))).Exclude(FrameExclusionTies)),
+		ExpectedStmt: `COUNT(table1.column1) OVER (ORDER BY table1.column3 GROUPS BETWEEN CURRENT ROW AND $1 FOLLOWING EXCLUDE TIES)`,
+	},
+	{
+		Constructed:  Count(Table1.Column1).Filter(Table1.BoolColumn.IsEq(true)).Over(Window().PartitionBy(Table1.Column2)),
+		ExpectedStmt: `COUNT(table1.column1) FILTER (WHERE table1.bool_column = $1) OVER (PARTITION BY table1.column2)`,
+	},
+	{
+		Constructed:  Sum(Table1.Column3).Over(WindowFrom("w").OrderBy(Table1.Column3)).As("running_total"),
+		ExpectedStmt: `SUM(table1.column3) OVER (w ORDER BY table1.column3) AS running_total`,
+	},
+	{
+		Constructed:  Now().Sub(Interval(24 * time.Hour)),
+		ExpectedStmt: `NOW() - $1::interval`,
+		Arguments:    []interface{}{"PT86400S"},
+	},
+	{
+		Constructed:  Table1.TimeColumn.Add(Interval(-1500 * time.Millisecond)).IsLt(time.Time{}),
+		ExpectedStmt: `table1.time_column + $1::interval < $2`,
+		Arguments:    []interface{}{"PT-1.500000S", time.Time{}},
+	},
+	{
+		Constructed:  Now().SubDateTime(Table1.TimeColumn).IsGt(time.Hour),
+		ExpectedStmt: `NOW() - table1.time_column > $1::interval`,
+		Arguments:    []interface{}{"PT3600S"},
+	},
+	{
+		Constructed:  Table1.Duration.Mult(Int64(2)).Add(IntervalFromISO("P1DT2H")),
+		ExpectedStmt: `table1.duration * $1 + $2::interval`,
+		Arguments:    []interface{}{int64(2), "P1DT2H"},
+	},
+	{
+		Constructed:  IntervalFromISO("1 day"),
+		ExpectedStmt: `$1::interval`,
+		Errors:       []error{fmt.Errorf("invalid ISO 8601 interval %q", "1 day")},
+	},
+	{
+		Constructed:  CurrentDate.Add(Table1.Duration).Gt(CurrentTimestamp),
+		ExpectedStmt: `CURRENT_DATE + table1.duration > CURRENT_TIMESTAMP`,
+	},
+	{
+		Constructed:  Table1.TimeColumn.AtTimeZone("UTC"),
+		ExpectedStmt: `table1.time_column AT TIME ZONE $1`,
+	},
+	{
+		Constructed:  Extract(DateFieldEpoch, Age(Table1.TimeColumn, Table2.TimeColumn)),
+		ExpectedStmt: `EXTRACT(EPOCH FROM AGE(table1.time_column, table2.time_column))`,
+	},
+	{
+		Constructed:  DatePart(DateFieldHour, Table1.TimeColumn),
+		ExpectedStmt: `DATE_PART($1, table1.time_column)`,
+		Arguments:    []interface{}{"HOUR"},
+	},
+	{
+		Constructed:  DateTrunc("day", Now()).Sub(MakeInterval(IntervalFields{Days: Table1.Column3, Secs: Float64(1.5)})),
+		ExpectedStmt: `DATE_TRUNC($1, NOW()) - MAKE_INTERVAL(days => table1.column3, secs => $2)`,
+	},
+	{
+		Constructed:  JustifyInterval(JustifyHours(JustifyDays(Table1.Duration))),
+		ExpectedStmt: `JUSTIFY_INTERVAL(JUSTIFY_HOURS(JUSTIFY_DAYS(table1.duration)))`,
+	},
+	{
+		Constructed:  IsFinite(Table1.TimeColumn),
+		ExpectedStmt: `ISFINITE(table1.time_column)`,
+	},
+	{
+		Constructed:  MakeTimestampTz(Int64(2020), Int64(1), Int64(2), Int64(3), Int64(4), Float64(5), String("UTC")),
+		ExpectedStmt: `MAKE_TIMESTAMPTZ($1, $2, $3, $4, $5, $6, $7)`,
+	},
+	{
+		Constructed:  MakeDate(Int64(2020), Int64(1), Int64(2)).Lt(MakeTimestamp(Int64(2020), Int64(1), Int64(2), Int64(3), Int64(4), Float64(5))),
+		ExpectedStmt: `MAKE_DATE($1, $2, $3) < MAKE_TIMESTAMP($4, $5, $6, $7, $8, $9)`,
+	},
+	{
+		Constructed:  ToChar(Table1.TimeColumn, "YYYY-MM-DD"),
+		ExpectedStmt: `TO_CHAR(table1.time_column, $1)`,
+		Arguments:    []interface{}{"YYYY-MM-DD"},
+	},
+	{
+		Constructed:  ToTimestamp(Table1.Column1, "DD Mon YYYY").Gt(ToDate(Table1.Column2, "YYYYMMDD")),
+		ExpectedStmt: `TO_TIMESTAMP(table1.column1, $1) > TO_DATE(table1.column2, $2)`,
+	},
+	{
+		Constructed:  ToTimestampFromEpoch(ToNumber(Table1.Column1, "99999")),
+		ExpectedStmt: `TO_TIMESTAMP(TO_NUMBER(table1.column1, $1))`,
+	},
+	{
+		Constructed:  Select(ClockTimestamp(), StatementTimestamp(), TransactionTimestamp(), TimeOfDay(), CurrentTime, LocalTime),
+		ExpectedStmt: `SELECT CLOCK_TIMESTAMP(), STATEMENT_TIMESTAMP(), TRANSACTION_TIMESTAMP(), TIMEOFDAY(), CURRENT_TIME, LOCALTIME`,
+	},
 	{
 		Constructed:  TryAdvisoryLock(Int64(43)),
 		ExpectedStmt: `pg_try_advisory_lock($1)`,
@@ -188,3 +406,71 @@ var functionTestCases = []TestCase{
 func TestFunctions(t *testing.T) {
 	runTestCases(t, functionTestCases)
 }
+
+func TestDerivedCases(t *testing.T) {
+	base := Case().When(Table1.BoolColumn, Table1.Column1)
+	numeric := Case().WhenNumeric(Table1.BoolColumn, Table1.Column3)
+	// the whens of long have spare capacity after the third one
+	long := base.When(Table1.Column3.IsGt(1), Table1.Column2).When(Table1.Column3.IsGt(2), Table1.Column4)
+	runTestCases(t, []TestCase{
+		{
+			Constructed:  long.When(Table1.Column3.IsGt(3), Table1.Column1),
+			ExpectedStmt: `CASE WHEN table1.bool_column THEN table1.column1 WHEN table1.column3 > $1 THEN table1.column2 WHEN table1.column3 > $2 THEN table1.column4 WHEN table1.column3 > $3 THEN table1.column1 END`,
+		},
+		{
+			Constructed:  long.When(Table1.Column3.IsGt(4), Table1.Column2),
+			ExpectedStmt: `CASE WHEN table1.bool_column THEN table1.column1 WHEN table1.column3 > $1 THEN table1.column2 WHEN table1.column3 > $2 THEN table1.column4 WHEN table1.column3 > $3 THEN table1.column2 END`,
+		},
+		{
+			Constructed:  base.When(Table1.Column3.IsGt(1), Table1.Column2),
+			ExpectedStmt: `CASE WHEN table1.bool_column THEN table1.column1 WHEN table1.column3 > $1 THEN table1.column2 END`,
+		},
+		{
+			Constructed:  base.When(Table1.Column3.IsGt(2), Table1.Column4),
+			ExpectedStmt: `CASE WHEN table1.bool_column THEN table1.column1 WHEN table1.column3 > $1 THEN table1.column4 END`,
+		},
+		{
+			Constructed:  base.Else(Table1.Column2),
+			ExpectedStmt: `CASE WHEN table1.bool_column THEN table1.column1 ELSE table1.column2 END`,
+		},
+		{
+			Constructed:  base,
+			ExpectedStmt: `CASE WHEN table1.bool_column THEN table1.column1 END`,
+		},
+		{
+			Constructed:  numeric.When(Table1.Column3.IsGt(1), Int64(1)).Else(Int64(0)),
+			ExpectedStmt: `CASE WHEN table1.bool_column THEN table1.column3 WHEN table1.column3 > $1 THEN $2 ELSE $3 END`,
+		},
+		{
+			Constructed:  numeric.When(Table1.Column3.IsGt(2), Int64(2)),
+			ExpectedStmt: `CASE WHEN table1.bool_column THEN table1.column3 WHEN table1.column3 > $1 THEN $2 END`,
+		},
+	})
+}
+
+func TestDerivedWindows(t *testing.T) {
+	base := Window().PartitionBy(Table1.Column1)
+	ordered := base.OrderBy(Table1.Column3)
+	runTestCases(t, []TestCase{
+		{
+			Constructed:  Sum(Table1.Column3).Over(ordered.Rows(UnboundedPreceding)),
+			ExpectedStmt: `SUM(table1.column3) OVER (PARTITION BY table1.column1 ORDER BY table1.column3 ROWS UNBOUNDED PRECEDING)`,
+		},
+		{
+			Constructed:  Sum(Table1.Column3).Over(ordered.RangeBetween(CurrentRow, UnboundedFollowing).Exclude(FrameExclusionTies)),
+			ExpectedStmt: `SUM(table1.column3) OVER (PARTITION BY table1.column1 ORDER BY table1.column3 RANGE BETWEEN CURRENT ROW AND UNBOUNDED FOLLOWING EXCLUDE TIES)`,
+		},
+		{
+			Constructed:  Sum(Table1.Column3).Over(ordered),
+			ExpectedStmt: `SUM(table1.column3) OVER (PARTITION BY table1.column1 ORDER BY table1.column3)`,
+		},
+		{
+			Constructed:  Sum(Table1.Column3).Over(base.OrderBy(Table1.TimeColumn)),
+			ExpectedStmt: `SUM(table1.column3) OVER (PARTITION BY table1.column1 ORDER BY table1.time_column)`,
+		},
+		{
+			Constructed:  Sum(Table1.Column3).Over(base),
+			ExpectedStmt: `SUM(table1.column3) OVER (PARTITION BY table1.column1)`,
+		},
+	})
+}
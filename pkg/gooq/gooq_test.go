@@ -18,6 +18,11 @@ type testTable struct {
 	DecimalColumn DecimalField
 	StringColumn  StringField
 	TimeColumn    TimeField
+	Tags          StringArrayField
+	Scores        IntArrayField
+	Owners        UUIDArrayField
+	Data          JsonbField
+	Duration      IntervalField
 }
 
 func newTestTable(name string) *testTable {
@@ -32,6 +37,11 @@ func newTestTable(name string) *testTable {
 	instance.DecimalColumn = NewDecimalField(instance, "decimal_column")
 	instance.StringColumn = NewStringField(instance, "string_column")
 	instance.TimeColumn = NewTimeField(instance, "time_column")
+	instance.Tags = NewStringArrayField(instance, "tags")
+	instance.Scores = NewIntArrayField(instance, "scores")
+	instance.Owners = NewUUIDArrayField(instance, "owners")
+	instance.Data = NewJsonbField(instance, "data")
+	instance.Duration = NewIntervalField(instance, "duration")
 	return instance
 }
 
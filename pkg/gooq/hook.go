@@ -0,0 +1,345 @@
+package gooq
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// QueryEvent describes a query sent to the database. RowsAffected is -1 when
+// it is unknown, e.g. for queries returning rows. Prepare is set when the
+// query is only prepared, see NewHookedDB.
+type QueryEvent struct {
+	Query        string
+	Args         []interface{}
+	StartTime    time.Time
+	Duration     time.Duration
+	RowsAffected int64
+	Prepare      bool
+	Err          error
+}
+
+// QueryHook intercepts the queries sent to the database. BeforeQuery may
+// return a derived context which is used to run the query and is passed to
+// AfterQuery, e.g. to carry a tracing span. Hooks are attached to a database
+// handle with NewHookedDB or to a context with WithQueryHooks, in which case
+// they apply to the *WithContext methods of the statements.
+type QueryHook interface {
+	BeforeQuery(ctx context.Context, event *QueryEvent) context.Context
+	AfterQuery(ctx context.Context, event *QueryEvent)
+}
+
+type queryHooksKey struct{}
+
+// WithQueryHooks returns a context that runs hooks, after the hooks already
+// attached to ctx, around the statements executed with it
+func WithQueryHooks(ctx context.Context, hooks ...QueryHook) context.Context {
+	existing := queryHooksFromContext(ctx)
+	combined := make([]QueryHook, 0, len(existing)+len(hooks))
+	combined = append(combined, existing...)
+	combined = append(combined, hooks...)
+	return context.WithValue(ctx, queryHooksKey{}, combined)
+}
+
+func queryHooksFromContext(ctx context.Context) []QueryHook {
+	hooks, _ := ctx.Value(queryHooksKey{}).([]QueryHook)
+	return hooks
+}
+
+// withContextHooks wraps db with the hooks attached to ctx, if any
+func withContextHooks(ctx context.Context, db DBInterface) DBInterface {
+	hooks := queryHooksFromContext(ctx)
+	if len(hooks) == 0 {
+		return db
+	}
+	return &hookedDB{DBInterface: db, hooks: hooks}
+}
+
+// NewHookedDB wraps db so that every query it runs goes through hooks, in
+// order. Wrapping a TxInterface returns a TxInterface. Transactions started
+// on the returned handle with RunInTx run their queries through hooks as well.
+// Prepare and PrepareContext run hooks around preparing the query, but the
+// returned *sql.Stmt executes it without them. The statements prepared with
+// Prepare or PrepareWithDialect run hooks around every execution instead.
+func NewHookedDB(db DBInterface, hooks ...QueryHook) DBInterface {
+	hooked := hookedDB{DBInterface: db, hooks: hooks}
+	if tx, ok := db.(TxInterface); ok {
+		return &hookedTx{hookedDB: hooked, tx: tx}
+	}
+	return &hooked
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Implementation
+///////////////////////////////////////////////////////////////////////////////
+
+type hookedDB struct {
+	DBInterface
+	hooks []QueryHook
+}
+
+func (db *hookedDB) run(
+	ctx context.Context, query string, args []interface{},
+	fn func(ctx context.Context, event *QueryEvent),
+) {
+	runQueryHooks(ctx, db.hooks, query, args, fn)
+}
+
+// runQueryHooks runs fn, which executes the query and records its outcome in
+// the event, between the BeforeQuery and AfterQuery calls of hooks
+func runQueryHooks(
+	ctx context.Context, hooks []QueryHook, query string, args []interface{},
+	fn func(ctx context.Context, event *QueryEvent),
+) {
+	event := &QueryEvent{
+		Query:        query,
+		Args:         args,
+		StartTime:    time.Now(),
+		RowsAffected: -1,
+	}
+	for _, hook := range hooks {
+		ctx = hook.BeforeQuery(ctx, event)
+	}
+	fn(ctx, event)
+	event.Duration = time.Since(event.StartTime)
+	for index := len(hooks) - 1; index >= 0; index-- {
+		hooks[index].AfterQuery(ctx, event)
+	}
+}
+
+func (db *hookedDB) Prepare(query string) (stmt *sql.Stmt, err error) {
+	return db.PrepareContext(context.Background(), query)
+}
+
+func (db *hookedDB) PrepareContext(
+	ctx context.Context, query string,
+) (stmt *sql.Stmt, err error) {
+	db.run(ctx, query, nil, func(ctx context.Context, event *QueryEvent) {
+		event.Prepare = true
+		stmt, err = db.DBInterface.PrepareContext(ctx, query)
+		event.Err = err
+	})
+	return
+}
+
+func (db *hookedDB) Exec(query string, args ...interface{}) (result sql.Result, err error) {
+	db.run(context.Background(), query, args, func(ctx context.Context, event *QueryEvent) {
+		result, err = db.DBInterface.Exec(query, args...)
+		event.setResult(result, err)
+	})
+	return
+}
+
+func (db *hookedDB) ExecContext(
+	ctx context.Context, query string, args ...interface{},
+) (result sql.Result, err error) {
+	db.run(ctx, query, args, func(ctx context.Context, event *QueryEvent) {
+		result, err = db.DBInterface.ExecContext(ctx, query, args...)
+		event.setResult(result, err)
+	})
+	return
+}
+
+func (db *hookedDB) Query(query string, args ...interface{}) (rows *sql.Rows, err error) {
+	db.run(context.Background(), query, args, func(ctx context.Context, event *QueryEvent) {
+		rows, err = db.DBInterface.Query(query, args...)
+		event.Err = err
+	})
+	return
+}
+
+func (db *hookedDB) QueryContext(
+	ctx context.Context, query string, args ...interface{},
+) (rows *sql.Rows, err error) {
+	db.run(ctx, query, args, func(ctx context.Context, event *QueryEvent) {
+		rows, err = db.DBInterface.QueryContext(ctx, query, args...)
+		event.Err = err
+	})
+	return
+}
+
+func (db *hookedDB) Queryx(query string, args ...interface{}) (rows *sqlx.Rows, err error) {
+	db.run(context.Background(), query, args, func(ctx context.Context, event *QueryEvent) {
+		rows, err = db.DBInterface.Queryx(query, args...)
+		event.Err = err
+	})
+	return
+}
+
+func (db *hookedDB) QueryxContext(
+	ctx context.Context, query string, args ...interface{},
+) (rows *sqlx.Rows, err error) {
+	db.run(ctx, query, args, func(ctx context.Context, event *QueryEvent) {
+		rows, err = db.DBInterface.QueryxContext(ctx, query, args...)
+		event.Err = err
+	})
+	return
+}
+
+func (db *hookedDB) QueryRowx(query string, args ...interface{}) (row *sqlx.Row) {
+	db.run(context.Background(), query, args, func(ctx context.Context, event *QueryEvent) {
+		row = db.DBInterface.QueryRowx(query, args...)
+		event.Err = row.Err()
+	})
+	return
+}
+
+func (db *hookedDB) QueryRowxContext(
+	ctx context.Context, query string, args ...interface{},
+) (row *sqlx.Row) {
+	db.run(ctx, query, args, func(ctx context.Context, event *QueryEvent) {
+		row = db.DBInterface.QueryRowxContext(ctx, query, args...)
+		event.Err = row.Err()
+	})
+	return
+}
+
+type hookedTx struct {
+	hookedDB
+	tx TxInterface
+}
+
+func (tx *hookedTx) Commit() error {
+	return tx.tx.Commit()
+}
+
+func (tx *hookedTx) Rollback() error {
+	return tx.tx.Rollback()
+}
+
+func (event *QueryEvent) setResult(result sql.Result, err error) {
+	event.Err = err
+	if err != nil {
+		return
+	}
+	if rowsAffected, rowsErr := result.RowsAffected(); rowsErr == nil {
+		event.RowsAffected = rowsAffected
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Logging
+///////////////////////////////////////////////////////////////////////////////
+
+// LoggingHook logs every query once it completes. The fields are query,
+// args (unless HideArgs is set), duration, rows_affected (when known) and
+// error (when the query failed) so that Log can forward them to a structured
+// logger.
+type LoggingHook struct {
+	Log      func(ctx context.Context, message string, fields map[string]interface{})
+	HideArgs bool
+}
+
+func (hook *LoggingHook) BeforeQuery(ctx context.Context, event *QueryEvent) context.Context {
+	return ctx
+}
+
+func (hook *LoggingHook) AfterQuery(ctx context.Context, event *QueryEvent) {
+	fields := map[string]interface{}{
+		"query":    event.Query,
+		"duration": event.Duration,
+	}
+	if !hook.HideArgs {
+		fields["args"] = event.Args
+	}
+	if event.RowsAffected >= 0 {
+		fields["rows_affected"] = event.RowsAffected
+	}
+	message := "query executed"
+	if event.Prepare {
+		message = "query prepared"
+	}
+	if event.Err != nil {
+		fields["error"] = event.Err
+		message = "query failed"
+	}
+	hook.Log(ctx, message, fields)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Slow queries
+///////////////////////////////////////////////////////////////////////////////
+
+// SlowQueryHook reports the queries that take at least Threshold to run
+type SlowQueryHook struct {
+	Threshold time.Duration
+	Report    func(ctx context.Context, event *QueryEvent)
+}
+
+func (hook *SlowQueryHook) BeforeQuery(ctx context.Context, event *QueryEvent) context.Context {
+	return ctx
+}
+
+func (hook *SlowQueryHook) AfterQuery(ctx context.Context, event *QueryEvent) {
+	if event.Duration >= hook.Threshold {
+		hook.Report(ctx, event)
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Tracing
+///////////////////////////////////////////////////////////////////////////////
+
+// Tracer starts spans, e.g. an adapter of an OpenTelemetry trace.Tracer
+type Tracer interface {
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+}
+
+// Span is the subset of an OpenTelemetry trace.Span used by TracingHook
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// TracingHook runs every query in a span named after its SQL command (e.g.
+// SELECT) with the db.system, db.statement and db.rows_affected attributes.
+type TracingHook struct {
+	Tracer Tracer
+	// System is the value of the db.system attribute, e.g. postgresql
+	System string
+}
+
+type tracingSpanKey struct {
+	hook *TracingHook
+}
+
+func (hook *TracingHook) BeforeQuery(ctx context.Context, event *QueryEvent) context.Context {
+	name := queryOperation(event.Query)
+	if event.Prepare {
+		name = "PREPARE " + name
+	}
+	ctx, span := hook.Tracer.Start(ctx, name)
+	if hook.System != "" {
+		span.SetAttribute("db.system", hook.System)
+	}
+	span.SetAttribute("db.statement", event.Query)
+	return context.WithValue(ctx, tracingSpanKey{hook}, span)
+}
+
+func (hook *TracingHook) AfterQuery(ctx context.Context, event *QueryEvent) {
+	span, ok := ctx.Value(tracingSpanKey{hook}).(Span)
+	if !ok {
+		return
+	}
+	if event.RowsAffected >= 0 {
+		span.SetAttribute("db.rows_affected", event.RowsAffected)
+	}
+	if event.Err != nil {
+		span.RecordError(event.Err)
+	}
+	span.End()
+}
+
+// queryOperation returns the SQL command of query, i.e. its first keyword
+// (WITH for a statement with common table expressions)
+func queryOperation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "query"
+	}
+	return strings.ToUpper(fields[0])
+}
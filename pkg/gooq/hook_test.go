@@ -0,0 +1,278 @@
+package gooq
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordingHook struct {
+	name   string
+	log    *[]string
+	events []QueryEvent
+}
+
+func (hook *recordingHook) BeforeQuery(ctx context.Context, event *QueryEvent) context.Context {
+	*hook.log = append(*hook.log, "before "+hook.name)
+	return ctx
+}
+
+func (hook *recordingHook) AfterQuery(ctx context.Context, event *QueryEvent) {
+	*hook.log = append(*hook.log, "after "+hook.name)
+	hook.events = append(hook.events, *event)
+}
+
+// inMemoryExporter is a Tracer that keeps the ended spans in memory
+type inMemoryExporter struct {
+	spans []*inMemorySpan
+}
+
+type inMemorySpan struct {
+	name       string
+	parent     *inMemorySpan
+	attributes map[string]interface{}
+	errors     []error
+	ended      bool
+}
+
+type inMemorySpanKey struct{}
+
+func (exporter *inMemoryExporter) Start(ctx context.Context, spanName string) (context.Context, Span) {
+	parent, _ := ctx.Value(inMemorySpanKey{}).(*inMemorySpan)
+	span := &inMemorySpan{name: spanName, parent: parent, attributes: map[string]interface{}{}}
+	exporter.spans = append(exporter.spans, span)
+	return context.WithValue(ctx, inMemorySpanKey{}, span), span
+}
+
+func (span *inMemorySpan) SetAttribute(key string, value interface{}) {
+	span.attributes[key] = value
+}
+
+func (span *inMemorySpan) RecordError(err error) {
+	span.errors = append(span.errors, err)
+}
+
+func (span *inMemorySpan) End() {
+	span.ended = true
+}
+
+func TestQueryHooks(t *testing.T) {
+	failure := fmt.Errorf("failure")
+	insertStmt := InsertInto(Table1).Set(Table1.Column1, "foo")
+	selectStmt := Select(Table1.Column1).From(Table1).Where(Table1.Column2.Eq(String("bar")))
+	selectQuery := `SELECT table1.column1 FROM public.table1 WHERE table1.column2 = $1`
+
+	t.Run("hooked db", func(t *testing.T) {
+		var log []string
+		first := &recordingHook{name: "first", log: &log}
+		second := &recordingHook{name: "second", log: &log}
+		sqlxDB, _ := newRecordingDB(nil)
+		db := NewHookedDB(sqlxDB, first, second)
+
+		_, err := insertStmt.Exec(Postgres, db)
+		require.NoError(t, err)
+		rows, err := selectStmt.Fetch(Postgres, db)
+		require.NoError(t, err)
+		require.NoError(t, rows.Close())
+
+		require.Equal(t, []string{
+			"before first", "before second", "after second", "after first",
+			"before first", "before second", "after second", "after first",
+		}, log)
+		require.Len(t, first.events, 2)
+		require.Equal(t, "INSERT INTO public.table1 (column1) VALUES ($1)", first.events[0].Query)
+		require.Equal(t, []interface{}{"foo"}, first.events[0].Args)
+		require.Equal(t, int64(1), first.events[0].RowsAffected)
+		require.False(t, first.events[0].StartTime.IsZero())
+		require.Equal(t, selectQuery, first.events[1].Query)
+		require.Equal(t, int64(-1), first.events[1].RowsAffected)
+	})
+
+	t.Run("context hooks", func(t *testing.T) {
+		var log []string
+		dbHook := &recordingHook{name: "db", log: &log}
+		ctxHook := &recordingHook{name: "ctx", log: &log}
+		sqlxDB, _ := newRecordingDB(nil)
+		db := NewHookedDB(sqlxDB, dbHook)
+		ctx := WithQueryHooks(context.Background(), ctxHook)
+
+		_, err := insertStmt.ExecWithContext(ctx, Postgres, db)
+		require.NoError(t, err)
+		row := selectStmt.FetchRowWithContext(ctx, Postgres, sqlxDB)
+		require.NoError(t, row.Err())
+
+		require.Equal(t, []string{
+			"before ctx", "before db", "after db", "after ctx",
+			"before ctx", "after ctx",
+		}, log)
+		require.Len(t, ctxHook.events, 2)
+		require.Len(t, dbHook.events, 1)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var log []string
+		hook := &recordingHook{name: "hook", log: &log}
+		sqlxDB, _ := newRecordingDB(func(stmt string) error {
+			return failure
+		})
+		_, err := insertStmt.Exec(Postgres, NewHookedDB(sqlxDB, hook))
+		require.Equal(t, failure, err)
+		require.Len(t, hook.events, 1)
+		require.Equal(t, failure, hook.events[0].Err)
+		require.Equal(t, int64(-1), hook.events[0].RowsAffected)
+	})
+
+	t.Run("transactions", func(t *testing.T) {
+		var log []string
+		hook := &recordingHook{name: "hook", log: &log}
+		sqlxDB, connector := newRecordingDB(nil)
+		db := NewHookedDB(sqlxDB, hook)
+		err := RunInTx(context.Background(), db, nil, func(tx TxInterface) error {
+			return RunInTx(context.Background(), tx, nil, func(tx TxInterface) error {
+				_, err := insertStmt.Exec(Postgres, tx)
+				return err
+			})
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"BEGIN",
+			"SAVEPOINT gooq_savepoint_1",
+			"INSERT INTO public.table1 (column1) VALUES ($1)",
+			"RELEASE SAVEPOINT gooq_savepoint_1",
+			"COMMIT",
+		}, connector.log)
+		require.Len(t, hook.events, 3)
+
+		rawTx, err := sqlxDB.Beginx()
+		require.NoError(t, err)
+		hookedTx, ok := NewHookedDB(rawTx, hook).(TxInterface)
+		require.True(t, ok)
+		require.NoError(t, hookedTx.Commit())
+	})
+
+	t.Run("prepared statements", func(t *testing.T) {
+		var log []string
+		hook := &recordingHook{name: "hook", log: &log}
+		sqlxDB, connector := newRecordingDB(nil)
+		db := NewHookedDB(sqlxDB, hook)
+
+		stmt, err := db.PrepareContext(context.Background(), selectQuery)
+		require.NoError(t, err)
+		require.NoError(t, stmt.Close())
+		require.Len(t, hook.events, 1)
+		require.True(t, hook.events[0].Prepare)
+		require.Equal(t, selectQuery, hook.events[0].Query)
+
+		err = RunInTx(context.Background(), db, nil, func(tx TxInterface) error {
+			prepared, err := Prepare(context.Background(), tx, insertStmt)
+			if err != nil {
+				return err
+			}
+			defer prepared.Close()
+			for index := 0; index < 2; index++ {
+				if _, err := prepared.Exec(context.Background(), nil); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Contains(t, connector.log, "PREPARE INSERT INTO public.table1 (column1) VALUES ($1)")
+		// the statement is prepared on the transaction without running the hooks
+		require.Len(t, hook.events, 3)
+		for _, event := range hook.events[1:] {
+			require.False(t, event.Prepare)
+			require.Equal(t, "INSERT INTO public.table1 (column1) VALUES ($1)", event.Query)
+			require.Equal(t, int64(1), event.RowsAffected)
+		}
+	})
+
+	t.Run("logging", func(t *testing.T) {
+		var messages []string
+		var fields []map[string]interface{}
+		hook := &LoggingHook{
+			Log: func(ctx context.Context, message string, f map[string]interface{}) {
+				messages = append(messages, message)
+				fields = append(fields, f)
+			},
+		}
+		hook.AfterQuery(context.Background(), &QueryEvent{
+			Query: "UPDATE t SET a = $1", Args: []interface{}{1},
+			Duration: time.Second, RowsAffected: 3,
+		})
+		hook.HideArgs = true
+		hook.AfterQuery(context.Background(), &QueryEvent{
+			Query: "SELECT 1", Args: []interface{}{"secret"},
+			Duration: time.Millisecond, RowsAffected: -1, Err: failure,
+		})
+		hook.AfterQuery(context.Background(), &QueryEvent{
+			Query: "SELECT 2", Duration: time.Millisecond, RowsAffected: -1, Prepare: true,
+		})
+		require.Equal(t, []string{"query executed", "query failed", "query prepared"}, messages)
+		require.Equal(t, []map[string]interface{}{
+			{
+				"query":         "UPDATE t SET a = $1",
+				"args":          []interface{}{1},
+				"duration":      time.Second,
+				"rows_affected": int64(3),
+			},
+			{
+				"query":    "SELECT 1",
+				"duration": time.Millisecond,
+				"error":    failure,
+			},
+			{
+				"query":    "SELECT 2",
+				"duration": time.Millisecond,
+			},
+		}, fields)
+	})
+
+	t.Run("slow queries", func(t *testing.T) {
+		var reported []string
+		hook := &SlowQueryHook{
+			Threshold: 100 * time.Millisecond,
+			Report: func(ctx context.Context, event *QueryEvent) {
+				reported = append(reported, event.Query)
+			},
+		}
+		hook.AfterQuery(context.Background(), &QueryEvent{Query: "fast", Duration: time.Millisecond})
+		hook.AfterQuery(context.Background(), &QueryEvent{Query: "slow", Duration: time.Second})
+		require.Equal(t, []string{"slow"}, reported)
+	})
+
+	t.Run("tracing", func(t *testing.T) {
+		exporter := &inMemoryExporter{}
+		sqlxDB, _ := newRecordingDB(func(stmt string) error {
+			if stmt == selectQuery {
+				return failure
+			}
+			return nil
+		})
+		db := NewHookedDB(sqlxDB, &TracingHook{Tracer: exporter, System: "postgresql"})
+		ctx, parent := exporter.Start(context.Background(), "request")
+
+		_, err := insertStmt.ExecWithContext(ctx, Postgres, db)
+		require.NoError(t, err)
+		_, err = selectStmt.FetchWithContext(ctx, Postgres, db)
+		require.Equal(t, failure, err)
+
+		require.Len(t, exporter.spans, 3)
+		insertSpan, selectSpan := exporter.spans[1], exporter.spans[2]
+		require.Equal(t, "INSERT", insertSpan.name)
+		require.Equal(t, parent, insertSpan.parent)
+		require.True(t, insertSpan.ended)
+		require.Equal(t, map[string]interface{}{
+			"db.system":        "postgresql",
+			"db.statement":     "INSERT INTO public.table1 (column1) VALUES ($1)",
+			"db.rows_affected": int64(1),
+		}, insertSpan.attributes)
+		require.Equal(t, "SELECT", selectSpan.name)
+		require.True(t, selectSpan.ended)
+		require.Equal(t, []error{failure}, selectSpan.errors)
+		require.NotContains(t, selectSpan.attributes, "db.rows_affected")
+	})
+}
@@ -0,0 +1,116 @@
+package gooq
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lib/pq"
+)
+
+// RenderInline renders a statement for Postgres with every argument inlined
+// as a literal instead of a placeholder so that it can be pasted into psql,
+// e.g. for logging or debugging. Statements should still be executed with
+// their arguments, see Build(Dialect).
+func RenderInline(stmt Renderable) (string, error) {
+	builder := (&Builder{}).Debug()
+	stmt.Render(builder)
+	return builder.String(), builder.Err()
+}
+
+// inlineLiteral formats value as a literal of the dialect. Values are
+// converted like lib/pq converts arguments: driver.Valuer first, then by
+// kind. Like a placeholder, quoted literals are untyped so the database
+// resolves their type from the context they are used in.
+func inlineLiteral(dialect *dialectSpec, value interface{}) (string, error) {
+	if value == nil {
+		return "NULL", nil
+	}
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() == reflect.Ptr && reflected.IsNil() {
+		return "NULL", nil
+	}
+	switch value := value.(type) {
+	case driver.Valuer:
+		driverValue, err := value.Value()
+		if err != nil {
+			return "", err
+		}
+		return inlineLiteral(dialect, driverValue)
+	case string:
+		return quoteLiteral(dialect, value), nil
+	case []byte:
+		return inlineBytes(dialect, value), nil
+	case bool:
+		if value {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case time.Time:
+		return quoteLiteral(dialect, string(pq.FormatTimestamp(value))), nil
+	case big.Float:
+		return value.Text('f', -1), nil
+	case *big.Float:
+		return value.Text('f', -1), nil
+	case big.Int:
+		return value.String(), nil
+	case *big.Int:
+		return value.String(), nil
+	}
+	switch reflected.Kind() {
+	case reflect.Ptr:
+		return inlineLiteral(dialect, reflected.Elem().Interface())
+	case reflect.String:
+		return quoteLiteral(dialect, reflected.String()), nil
+	case reflect.Bool:
+		return inlineLiteral(dialect, reflected.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(reflected.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(reflected.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return inlineFloat(reflected.Float(), reflected.Type().Bits()), nil
+	case reflect.Slice:
+		return "", &InvalidLiteralError{Value: value}
+	}
+	return "", fmt.Errorf("cannot inline a value of type %T", value)
+}
+
+// quoteLiteral quotes str as a string constant. Backslashes are only escaped
+// for the dialects that treat them as escape characters, e.g. MySQL, while
+// Postgres does not since standard_conforming_strings is on by default.
+func quoteLiteral(dialect *dialectSpec, str string) string {
+	if dialect.backslashEscapes {
+		str = strings.Replace(str, `\`, `\\`, -1)
+	}
+	return "'" + strings.Replace(str, "'", "''", -1) + "'"
+}
+
+// inlineBytes renders text as a string constant and anything else, which
+// can only be meant for a binary string, in the hex format
+func inlineBytes(dialect *dialectSpec, bytes []byte) string {
+	str := string(bytes)
+	if utf8.ValidString(str) && !strings.ContainsAny(str, "\x00\\") {
+		return quoteLiteral(dialect, str)
+	}
+	return fmt.Sprintf(dialect.bytesFormat, hex.EncodeToString(bytes))
+}
+
+func inlineFloat(value float64, bitSize int) string {
+	switch {
+	case math.IsNaN(value):
+		return "'NaN'"
+	case math.IsInf(value, 1):
+		return "'Infinity'"
+	case math.IsInf(value, -1):
+		return "'-Infinity'"
+	}
+	return strconv.FormatFloat(value, 'g', -1, bitSize)
+}
@@ -0,0 +1,157 @@
+package gooq
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/lumina-tech/gooq/pkg/nullable"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v3"
+)
+
+type testMood string
+
+type testStatus int
+
+func (status testStatus) Value() (driver.Value, error) {
+	switch status {
+	case 0:
+		return "active", nil
+	case 1:
+		return "archived", nil
+	}
+	return nil, fmt.Errorf("invalid status %d", int(status))
+}
+
+func TestInlineLiteral(t *testing.T) {
+	id := uuid.MustParse("5f2d6a3c-2f0e-4a57-9a0e-6c4d2b1f8e90")
+	bigFloat, _, _ := big.ParseFloat("3.14159265358979323846", 10, 128, big.ToNearestEven)
+	text := "it's"
+	var nilText *string
+	var testCases = []struct {
+		value    interface{}
+		expected string
+	}{
+		{nil, "NULL"},
+		{"foo", "'foo'"},
+		{"it's a 'quote'", "'it''s a ''quote'''"},
+		{`back\slash`, `'back\slash'`},
+		{&text, "'it''s'"},
+		{nilText, "NULL"},
+		{true, "TRUE"},
+		{false, "FALSE"},
+		{42, "42"},
+		{int64(-7), "-7"},
+		{uint8(255), "255"},
+		{1.5, "1.5"},
+		{float32(0.1), "0.1"},
+		{math.NaN(), "'NaN'"},
+		{math.Inf(-1), "'-Infinity'"},
+		{*bigFloat, "3.14159265358979323846"},
+		{bigFloat, "3.14159265358979323846"},
+		{big.NewInt(-12345678901234), "-12345678901234"},
+		{time.Date(2020, 1, 2, 3, 4, 5, 600000000, time.UTC), "'2020-01-02 03:04:05.6Z'"},
+		{time.Date(2020, 1, 2, 3, 4, 5, 0, time.FixedZone("", -5*3600)), "'2020-01-02 03:04:05-05:00'"},
+		{[]byte(`{"a": 1}`), `'{"a": 1}'`},
+		{[]byte{0xde, 0xad, 0x00}, `'\xdead00'`},
+		{id, "'5f2d6a3c-2f0e-4a57-9a0e-6c4d2b1f8e90'"},
+		{null.StringFrom("bar"), "'bar'"},
+		{null.String{}, "NULL"},
+		{null.IntFrom(3), "3"},
+		{null.TimeFrom(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)), "'2020-01-02 00:00:00Z'"},
+		{nullable.UUIDFrom(id), "'5f2d6a3c-2f0e-4a57-9a0e-6c4d2b1f8e90'"},
+		{nullable.BigFloatFrom(*big.NewFloat(2.5)), "'2.5'"},
+		{nullable.Jsonb{Jsonb: []byte(`{"b":"it's"}`), Valid: true}, `'{"b":"it''s"}'`},
+		{pq.StringArray{"a", "it's", `"q"`}, `'{"a","it''s","\"q\""}'`},
+		{testMood("happy"), "'happy'"},
+		{testStatus(1), "'archived'"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.expected, func(t *testing.T) {
+			literal, err := inlineLiteral(dialectSpecs[Postgres], testCase.value)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, literal)
+		})
+	}
+
+	_, err := inlineLiteral(dialectSpecs[Postgres], testStatus(5))
+	require.EqualError(t, err, "invalid status 5")
+	_, err = inlineLiteral(dialectSpecs[Postgres], []string{"a"})
+	require.Equal(t, &InvalidLiteralError{Value: []string{"a"}}, err)
+	_, err = inlineLiteral(dialectSpecs[Postgres], map[string]int{})
+	require.EqualError(t, err, "cannot inline a value of type map[string]int")
+}
+
+func TestInlineLiteralDialect(t *testing.T) {
+	var testCases = []struct {
+		dialect  Dialect
+		value    interface{}
+		expected string
+	}{
+		{Postgres, `it's a back\slash`, `'it''s a back\slash'`},
+		{MySQL, `it's a back\slash`, `'it''s a back\\slash'`},
+		{Sqlite, `it's a back\slash`, `'it''s a back\slash'`},
+		{MySQL, `\'; DROP TABLE table1; --`, `'\\''; DROP TABLE table1; --'`},
+		{Postgres, []byte{0xde, 0xad, 0x00}, `'\xdead00'`},
+		{MySQL, []byte{0xde, 0xad, 0x00}, `X'dead00'`},
+		{Sqlite, []byte{0xde, 0xad, 0x00}, `X'dead00'`},
+	}
+	for _, testCase := range testCases {
+		t.Run(fmt.Sprintf("%s %s", testCase.dialect, testCase.expected), func(t *testing.T) {
+			literal, err := inlineLiteral(dialectSpecs[testCase.dialect], testCase.value)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, literal)
+		})
+	}
+
+	builder := NewBuilder(MySQL).Debug()
+	Select().From(Table1).Where(Table1.Column1.Eq(String(`a\b`))).Render(builder)
+	require.NoError(t, builder.Err())
+	require.Equal(t, `SELECT * FROM public.table1 WHERE table1.column1 = 'a\\b'`, builder.String())
+}
+
+func TestRenderInline(t *testing.T) {
+	var testCases = []struct {
+		constructed Renderable
+		expected    string
+	}{
+		{
+			Select().From(Table1).
+				Where(Table1.Column1.Eq(String("it's")), Table1.Column3.Gt(Int64(3))),
+			`SELECT * FROM public.table1 WHERE table1.column1 = 'it''s' AND table1.column3 > 3`,
+		},
+		{
+			Select().From(Table1).Where(Table1.Tags.Contains(StringArray("a", "b"))),
+			`SELECT * FROM public.table1 WHERE table1.tags @> '{"a","b"}'`,
+		},
+		{
+			Select().From(Table1).Where(Table1.Data.Contains(Jsonb(map[string]string{"k": "v'"}))),
+			`SELECT * FROM public.table1 WHERE table1.data @> '{"k":"v''"}'::jsonb`,
+		},
+		{
+			Select(Table1.TimeColumn.Add(Interval(time.Hour))).From(Table1),
+			`SELECT table1.time_column + 'PT3600S'::interval FROM public.table1`,
+		},
+		{
+			InsertInto(Table1).Set(Table1.Column1, null.String{}).Set(Table1.BoolColumn, true),
+			`INSERT INTO public.table1 (column1, bool_column) VALUES (NULL, TRUE)`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.expected, func(t *testing.T) {
+			stmt, err := RenderInline(testCase.constructed)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, stmt)
+		})
+	}
+
+	builder := NewBuilder(Postgres).Debug()
+	Select(newLiteralExpression([]string{"a"})).Render(builder)
+	require.Equal(t, []error{&InvalidLiteralError{Value: []string{"a"}}}, builder.Errors())
+}
@@ -25,27 +25,46 @@ type InsertValuesStep interface {
 
 type InsertOnConflictStep interface {
 	InsertReturningStep
+	OnConflict(fields ...Field) InsertConflictTargetStep
 	OnConflictDoNothing() InsertReturningStep
 	OnConflictDoUpdate(*DatabaseConstraint) InsertOnConflictSetStep
 }
 
+type InsertConflictTargetStep interface {
+	InsertConflictActionStep
+	Where(conditions ...Expression) InsertConflictActionStep
+}
+
+type InsertConflictActionStep interface {
+	DoNothing() InsertReturningStep
+	DoUpdate() InsertOnConflictSetStep
+}
+
 type InsertOnConflictSetStep interface {
-	InsertReturningStep
+	InsertOnConflictWhereStep
 	SetUpdates(f Field, v interface{}) InsertOnConflictSetStep
 	SetUpdateColumns(f ...Field) InsertOnConflictSetStep
 }
 
+type InsertOnConflictWhereStep interface {
+	InsertReturningStep
+	Where(conditions ...Expression) InsertReturningStep
+}
+
 type InsertReturningStep interface {
 	InsertFinalStep
 	Returning(...Expression) InsertResultStep
 }
 
 type InsertResultStep interface {
+	Buildable
 	Fetchable
+	RowFetchable
 	Renderable
 }
 
 type InsertFinalStep interface {
+	Buildable
 	Executable
 	Renderable
 }
@@ -57,31 +76,53 @@ type InsertFinalStep interface {
 // https://www.postgresql.org/docs/current/sql-insert.html
 
 type insert struct {
-	table                 Table
-	selection             Selectable
-	columns               []Field
-	values                [][]interface{}
-	conflictAction        ConflictAction
-	conflictConstraint    *DatabaseConstraint
-	conflictSetPredicates []setPredicate
-	returning             []Expression
+	with                    withClause
+	table                   Table
+	selection               Selectable
+	columns                 []Field
+	values                  [][]interface{}
+	conflictAction          ConflictAction
+	conflictConstraint      *DatabaseConstraint
+	conflictColumns         []Field
+	conflictPredicate       []Expression
+	conflictSetPredicates   []setPredicate
+	conflictUpdatePredicate []Expression
+	returning               []Expression
 }
 
 func InsertInto(t Table) InsertSetStep {
 	return &insert{table: t}
 }
 
+// clone returns a copy of i for a step to modify, see selection.clone. The
+// rows are copied as well since Set appends to the first one.
+func (i *insert) clone() *insert {
+	c := *i
+	c.with = i.with.clone()
+	c.columns = c.columns[:len(c.columns):len(c.columns)]
+	c.values = make([][]interface{}, len(i.values))
+	for index, row := range i.values {
+		c.values[index] = row[:len(row):len(row)]
+	}
+	c.conflictSetPredicates = c.conflictSetPredicates[:len(c.conflictSetPredicates):len(c.conflictSetPredicates)]
+	c.conflictUpdatePredicate = c.conflictUpdatePredicate[:len(c.conflictUpdatePredicate):len(c.conflictUpdatePredicate)]
+	return &c
+}
+
 func (i *insert) Select(s Selectable) InsertOnConflictStep {
+	i = i.clone()
 	i.selection = s
 	return i
 }
 
 func (i *insert) Columns(fields ...Field) InsertValuesStep {
+	i = i.clone()
 	i.columns = fields
 	return i
 }
 
 func (i *insert) Values(values ...interface{}) InsertValuesStep {
+	i = i.clone()
 	i.values = append(i.values, values)
 	return i
 }
@@ -89,6 +130,7 @@ func (i *insert) Values(values ...interface{}) InsertValuesStep {
 func (i *insert) Set(
 	field Field, value interface{},
 ) InsertSetMoreStep {
+	i = i.clone()
 	i.columns = append(i.columns, field)
 	if len(i.values) == 0 {
 		i.values = append(i.values, []interface{}{})
@@ -97,7 +139,18 @@ func (i *insert) Set(
 	return i
 }
 
+// OnConflict sets the columns of the unique index that is the conflict
+// target, e.g. OnConflict(Table.Email).DoNothing(). The conditions given to
+// Where are the predicate of a partial unique index.
+func (i *insert) OnConflict(fields ...Field) InsertConflictTargetStep {
+	i = i.clone()
+	i.conflictConstraint = nil
+	i.conflictColumns = fields
+	return &insertConflictTarget{insert: i}
+}
+
 func (i *insert) OnConflictDoNothing() InsertReturningStep {
+	i = i.clone()
 	i.conflictAction = ConflictActionDoNothing
 	return i
 }
@@ -105,50 +158,94 @@ func (i *insert) OnConflictDoNothing() InsertReturningStep {
 func (i *insert) OnConflictDoUpdate(
 	constraint *DatabaseConstraint,
 ) InsertOnConflictSetStep {
+	i = i.clone()
 	i.conflictAction = ConflictActionDoUpdate
 	i.conflictConstraint = constraint
+	i.conflictColumns = nil
+	i.conflictPredicate = nil
 	return i
 }
 
 func (i *insert) SetUpdates(
 	field Field, value interface{},
 ) InsertOnConflictSetStep {
-	i.conflictSetPredicates = append(i.conflictSetPredicates, setPredicate{field, value})
+	i = i.clone()
+	i.conflictSetPredicates = append(i.conflictSetPredicates, setPredicate{field: field, value: value})
 	return i
 }
 
 func (i *insert) SetUpdateColumns(
 	fields ...Field,
 ) InsertOnConflictSetStep {
-	// NOTE: excluded has to be lowercase
-	excludedTable := NewTable("", "excluded")
+	i = i.clone()
 	for _, field := range fields {
 		i.conflictSetPredicates = append(i.conflictSetPredicates, setPredicate{
 			field: field,
-			value: NewStringField(excludedTable, field.GetName()),
+			value: Excluded(field),
 		})
 	}
 	return i
 }
 
+// Where guards the update, e.g. to only update rows that are older than the
+// proposed ones: Where(Table.UpdatedAt.Lt(ExcludedTime(Table.UpdatedAt)))
+func (i *insert) Where(conditions ...Expression) InsertReturningStep {
+	i = i.clone()
+	i.conflictUpdatePredicate = append(i.conflictUpdatePredicate, conditions...)
+	return i
+}
+
 func (i *insert) Returning(f ...Expression) InsertResultStep {
+	i = i.clone()
 	i.returning = f
 	return i
 }
 
+// insertConflictTarget separates the index predicate of the conflict target
+// from the guard of the update, which are both set by Where
+type insertConflictTarget struct {
+	insert *insert
+}
+
+func (t *insertConflictTarget) Where(
+	conditions ...Expression,
+) InsertConflictActionStep {
+	i := t.insert.clone()
+	i.conflictPredicate = append(i.conflictPredicate, conditions...)
+	return &insertConflictTarget{insert: i}
+}
+
+func (t *insertConflictTarget) DoNothing() InsertReturningStep {
+	i := t.insert.clone()
+	i.conflictAction = ConflictActionDoNothing
+	return i
+}
+
+func (t *insertConflictTarget) DoUpdate() InsertOnConflictSetStep {
+	i := t.insert.clone()
+	i.conflictAction = ConflictActionDoUpdate
+	return i
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // Executable
 ///////////////////////////////////////////////////////////////////////////////
 
 func (i *insert) Exec(dl Dialect, db DBInterface) (sql.Result, error) {
 	builder := i.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
 	return db.Exec(builder.String(), builder.arguments...)
 }
 
 func (i *insert) ExecWithContext(
 	ctx context.Context, dl Dialect, db DBInterface) (sql.Result, error) {
 	builder := i.Build(dl)
-	return db.ExecContext(ctx, builder.String(), builder.arguments...)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return withContextHooks(ctx, db).ExecContext(ctx, builder.String(), builder.arguments...)
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -157,6 +254,9 @@ func (i *insert) ExecWithContext(
 
 func (i *insert) Fetch(dl Dialect, db DBInterface) (*sqlx.Rows, error) {
 	builder := i.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
 	return db.Queryx(builder.String(), builder.arguments...)
 }
 
@@ -165,79 +265,146 @@ func (i *insert) FetchRow(dl Dialect, db DBInterface) *sqlx.Row {
 	return db.QueryRowx(builder.String(), builder.arguments...)
 }
 
+func (i *insert) FetchOne(dl Dialect, db DBInterface) (*sqlx.Row, error) {
+	builder := i.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return db.QueryRowx(builder.String(), builder.arguments...), nil
+}
+
 func (i *insert) FetchWithContext(
 	ctx context.Context, dl Dialect, db DBInterface) (*sqlx.Rows, error) {
 	builder := i.Build(dl)
-	return db.QueryxContext(ctx, builder.String(), builder.arguments...)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return withContextHooks(ctx, db).QueryxContext(ctx, builder.String(), builder.arguments...)
 }
 
 func (i *insert) FetchRowWithContext(
 	ctx context.Context, dl Dialect, db DBInterface) *sqlx.Row {
 	builder := i.Build(dl)
-	return db.QueryRowxContext(ctx, builder.String(), builder.arguments...)
+	return withContextHooks(ctx, db).QueryRowxContext(ctx, builder.String(), builder.arguments...)
+}
+
+func (i *insert) FetchOneWithContext(
+	ctx context.Context, dl Dialect, db DBInterface) (*sqlx.Row, error) {
+	builder := i.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return withContextHooks(ctx, db).QueryRowxContext(ctx, builder.String(), builder.arguments...), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////
 // Renderable
 ///////////////////////////////////////////////////////////////////////////////
 
-func (i *insert) Build(d Dialect) *Builder {
-	builder := Builder{}
-	i.Render(&builder)
-	return &builder
+func (i *insert) Build(dl Dialect) *Builder {
+	builder := NewBuilder(dl)
+	i.Render(builder)
+	return builder
 }
 
 func (i *insert) Render(
 	builder *Builder,
 ) {
+	dialect := builder.getDialect()
+
+	// [ WITH [ RECURSIVE ] with_query [, ...] ]
+	i.with.render(builder)
+
 	// INSERT INTO table_name
-	builder.Printf("INSERT INTO %s ", i.table.GetQualifiedName())
+	if dialect.upsertStyle == upsertStyleOnDuplicateKey &&
+		i.conflictAction == ConflictActionDoNothing {
+		builder.Printf("INSERT IGNORE INTO %s ", builder.quoteTableName(i.table))
+	} else {
+		builder.Printf("INSERT INTO %s ", builder.quoteTableName(i.table))
+	}
 
 	if i.selection != nil {
 		// handle INSERT ...SELECT
-		builder.Print("(")
-		i.selection.Render(builder)
-		builder.Print(")")
+		if dialect.parenthesizedSelect {
+			builder.Print("(")
+			i.selection.Render(builder)
+			builder.Print(")")
+		} else {
+			i.selection.Render(builder)
+		}
 	} else {
 		// handle INSERT .. SET
-		i.renderColumnsAndValues(builder, i.columns, i.values)
+		renderColumnsAndValues(builder, i.columns, i.values)
 	}
 
 	// [ ON CONFLICT conflict_action ]
 	if i.conflictAction != ConflictActionNil {
-		builder.Printf(" ON CONFLICT")
-		if i.conflictConstraint != nil {
-			if i.conflictConstraint.Predicate.Valid {
-				builder.Print(" ")
-				builder.RenderFieldArray(i.conflictConstraint.Columns)
-				builder.Printf(" WHERE %s", i.conflictConstraint.Predicate.String)
-			} else {
-				builder.Printf(" ON CONSTRAINT %s", i.conflictConstraint.Name)
+		switch dialect.upsertStyle {
+		case upsertStyleOnConflict:
+			i.renderOnConflict(builder)
+		case upsertStyleOnDuplicateKey:
+			// MySQL applies the update to whichever unique key conflicts so
+			// the conflict target cannot be expressed and is ignored
+			if i.conflictAction == ConflictActionDoUpdate {
+				builder.Print(" ON DUPLICATE KEY UPDATE ")
+				builder.RenderSetPredicates(i.conflictSetPredicates)
+				if len(withoutNoops(i.conflictUpdatePredicate)) > 0 {
+					builder.errors = append(builder.errors, &UnsupportedClauseError{
+						Statement: "INSERT ... ON DUPLICATE KEY UPDATE",
+						Clause:    "WHERE",
+					})
+				}
 			}
 		}
-		if i.conflictAction == ConflictActionDoNothing {
-			builder.Print(" DO NOTHING")
-		} else if i.conflictAction == ConflictActionDoUpdate {
-			builder.Printf(" %s SET ", i.conflictAction)
-			builder.RenderSetPredicates(i.conflictSetPredicates)
-		}
 	}
 
 	// [ RETURNING output_expression ]
-	if i.returning != nil {
+	if i.returning != nil && builder.requireFeature(DialectFeatureReturning) {
 		builder.Print(" RETURNING ")
 		builder.RenderExpressions(i.returning)
 	}
 }
 
+func (i *insert) renderOnConflict(
+	builder *Builder,
+) {
+	builder.Printf(" ON CONFLICT")
+	if len(i.conflictColumns) > 0 {
+		builder.Print(" ")
+		builder.RenderFieldArray(i.conflictColumns)
+		if conditions := withoutNoops(i.conflictPredicate); len(conditions) > 0 {
+			builder.Print(" WHERE ")
+			builder.renderInlineConditions(conditions)
+		}
+	} else if i.conflictConstraint != nil {
+		if i.conflictConstraint.Predicate.Valid {
+			builder.Print(" ")
+			builder.RenderFieldArray(i.conflictConstraint.Columns)
+			builder.Printf(" WHERE %s", i.conflictConstraint.Predicate.String)
+		} else if builder.requireFeature(DialectFeatureOnConflictOnConstraint) {
+			builder.Printf(" ON CONSTRAINT %s", builder.QuoteIdentifier(i.conflictConstraint.Name))
+		}
+	}
+	if i.conflictAction == ConflictActionDoNothing {
+		builder.Print(" DO NOTHING")
+	} else if i.conflictAction == ConflictActionDoUpdate {
+		builder.Printf(" %s SET ", i.conflictAction)
+		builder.RenderSetPredicates(i.conflictSetPredicates)
+		if conditions := withoutNoops(i.conflictUpdatePredicate); len(conditions) > 0 {
+			builder.Print(" WHERE ")
+			builder.RenderConditions(conditions)
+		}
+	}
+}
+
 // render set columns and values
-func (i *insert) renderColumnsAndValues(
+func renderColumnsAndValues(
 	builder *Builder, columns []Field, values [][]interface{},
 ) *Builder {
 	if len(columns) > 0 {
 		builder.Print("(")
 		for index, column := range columns {
-			builder.Printf(column.GetName())
+			builder.Print(builder.QuoteIdentifier(column.GetName()))
 			if index != len(columns)-1 {
 				builder.Print(", ")
 			}
@@ -248,7 +415,7 @@ func (i *insert) renderColumnsAndValues(
 	for arrayIndex, array := range values {
 		builder.Print("(")
 		for index, value := range array {
-			builder.RenderExpression(newLiteralExpression(value))
+			renderValue(builder, value)
 			if index != len(array)-1 {
 				builder.Print(", ")
 			}
@@ -260,3 +427,238 @@ func (i *insert) renderColumnsAndValues(
 	}
 	return builder
 }
+
+// renderValue renders an expression as is and any other value as a literal
+func renderValue(
+	builder *Builder, value interface{},
+) {
+	if expression, ok := value.(Expression); ok {
+		builder.RenderExpression(expression)
+	} else {
+		builder.RenderExpression(newLiteralExpression(value))
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Excluded
+///////////////////////////////////////////////////////////////////////////////
+
+// Excluded references the value proposed for insertion into field in the
+// update of an upsert, i.e. excluded.field or VALUES(field) in MySQL. The
+// expression has the type of the field, e.g. a NumericExpression for an
+// IntField, the typed variants below avoid the type assertion:
+//
+//	SetUpdates(Table.Counter, Table.Counter.Add(ExcludedNumeric(Table.Counter)))
+func Excluded(field Field) Expression {
+	switch field := field.(type) {
+	case BoolExpression:
+		return ExcludedBool(field.(BoolField))
+	case NumericExpression:
+		return ExcludedNumeric(field.(DecimalField))
+	case StringExpression:
+		return ExcludedString(field.(StringField))
+	case DateTimeExpression:
+		return ExcludedTime(field.(TimeField))
+	case IntervalExpression:
+		return ExcludedInterval(field.(IntervalField))
+	case UUIDExpression:
+		return ExcludedUUID(field.(UUIDField))
+	case JsonbExpression:
+		return ExcludedJsonb(field.(JsonbField))
+	case StringArrayExpression:
+		return ExcludedStringArray(field.(StringArrayField))
+	case NumericArrayExpression:
+		return ExcludedNumericArray(field.(IntArrayField))
+	case UUIDArrayExpression:
+		return ExcludedUUIDArray(field.(UUIDArrayField))
+	}
+	column := &excludedColumn{name: field.GetName()}
+	column.expressionImpl.initFieldExpressionImpl(column)
+	return column
+}
+
+// ExcludedBool is Excluded for a BoolField
+func ExcludedBool(field BoolField) BoolExpression {
+	column := &excludedBoolColumn{name: field.GetName()}
+	column.expressionImpl.initFieldExpressionImpl(column)
+	return column
+}
+
+// ExcludedNumeric is Excluded for an IntField or a DecimalField
+func ExcludedNumeric(field IntField) NumericExpression {
+	column := &excludedNumericColumn{name: field.GetName()}
+	column.expressionImpl.initFieldExpressionImpl(column)
+	return column
+}
+
+// ExcludedString is Excluded for a StringField
+func ExcludedString(field StringField) StringExpression {
+	column := &excludedStringColumn{name: field.GetName()}
+	column.expressionImpl.initFieldExpressionImpl(column)
+	return column
+}
+
+// ExcludedTime is Excluded for a TimeField
+func ExcludedTime(field TimeField) DateTimeExpression {
+	column := &excludedDateTimeColumn{name: field.GetName()}
+	column.expressionImpl.initFieldExpressionImpl(column)
+	return column
+}
+
+// ExcludedInterval is Excluded for an IntervalField
+func ExcludedInterval(field IntervalField) IntervalExpression {
+	column := &excludedIntervalColumn{name: field.GetName()}
+	column.expressionImpl.initFieldExpressionImpl(column)
+	return column
+}
+
+// ExcludedUUID is Excluded for a UUIDField
+func ExcludedUUID(field UUIDField) UUIDExpression {
+	column := &excludedUUIDColumn{name: field.GetName()}
+	column.expressionImpl.initFieldExpressionImpl(column)
+	return column
+}
+
+// ExcludedJsonb is Excluded for a JsonbField
+func ExcludedJsonb(field JsonbField) JsonbExpression {
+	column := &excludedJsonbColumn{name: field.GetName()}
+	column.expressionImpl.initFieldExpressionImpl(column)
+	return column
+}
+
+// ExcludedStringArray is Excluded for a StringArrayField
+func ExcludedStringArray(field StringArrayField) StringArrayExpression {
+	column := &excludedStringArrayColumn{name: field.GetName()}
+	column.expressionImpl.initFieldExpressionImpl(column)
+	return column
+}
+
+// ExcludedNumericArray is Excluded for an IntArrayField
+func ExcludedNumericArray(field IntArrayField) NumericArrayExpression {
+	column := &excludedNumericArrayColumn{name: field.GetName()}
+	column.expressionImpl.initFieldExpressionImpl(column)
+	return column
+}
+
+// ExcludedUUIDArray is Excluded for a UUIDArrayField
+func ExcludedUUIDArray(field UUIDArrayField) UUIDArrayExpression {
+	column := &excludedUUIDArrayColumn{name: field.GetName()}
+	column.expressionImpl.initFieldExpressionImpl(column)
+	return column
+}
+
+// excludedColumn references the value proposed for insertion into a column
+// of any other type
+type excludedColumn struct {
+	expressionImpl
+	name string
+}
+
+func (column *excludedColumn) Render(builder *Builder) {
+	renderExcluded(builder, column.name)
+}
+
+// the typed references embed the expression implementation of their type so
+// that they can be used with its operators
+
+type excludedBoolColumn struct {
+	boolExpressionImpl
+	name string
+}
+
+func (column *excludedBoolColumn) Render(builder *Builder) {
+	renderExcluded(builder, column.name)
+}
+
+type excludedNumericColumn struct {
+	numericExpressionImpl
+	name string
+}
+
+func (column *excludedNumericColumn) Render(builder *Builder) {
+	renderExcluded(builder, column.name)
+}
+
+type excludedStringColumn struct {
+	stringExpressionImpl
+	name string
+}
+
+func (column *excludedStringColumn) Render(builder *Builder) {
+	renderExcluded(builder, column.name)
+}
+
+type excludedDateTimeColumn struct {
+	dateTimeExpressionImpl
+	name string
+}
+
+func (column *excludedDateTimeColumn) Render(builder *Builder) {
+	renderExcluded(builder, column.name)
+}
+
+type excludedIntervalColumn struct {
+	intervalExpressionImpl
+	name string
+}
+
+func (column *excludedIntervalColumn) Render(builder *Builder) {
+	renderExcluded(builder, column.name)
+}
+
+type excludedUUIDColumn struct {
+	uuidExpressionImpl
+	name string
+}
+
+func (column *excludedUUIDColumn) Render(builder *Builder) {
+	renderExcluded(builder, column.name)
+}
+
+type excludedJsonbColumn struct {
+	jsonbExpressionImpl
+	name string
+}
+
+func (column *excludedJsonbColumn) Render(builder *Builder) {
+	renderExcluded(builder, column.name)
+}
+
+type excludedStringArrayColumn struct {
+	stringArrayExpressionImpl
+	name string
+}
+
+func (column *excludedStringArrayColumn) Render(builder *Builder) {
+	renderExcluded(builder, column.name)
+}
+
+type excludedNumericArrayColumn struct {
+	numericArrayExpressionImpl
+	name string
+}
+
+func (column *excludedNumericArrayColumn) Render(builder *Builder) {
+	renderExcluded(builder, column.name)
+}
+
+type excludedUUIDArrayColumn struct {
+	uuidArrayExpressionImpl
+	name string
+}
+
+func (column *excludedUUIDArrayColumn) Render(builder *Builder) {
+	renderExcluded(builder, column.name)
+}
+
+// renderExcluded renders excluded.column or VALUES(column) in MySQL
+func renderExcluded(builder *Builder, name string) {
+	switch builder.getDialect().upsertStyle {
+	case upsertStyleOnDuplicateKey:
+		builder.Printf("VALUES(%s)", builder.QuoteIdentifier(name))
+	default:
+		// NOTE: excluded has to be lowercase
+		builder.Printf("%s.%s", builder.QuoteIdentifier("excluded"),
+			builder.QuoteIdentifier(name))
+	}
+}
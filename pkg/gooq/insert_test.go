@@ -1,6 +1,11 @@
 package gooq
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
 
 var insertTestCases = []TestCase{
 	{
@@ -11,6 +16,11 @@ var insertTestCases = []TestCase{
 		Constructed:  InsertInto(Table1).Set(Table1.Column1, "foo").Set(Table1.Column2, "bar"),
 		ExpectedStmt: `INSERT INTO public.table1 (column1, column2) VALUES ($1, $2)`,
 	},
+	{
+		Constructed:  InsertInto(Table1).Set(Table1.Column1, "foo").Set(Table1.TimeColumn, Now()),
+		ExpectedStmt: `INSERT INTO public.table1 (column1, time_column) VALUES ($1, NOW())`,
+		Arguments:    []interface{}{"foo"},
+	},
 	{
 		Constructed: InsertInto(Table1).
 			Values("1", "2", 3, 4).
@@ -26,11 +36,16 @@ var insertTestCases = []TestCase{
 	},
 	{
 		Constructed:  InsertInto(Table1).Select(Select(Table1.Column1).From(Table1)),
-		ExpectedStmt: `INSERT INTO public.table1 (SELECT "table1".column1 FROM public.table1)`,
+		ExpectedStmt: `INSERT INTO public.table1 (SELECT table1.column1 FROM public.table1)`,
+	},
+	{
+		Constructed: With("moved", Delete(Table2).Where(Table2.Column3.IsLt(0)).Returning(Table2.Column1)).
+			InsertInto(Table1).Select(Select(NewStringField(NewTable("", "moved"), "column1")).From(NewTable("", "moved"))),
+		ExpectedStmt: `WITH moved AS (DELETE FROM public.table2 WHERE table2.column3 < $1 RETURNING table2.column1) INSERT INTO public.table1 (SELECT moved.column1 FROM moved)`,
 	},
 	{
 		Constructed:  InsertInto(Table1).Set(Table1.Column1, "foo").Returning(Table1.Column1),
-		ExpectedStmt: `INSERT INTO public.table1 (column1) VALUES ($1) RETURNING "table1".column1`,
+		ExpectedStmt: `INSERT INTO public.table1 (column1) VALUES ($1) RETURNING table1.column1`,
 	},
 	{
 		Constructed: InsertInto(Table1).
@@ -44,7 +59,7 @@ var insertTestCases = []TestCase{
 			Set(Table1.Column1, "foo").Set(Table1.Column2, "bar").
 			OnConflictDoUpdate(&Table1Constraint).
 			SetUpdateColumns(Table1.Column2),
-		ExpectedStmt: `INSERT INTO public.table1 (column1, column2) VALUES ($1, $2) ON CONFLICT ON CONSTRAINT table1_pkey DO UPDATE SET column2 = "excluded".column2`,
+		ExpectedStmt: `INSERT INTO public.table1 (column1, column2) VALUES ($1, $2) ON CONFLICT ON CONSTRAINT table1_pkey DO UPDATE SET column2 = excluded.column2`,
 	},
 	{
 		Constructed:  InsertInto(Table1).Set(Table1.Column1, "foo").OnConflictDoNothing(),
@@ -57,10 +72,118 @@ var insertTestCases = []TestCase{
 			Set(Table2.Column3, 1).
 			OnConflictDoUpdate(&Table2Constraint).
 			SetUpdateColumns(Table2.Column3),
-		ExpectedStmt: `INSERT INTO public.table2 (column1, column2, column3) VALUES ($1, $2, $3) ON CONFLICT (column1, column2) WHERE ((bool_column)::bool <> 'true'::bool) DO UPDATE SET column3 = "excluded".column3`,
+		ExpectedStmt: `INSERT INTO public.table2 (column1, column2, column3) VALUES ($1, $2, $3) ON CONFLICT (column1, column2) WHERE ((bool_column)::bool <> 'true'::bool) DO UPDATE SET column3 = excluded.column3`,
+	},
+	{
+		Constructed: InsertInto(Table1).
+			Set(Table1.Column1, "foo").
+			OnConflict(Table1.Column1).DoNothing(),
+		ExpectedStmt: `INSERT INTO public.table1 (column1) VALUES ($1) ON CONFLICT (column1) DO NOTHING`,
+	},
+	{
+		Constructed: InsertInto(Table1).
+			Set(Table1.Column1, "foo").Set(Table1.Column3, 1).
+			OnConflict(Table1.Column1).Where(Table1.BoolColumn.IsEq(true)).
+			DoUpdate().
+			SetUpdates(Table1.Column3, Table1.Column3.Add(ExcludedNumeric(Table1.Column3))),
+		ExpectedStmt: `INSERT INTO public.table1 (column1, column3) VALUES ($1, $2) ON CONFLICT (column1) WHERE table1.bool_column = TRUE DO UPDATE SET column3 = table1.column3 + excluded.column3`,
+		Arguments:    []interface{}{"foo", 1},
+	},
+	{
+		Constructed: InsertInto(Table1).
+			Set(Table1.ID, "id").Set(Table1.Column1, "foo").Set(Table1.TimeColumn, "now").
+			OnConflict(Table1.ID).DoUpdate().
+			SetUpdateColumns(Table1.Column1, Table1.TimeColumn).
+			Where(Table1.TimeColumn.Lt(ExcludedTime(Table1.TimeColumn))).
+			Returning(Table1.ID),
+		ExpectedStmt: `INSERT INTO public.table1 (id, column1, time_column) VALUES ($1, $2, $3) ON CONFLICT (id) DO UPDATE SET column1 = excluded.column1, time_column = excluded.time_column WHERE table1.time_column < excluded.time_column RETURNING table1.id`,
+	},
+	{
+		Constructed: InsertInto(Table2).
+			Set(Table2.Column1, "foo").
+			OnConflictDoUpdate(&Table2Constraint).
+			SetUpdates(Table2.Column2, Coalesce(Excluded(Table2.Column2), Table2.Column2)).
+			Where(Noop(), Table2.Column2.NotEq(ExcludedString(Table2.Column2))),
+		ExpectedStmt: `INSERT INTO public.table2 (column1) VALUES ($1) ON CONFLICT (column1, column2) WHERE ((bool_column)::bool <> 'true'::bool) DO UPDATE SET column2 = COALESCE(excluded.column2, table2.column2) WHERE table2.column2 != excluded.column2`,
 	},
 }
 
 func TestInsert(t *testing.T) {
 	runTestCases(t, insertTestCases)
 }
+
+func TestDerivedInserts(t *testing.T) {
+	// the first row has spare capacity after the third Set
+	base := InsertInto(Table1).Set(Table1.Column1, "foo").Set(Table1.Column2, "bar").Set(Table1.Column3, 1)
+	runTestCases(t, []TestCase{
+		{
+			Constructed:  base.Set(Table1.StringColumn, "a"),
+			ExpectedStmt: `INSERT INTO public.table1 (column1, column2, column3, string_column) VALUES ($1, $2, $3, $4)`,
+			Arguments:    []interface{}{"foo", "bar", 1, "a"},
+		},
+		{
+			Constructed:  base.Set(Table1.BoolColumn, true),
+			ExpectedStmt: `INSERT INTO public.table1 (column1, column2, column3, bool_column) VALUES ($1, $2, $3, $4)`,
+			Arguments:    []interface{}{"foo", "bar", 1, true},
+		},
+		{
+			Constructed:  base.OnConflictDoNothing(),
+			ExpectedStmt: `INSERT INTO public.table1 (column1, column2, column3) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`,
+		},
+		{
+			Constructed:  base,
+			ExpectedStmt: `INSERT INTO public.table1 (column1, column2, column3) VALUES ($1, $2, $3)`,
+		},
+	})
+}
+
+func TestExcluded(t *testing.T) {
+	// the references have the expression type of their field
+	require.Implements(t, (*UUIDExpression)(nil), Excluded(Table1.ID))
+	require.Implements(t, (*StringExpression)(nil), Excluded(Table1.Column1))
+	require.Implements(t, (*NumericExpression)(nil), Excluded(Table1.Column3))
+	require.Implements(t, (*NumericExpression)(nil), Excluded(Table1.Column4))
+	require.Implements(t, (*BoolExpression)(nil), Excluded(Table1.BoolColumn))
+	require.Implements(t, (*DateTimeExpression)(nil), Excluded(Table1.TimeColumn))
+	require.Implements(t, (*IntervalExpression)(nil), Excluded(Table1.Duration))
+	require.Implements(t, (*JsonbExpression)(nil), Excluded(Table1.Data))
+	require.Implements(t, (*StringArrayExpression)(nil), Excluded(Table1.Tags))
+	require.Implements(t, (*NumericArrayExpression)(nil), Excluded(Table1.Scores))
+	require.Implements(t, (*UUIDArrayExpression)(nil), Excluded(Table1.Owners))
+
+	for _, dialect := range []Dialect{Postgres, MySQL} {
+		builder := NewBuilder(dialect)
+		builder.RenderExpression(ExcludedNumeric(Table1.Column3).Add(Int64(1)))
+		switch dialect {
+		case MySQL:
+			require.Equal(t, "VALUES(column3) + ?", builder.String())
+		default:
+			require.Equal(t, "excluded.column3 + $1", builder.String())
+		}
+	}
+}
+
+func TestConflictTargetPredicate(t *testing.T) {
+	// the index predicate is matched against partial unique indexes at plan
+	// time, so it cannot have placeholders
+	stmt := InsertInto(Table1).
+		Set(Table1.Column1, "foo").
+		OnConflict(Table1.Column1).Where(Table1.Column2.Eq(String("it's")), Table1.Column3.Gt(Int64(1))).
+		DoUpdate().
+		SetUpdates(Table1.Column2, Excluded(Table1.Column2)).
+		Where(Table1.Column4.Lt(Float64(2.5)))
+	builder := stmt.Build(Postgres)
+	require.NoError(t, builder.Err())
+	require.Equal(t, `INSERT INTO public.table1 (column1) VALUES ($1) ON CONFLICT (column1) WHERE table1.column2 = 'it''s' AND table1.column3 > 1 DO UPDATE SET column2 = excluded.column2 WHERE table1.column4 < $2`, builder.String())
+	require.Equal(t, []interface{}{"foo", 2.5}, builder.arguments)
+	predicate := builder.String()[strings.Index(builder.String(), "ON CONFLICT"):strings.Index(builder.String(), "DO UPDATE")]
+	require.NotRegexp(t, `\$\d`, predicate)
+
+	builder = InsertInto(Table1).Set(Table1.Column1, "foo").
+		OnConflict(Table1.Column1).Where(Table1.Column2.Eq(StringParam("name"))).DoNothing().
+		Build(Postgres)
+	require.Equal(t, []error{&UnsupportedClauseError{
+		Statement: "an inlined condition",
+		Clause:    `parameter "name"`,
+	}}, builder.Errors())
+}
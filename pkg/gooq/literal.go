@@ -4,12 +4,19 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 func keyword(value string) Expression {
 	return newKeywordExpression(value)
 }
 
+// Null is the NULL literal, e.g. the argument that is passed for an OUT
+// parameter of a procedure
+func Null() Expression {
+	return keyword("NULL")
+}
+
 func Literal(value interface{}) Expression {
 	return newLiteralExpression(value)
 }
@@ -26,6 +33,21 @@ func DateTime(value time.Time) DateTimeExpression {
 	return expr
 }
 
+// Interval renders the duration as an ISO 8601 interval parameter
+func Interval(value time.Duration) IntervalExpression {
+	expr := &intervalLiteral{}
+	expr.expressionImpl.initLiteralExpression(expr, formatISOInterval(value))
+	return expr
+}
+
+// IntervalFromISO accepts an ISO 8601 duration such as P1Y2M3DT4H5M6S.
+// Invalid durations are reported when the statement is built.
+func IntervalFromISO(value string) IntervalExpression {
+	expr := &intervalLiteral{isISO: true}
+	expr.expressionImpl.initLiteralExpression(expr, value)
+	return expr
+}
+
 func Int64(value int64) NumericExpression {
 	expr := &numericExpressionImpl{}
 	expr.expressionImpl.initLiteralExpression(expr, value)
@@ -50,10 +72,62 @@ func UUID(value uuid.UUID) UUIDExpression {
 	return expr
 }
 
+func StringArray(value ...string) StringArrayExpression {
+	expr := &stringArrayExpressionImpl{}
+	expr.expressionImpl.initLiteralExpression(expr, pq.StringArray(value))
+	return expr
+}
+
+func Int64Array(value ...int64) NumericArrayExpression {
+	expr := &numericArrayExpressionImpl{}
+	expr.expressionImpl.initLiteralExpression(expr, pq.Int64Array(value))
+	return expr
+}
+
+// IntArray is Int64Array for the int columns of the generated models, e.g.
+// Table1.Column3.Eq(IntArray(ids...).Any())
+func IntArray(value ...int) NumericArrayExpression {
+	values := make(pq.Int64Array, len(value))
+	for index, v := range value {
+		values[index] = int64(v)
+	}
+	return Int64Array(values...)
+}
+
+func UUIDArray(value ...uuid.UUID) UUIDArrayExpression {
+	expr := &uuidArrayExpressionImpl{}
+	expr.expressionImpl.initLiteralExpression(expr, pq.GenericArray{A: value})
+	return expr
+}
+
+// Jsonb marshals value with encoding/json into a jsonb parameter. A []byte
+// or json.RawMessage value is passed through as an already encoded document.
+func Jsonb(value interface{}) JsonbExpression {
+	expr := &jsonbLiteral{}
+	expr.expressionImpl.initLiteralExpression(expr, value)
+	return expr
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // Other literals
 ///////////////////////////////////////////////////////////////////////////////
 
 var (
 	Asterisk = keyword("*")
+
+	// 9.9.4. Current Date/Time
+	// https://www.postgresql.org/docs/11/functions-datetime.html#FUNCTIONS-DATETIME-CURRENT
+	CurrentDate      = dateTimeKeyword("CURRENT_DATE")
+	CurrentTime      = dateTimeKeyword("CURRENT_TIME")
+	CurrentTimestamp = dateTimeKeyword("CURRENT_TIMESTAMP")
+	LocalTime        = dateTimeKeyword("LOCALTIME")
+	LocalTimestamp   = dateTimeKeyword("LOCALTIMESTAMP")
 )
+
+func dateTimeKeyword(value string) DateTimeExpression {
+	expr := &dateTimeExpressionImpl{}
+	expr.expressionImpl.originalExpression = expr
+	expr.expressionImpl.expressionType = ExpressionTypeKeyword
+	expr.expressionImpl.value = value
+	return expr
+}
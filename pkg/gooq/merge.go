@@ -0,0 +1,484 @@
+package gooq
+
+import (
+	"context"
+	"database/sql"
+)
+
+type MergeUsingStep interface {
+	Using(Selectable) MergeOnStep
+}
+
+type MergeOnStep interface {
+	On(...Expression) MergeWhenStep
+}
+
+type MergeWhenStep interface {
+	MergeFinalStep
+	// WhenMatched adds a WHEN MATCHED [AND conditions] clause for the rows of
+	// the target that are matched by a row of the source
+	WhenMatched(conditions ...Expression) MergeMatchedStep
+	// WhenNotMatched adds a WHEN NOT MATCHED [AND conditions] clause for the
+	// rows of the source that do not match any row of the target
+	WhenNotMatched(conditions ...Expression) MergeNotMatchedStep
+}
+
+type MergeMatchedStep interface {
+	ThenUpdate() MergeUpdateStep
+	ThenDelete() MergeWhenStep
+	ThenDoNothing() MergeWhenStep
+}
+
+type MergeUpdateStep interface {
+	MergeWhenStep
+	Set(f Field, v interface{}) MergeUpdateStep
+}
+
+type MergeNotMatchedStep interface {
+	ThenInsert(fields ...Field) MergeValuesStep
+	ThenDoNothing() MergeWhenStep
+}
+
+type MergeValuesStep interface {
+	Values(v ...interface{}) MergeWhenStep
+}
+
+type MergeFinalStep interface {
+	Buildable
+	Executable
+	Renderable
+	// Upsert renders the statement as INSERT ... ON CONFLICT, e.g. for
+	// Postgres servers older than 15, see MergeInto
+	Upsert() MergeFinalStep
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Implementation
+///////////////////////////////////////////////////////////////////////////////
+
+// https://www.postgresql.org/docs/15/sql-merge.html
+
+type mergeAction int
+
+const (
+	mergeActionDoNothing mergeAction = iota
+	mergeActionUpdate
+	mergeActionDelete
+	mergeActionInsert
+)
+
+type mergeClause struct {
+	isMatched     bool
+	conditions    []Expression
+	action        mergeAction
+	setPredicates []setPredicate
+	columns       []Field
+	values        []interface{}
+}
+
+type merge struct {
+	table      Table
+	source     Selectable
+	conditions []Expression
+	clauses    []mergeClause
+	isUpsert   bool
+}
+
+// MergeInto starts a MERGE statement, which is rendered as an upsert, i.e.
+// INSERT ... ON CONFLICT (or ON DUPLICATE KEY UPDATE in MySQL), for the
+// dialects that do not support MERGE or when Upsert() is called. An upsert
+// can only express a MERGE that:
+//
+//   - has a single WHEN NOT MATCHED THEN INSERT clause and at most one WHEN
+//     MATCHED clause, which does not DELETE
+//   - matches rows on the unique columns of the target that are compared by
+//     the ON conditions, which become the conflict target. Every ON condition
+//     must compare a column of the target for equality.
+//   - only references the source in the inserted values. The values of the
+//     source that are assigned by WHEN MATCHED THEN UPDATE must be fields of
+//     the source that are also inserted, they are replaced by the excluded
+//     values of the columns they are inserted into.
+func MergeInto(t Table) MergeUsingStep {
+	return &merge{table: t}
+}
+
+// clone returns a copy of m for a step to modify, see selection.clone. The
+// clauses are copied as well since the steps complete the last one.
+func (m *merge) clone() *merge {
+	c := *m
+	c.conditions = c.conditions[:len(c.conditions):len(c.conditions)]
+	c.clauses = make([]mergeClause, len(m.clauses))
+	for index, clause := range m.clauses {
+		clause.setPredicates = clause.setPredicates[:len(clause.setPredicates):len(clause.setPredicates)]
+		c.clauses[index] = clause
+	}
+	return &c
+}
+
+func (m *merge) lastClause() *mergeClause {
+	return &m.clauses[len(m.clauses)-1]
+}
+
+func (m *merge) Using(s Selectable) MergeOnStep {
+	m = m.clone()
+	m.source = s
+	return m
+}
+
+func (m *merge) On(c ...Expression) MergeWhenStep {
+	m = m.clone()
+	m.conditions = c
+	return m
+}
+
+func (m *merge) WhenMatched(c ...Expression) MergeMatchedStep {
+	m = m.clone()
+	m.clauses = append(m.clauses, mergeClause{isMatched: true, conditions: c})
+	return m
+}
+
+func (m *merge) WhenNotMatched(c ...Expression) MergeNotMatchedStep {
+	m = m.clone()
+	m.clauses = append(m.clauses, mergeClause{conditions: c})
+	return m
+}
+
+func (m *merge) ThenUpdate() MergeUpdateStep {
+	m = m.clone()
+	m.lastClause().action = mergeActionUpdate
+	return m
+}
+
+func (m *merge) Set(field Field, value interface{}) MergeUpdateStep {
+	m = m.clone()
+	clause := m.lastClause()
+	clause.setPredicates = append(clause.setPredicates, setPredicate{field: field, value: value})
+	return m
+}
+
+func (m *merge) ThenDelete() MergeWhenStep {
+	m = m.clone()
+	m.lastClause().action = mergeActionDelete
+	return m
+}
+
+func (m *merge) ThenInsert(fields ...Field) MergeValuesStep {
+	m = m.clone()
+	clause := m.lastClause()
+	clause.action = mergeActionInsert
+	clause.columns = fields
+	return m
+}
+
+func (m *merge) Values(values ...interface{}) MergeWhenStep {
+	m = m.clone()
+	m.lastClause().values = values
+	return m
+}
+
+func (m *merge) ThenDoNothing() MergeWhenStep {
+	m = m.clone()
+	m.lastClause().action = mergeActionDoNothing
+	return m
+}
+
+func (m *merge) Upsert() MergeFinalStep {
+	m = m.clone()
+	m.isUpsert = true
+	return m
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Executable
+///////////////////////////////////////////////////////////////////////////////
+
+func (m *merge) Exec(dl Dialect, db DBInterface) (sql.Result, error) {
+	builder := m.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return db.Exec(builder.String(), builder.arguments...)
+}
+
+func (m *merge) ExecWithContext(
+	ctx context.Context, dl Dialect, db DBInterface) (sql.Result, error) {
+	builder := m.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return withContextHooks(ctx, db).ExecContext(ctx, builder.String(), builder.arguments...)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Renderable
+///////////////////////////////////////////////////////////////////////////////
+
+func (m *merge) Build(dl Dialect) *Builder {
+	builder := NewBuilder(dl)
+	m.Render(builder)
+	return builder
+}
+
+func (m *merge) Render(
+	builder *Builder,
+) {
+	if m.isUpsert || !builder.getDialect().dialect.Supports(DialectFeatureMerge) {
+		m.renderUpsert(builder)
+		return
+	}
+
+	// MERGE INTO target_table_name [ [ AS ] target_alias ]
+	builder.Print("MERGE INTO ")
+	m.table.Render(builder)
+
+	// USING data_source ON join_condition
+	builder.Print(" USING ")
+	m.source.Render(builder)
+	builder.Print(" ON ")
+	builder.RenderConditions(m.conditions)
+
+	// when_clause [...]
+	for _, clause := range m.clauses {
+		if clause.isMatched {
+			builder.Print(" WHEN MATCHED")
+		} else {
+			builder.Print(" WHEN NOT MATCHED")
+		}
+		if conditions := withoutNoops(clause.conditions); len(conditions) > 0 {
+			builder.Print(" AND ")
+			builder.RenderConditions(conditions)
+		}
+		builder.Print(" THEN ")
+		switch clause.action {
+		case mergeActionUpdate:
+			builder.Print("UPDATE SET ")
+			builder.RenderSetPredicates(clause.setPredicates)
+		case mergeActionDelete:
+			builder.Print("DELETE")
+		case mergeActionInsert:
+			builder.Print("INSERT ")
+			renderColumnsAndValues(builder, clause.columns, [][]interface{}{clause.values})
+		default:
+			builder.Print("DO NOTHING")
+		}
+	}
+}
+
+// renderUpsert renders INSERT INTO target (columns) SELECT values FROM source
+// WHERE conditions ON CONFLICT (target columns of the ON conditions) DO
+// UPDATE SET ... | DO NOTHING
+func (m *merge) renderUpsert(
+	builder *Builder,
+) {
+	dialect := builder.getDialect()
+	statement := "INSERT ... ON CONFLICT"
+	if dialect.upsertStyle == upsertStyleOnDuplicateKey {
+		statement = "INSERT ... ON DUPLICATE KEY UPDATE"
+	}
+	unsupported := func(clause string) {
+		builder.errors = append(builder.errors,
+			&UnsupportedClauseError{Statement: statement, Clause: clause})
+	}
+
+	var matched, notMatched *mergeClause
+	for index := range m.clauses {
+		clause := &m.clauses[index]
+		switch {
+		case clause.isMatched && matched != nil:
+			unsupported("more than one WHEN MATCHED clause")
+		case clause.isMatched && clause.action == mergeActionDelete:
+			unsupported("WHEN MATCHED THEN DELETE")
+		case clause.isMatched:
+			matched = clause
+		case notMatched != nil:
+			unsupported("more than one WHEN NOT MATCHED clause")
+		case clause.action != mergeActionInsert:
+			unsupported("WHEN NOT MATCHED THEN DO NOTHING")
+		default:
+			notMatched = clause
+		}
+	}
+	if notMatched == nil {
+		unsupported("MERGE without WHEN NOT MATCHED THEN INSERT")
+		notMatched = &mergeClause{action: mergeActionInsert}
+	}
+	isUpdate := matched != nil && matched.action == mergeActionUpdate
+
+	// INSERT INTO table_name (column_name [, ...])
+	if dialect.upsertStyle == upsertStyleOnDuplicateKey && !isUpdate {
+		builder.Print("INSERT IGNORE INTO ")
+	} else {
+		builder.Print("INSERT INTO ")
+	}
+	m.table.Render(builder)
+	builder.Print(" ")
+	builder.RenderFieldArray(notMatched.columns)
+
+	// SELECT values FROM source WHERE conditions, the WHERE clause is always
+	// rendered since SQLite cannot parse ON CONFLICT after a FROM clause
+	builder.Print(" SELECT ")
+	for index, value := range notMatched.values {
+		renderValue(builder, value)
+		if index != len(notMatched.values)-1 {
+			builder.Print(", ")
+		}
+	}
+	builder.Print(" FROM ")
+	m.source.Render(builder)
+	builder.Print(" WHERE ")
+	builder.RenderConditions(notMatched.conditions)
+
+	// the ON conditions that are not conflict columns would be ignored by the
+	// upsert, which would then update rows that the MERGE does not match
+	conflictColumns, conditions := m.conflictColumns()
+	for range conditions {
+		unsupported("an ON condition that does not compare a column of the target for equality")
+	}
+	if len(conflictColumns) == 0 {
+		unsupported("an ON condition without a column of the target")
+	}
+
+	switch dialect.upsertStyle {
+	case upsertStyleOnConflict:
+		builder.Print(" ON CONFLICT ")
+		builder.RenderFieldArray(conflictColumns)
+		if !isUpdate {
+			builder.Print(" DO NOTHING")
+			return
+		}
+		builder.Print(" DO UPDATE SET ")
+		builder.RenderSetPredicates(m.excludedSetPredicates(builder, statement, matched, notMatched))
+		if conditions := withoutNoops(matched.conditions); len(conditions) > 0 {
+			builder.Print(" WHERE ")
+			builder.RenderConditions(conditions)
+		}
+	case upsertStyleOnDuplicateKey:
+		if !isUpdate {
+			return
+		}
+		if len(withoutNoops(matched.conditions)) > 0 {
+			unsupported("WHEN MATCHED AND ...")
+		}
+		builder.Print(" ON DUPLICATE KEY UPDATE ")
+		builder.RenderSetPredicates(m.excludedSetPredicates(builder, statement, matched, notMatched))
+	}
+}
+
+// conflictColumns returns the columns of the target that are compared for
+// equality by the ON conditions, and the conditions that do not compare a
+// column of the target for equality
+func (m *merge) conflictColumns() ([]Field, []Expression) {
+	var columns []Field
+	var others []Expression
+	for _, condition := range conjuncts(m.conditions) {
+		if column := m.conflictColumn(condition); column != nil {
+			columns = append(columns, column)
+		} else if !isNoop(condition) {
+			others = append(others, condition)
+		}
+	}
+	return columns, others
+}
+
+// conflictColumn returns the column of the target that condition compares
+// for equality, e.g. target.id = source.id
+func (m *merge) conflictColumn(condition Expression) Field {
+	impl, ok := condition.getOriginal().(*expressionImpl)
+	if !ok || impl.expressionType != ExpressionTypeBinary || impl.operator != OperatorEq {
+		return nil
+	}
+	for _, operand := range impl.expressions {
+		if field, ok := operand.(Field); ok &&
+			selectableNameOf(operand) == m.table.GetUnqualifiedName() {
+			return field
+		}
+	}
+	return nil
+}
+
+// excludedSetPredicates replaces the fields of the source that are assigned
+// by the update by the excluded values of the columns they are inserted into
+func (m *merge) excludedSetPredicates(
+	builder *Builder, statement string, matched, notMatched *mergeClause,
+) []setPredicate {
+	sourceName := ""
+	switch source := m.source.(type) {
+	case View:
+		sourceName = source.GetUnqualifiedName()
+	case *selection:
+		sourceName = source.GetAlias().String
+	}
+	var predicates []setPredicate
+	for _, predicate := range matched.setPredicates {
+		expression, ok := predicate.value.(Expression)
+		if !ok || sourceName == "" || selectableNameOf(expression) != sourceName {
+			predicates = append(predicates, predicate)
+			continue
+		}
+		column := notMatched.insertedColumn(expression)
+		if column == nil {
+			builder.errors = append(builder.errors, &UnsupportedClauseError{
+				Statement: statement,
+				Clause:    "an update to a value of the source that is not inserted",
+			})
+			predicates = append(predicates, predicate)
+			continue
+		}
+		predicates = append(predicates, setPredicate{
+			field: predicate.field,
+			value: Excluded(column),
+		})
+	}
+	return predicates
+}
+
+// insertedColumn returns the column that field is inserted into. The fields
+// are compared by name since the source can be referenced by different
+// instances of the same table, e.g. a table and its alias.
+func (clause *mergeClause) insertedColumn(field Expression) Field {
+	for index, value := range clause.values {
+		expression, ok := value.(Expression)
+		if ok && index < len(clause.columns) && isSameColumn(expression, field) {
+			return clause.columns[index]
+		}
+	}
+	return nil
+}
+
+// isSameColumn reports whether both expressions are the same column of the
+// same selectable
+func isSameColumn(lhs, rhs Expression) bool {
+	lhsField, ok := lhs.getOriginal().(Field)
+	if !ok {
+		return false
+	}
+	rhsField, ok := rhs.getOriginal().(Field)
+	if !ok {
+		return false
+	}
+	return lhsField.GetName() == rhsField.GetName() &&
+		selectableNameOf(lhs) == selectableNameOf(rhs)
+}
+
+// selectableNameOf returns the name that qualifies expr when it is a field
+func selectableNameOf(expr Expression) string {
+	if field, ok := expr.getOriginal().(interface{ getSelectableName() string }); ok {
+		return field.getSelectableName()
+	}
+	return ""
+}
+
+// conjuncts returns the conditions that are combined with AND by conditions
+func conjuncts(conditions []Expression) []Expression {
+	var results []Expression
+	for _, condition := range conditions {
+		impl, ok := condition.getOriginal().(*expressionImpl)
+		if ok && impl.operator == OperatorAnd &&
+			(impl.expressionType == ExpressionTypeBinary || impl.expressionType == ExpressionTypeMultigrade) {
+			results = append(results, conjuncts(impl.expressions)...)
+		} else {
+			results = append(results, condition)
+		}
+	}
+	return results
+}
@@ -0,0 +1,92 @@
+package gooq
+
+import "testing"
+
+var mergeSource = Table2.As("src").(*testTable)
+
+var mergeTestCases = []TestCase{
+	{
+		Constructed: MergeInto(Table1).Using(mergeSource).On(Table1.ID.Eq(mergeSource.ID)).
+			WhenMatched().ThenUpdate().Set(Table1.Column1, mergeSource.Column1).Set(Table1.Column3, 1).
+			WhenNotMatched().ThenInsert(Table1.ID, Table1.Column1).Values(mergeSource.ID, mergeSource.Column1),
+		ExpectedStmt: `MERGE INTO public.table1 USING public.table2 AS src ON table1.id = src.id WHEN MATCHED THEN UPDATE SET column1 = src.column1, column3 = $1 WHEN NOT MATCHED THEN INSERT (id, column1) VALUES (src.id, src.column1)`,
+	},
+	{
+		Constructed: MergeInto(aliasedTable1).Using(Select(Table2.ID, Table2.BoolColumn).From(Table2).As("src")).
+			On(aliasedTable1.ID.Eq(NewUUIDField(NewTable("", "src"), "id"))).
+			WhenMatched(NewBoolField(NewTable("", "src"), "bool_column").IsEq(false)).ThenDelete().
+			WhenMatched().ThenDoNothing().
+			WhenNotMatched(Noop()).ThenDoNothing(),
+		ExpectedStmt: `MERGE INTO public.table1 AS t USING (SELECT table2.id, table2.bool_column FROM public.table2) AS src ON t.id = src.id WHEN MATCHED AND src.bool_column = $1 THEN DELETE WHEN MATCHED THEN DO NOTHING WHEN NOT MATCHED THEN DO NOTHING`,
+	},
+	{
+		Constructed: MergeInto(Table1).Using(mergeSource).On(Table1.ID.Eq(mergeSource.ID), Table1.Column2.Eq(mergeSource.Column2)).
+			WhenMatched(Table1.Column3.Lt(Int64(10))).ThenUpdate().Set(Table1.Column1, mergeSource.Column1).Set(Table1.Column3, Table1.Column3.Add(Int64(1))).
+			WhenNotMatched().ThenInsert(Table1.ID, Table1.Column2, Table1.Column1).Values(mergeSource.ID, mergeSource.Column2, mergeSource.Column1).
+			Upsert(),
+		ExpectedStmt: `INSERT INTO public.table1 (id, column2, column1) SELECT src.id, src.column2, src.column1 FROM public.table2 AS src WHERE TRUE ON CONFLICT (id, column2) DO UPDATE SET column1 = excluded.column1, column3 = table1.column3 + $1 WHERE table1.column3 < $2`,
+	},
+	{
+		Constructed: MergeInto(Table1).Using(mergeSource).On(And(Table1.ID.Eq(mergeSource.ID))).
+			WhenNotMatched(mergeSource.BoolColumn.IsEq(true)).ThenInsert(Table1.ID).Values(mergeSource.ID).
+			Upsert(),
+		ExpectedStmt: `INSERT INTO public.table1 (id) SELECT src.id FROM public.table2 AS src WHERE src.bool_column = $1 ON CONFLICT (id) DO NOTHING`,
+	},
+	{
+		Constructed: MergeInto(Table1).Using(mergeSource).On(Table1.ID.Eq(mergeSource.ID)).
+			WhenMatched().ThenUpdate().Set(Table1.Column2, mergeSource.Column2).
+			WhenNotMatched().ThenInsert(Table1.ID).Values(mergeSource.ID).
+			Upsert(),
+		ExpectedStmt: `INSERT INTO public.table1 (id) SELECT src.id FROM public.table2 AS src WHERE TRUE ON CONFLICT (id) DO UPDATE SET column2 = src.column2`,
+		Errors: []error{&UnsupportedClauseError{
+			Statement: "INSERT ... ON CONFLICT",
+			Clause:    "an update to a value of the source that is not inserted",
+		}},
+	},
+	{
+		Constructed: MergeInto(Table1).Using(Select(Table2.ID, Table2.Column1).From(Table2).As("src")).
+			On(Table1.ID.Eq(NewUUIDField(NewTable("", "src"), "id"))).
+			WhenMatched().ThenUpdate().Set(Table1.Column1, NewStringField(NewTable("", "src"), "column1")).
+			WhenNotMatched().ThenInsert(Table1.ID, Table1.Column1).
+			Values(NewUUIDField(NewTable("", "src"), "id"), NewStringField(NewTable("", "src"), "column1")).
+			Upsert(),
+		ExpectedStmt: `INSERT INTO public.table1 (id, column1) SELECT src.id, src.column1 FROM (SELECT table2.id, table2.column1 FROM public.table2) AS src WHERE TRUE ON CONFLICT (id) DO UPDATE SET column1 = excluded.column1`,
+	},
+	{
+		Constructed: MergeInto(Table1).Using(mergeSource).On(Table1.Column3.Lt(mergeSource.Column3)).
+			WhenMatched().ThenDelete().
+			Upsert(),
+		ExpectedStmt: `INSERT INTO public.table1 () SELECT  FROM public.table2 AS src WHERE TRUE ON CONFLICT () DO NOTHING`,
+		Errors: []error{
+			&UnsupportedClauseError{Statement: "INSERT ... ON CONFLICT", Clause: "WHEN MATCHED THEN DELETE"},
+			&UnsupportedClauseError{Statement: "INSERT ... ON CONFLICT", Clause: "MERGE without WHEN NOT MATCHED THEN INSERT"},
+			&UnsupportedClauseError{Statement: "INSERT ... ON CONFLICT", Clause: "an ON condition that does not compare a column of the target for equality"},
+			&UnsupportedClauseError{Statement: "INSERT ... ON CONFLICT", Clause: "an ON condition without a column of the target"},
+		},
+	},
+	{
+		Constructed: MergeInto(Table1).Using(mergeSource).On(Table1.ID.Eq(mergeSource.ID), Table1.Column3.Gt(mergeSource.Column3)).
+			WhenMatched().ThenUpdate().Set(Table1.Column1, mergeSource.Column1).
+			WhenNotMatched().ThenInsert(Table1.ID, Table1.Column1).Values(mergeSource.ID, mergeSource.Column1).
+			Upsert(),
+		ExpectedStmt: `INSERT INTO public.table1 (id, column1) SELECT src.id, src.column1 FROM public.table2 AS src WHERE TRUE ON CONFLICT (id) DO UPDATE SET column1 = excluded.column1`,
+		Errors: []error{&UnsupportedClauseError{
+			Statement: "INSERT ... ON CONFLICT",
+			Clause:    "an ON condition that does not compare a column of the target for equality",
+		}},
+	},
+	{
+		Constructed: MergeInto(Table1).Using(mergeSource).On(Noop()).
+			WhenNotMatched().ThenInsert(Table1.ID).Values(mergeSource.ID).
+			Upsert(),
+		ExpectedStmt: `INSERT INTO public.table1 (id) SELECT src.id FROM public.table2 AS src WHERE TRUE ON CONFLICT () DO NOTHING`,
+		Errors: []error{&UnsupportedClauseError{
+			Statement: "INSERT ... ON CONFLICT",
+			Clause:    "an ON condition without a column of the target",
+		}},
+	},
+}
+
+func TestMerge(t *testing.T) {
+	runTestCases(t, mergeTestCases)
+}
@@ -38,6 +38,14 @@ var (
 	OperatorDiv  = Operator("/")
 	OperatorSqrt = Operator("|/")
 
+	// 9.4. String Functions and Operators
+	// https://www.postgresql.org/docs/11/functions-string.html
+	OperatorConcat = Operator("||")
+
+	// 9.9.3. AT TIME ZONE
+	// https://www.postgresql.org/docs/11/functions-datetime.html
+	OperatorAtTimeZone = Operator("AT TIME ZONE")
+
 	// Table 9.13. Bit String Operators
 	// https://www.postgresql.org/docs/11/functions-bitstring.html
 	// [Good First Issue][Help Wanted] TODO: implement remaining
@@ -52,6 +60,26 @@ var (
 	// https://www.postgresql.org/docs/11/functions-comparisons.html
 	OperatorIn    = Operator("IN")
 	OperatorNotIn = Operator("NOT IN")
+
+	// Table 9.48. Array Operators
+	// https://www.postgresql.org/docs/11/functions-array.html
+	OperatorContains    = Operator("@>")
+	OperatorContainedBy = Operator("<@")
+	OperatorOverlaps    = Operator("&&")
+
+	// Table 9.44. json and jsonb Operators
+	// Table 9.45. Additional jsonb Operators
+	// https://www.postgresql.org/docs/12/functions-json.html
+	OperatorJsonGet         = Operator("->")
+	OperatorJsonGetText     = Operator("->>")
+	OperatorJsonGetPath     = Operator("#>")
+	OperatorJsonGetPathText = Operator("#>>")
+	OperatorJsonHasKey      = Operator("?")
+	OperatorJsonHasAnyKey   = Operator("?|")
+	OperatorJsonHasAllKeys  = Operator("?&")
+	OperatorJsonDeletePath  = Operator("#-")
+	OperatorJsonPathExists  = Operator("@?")
+	OperatorJsonPathMatch   = Operator("@@")
 )
 
 func (op Operator) String() string {
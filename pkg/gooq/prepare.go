@@ -0,0 +1,259 @@
+package gooq
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/reflectx"
+)
+
+// Param is a placeholder for a value that is bound by name every time a
+// prepared statement is executed, see Prepare. A statement with parameters
+// cannot be executed directly.
+func Param(name string) Expression {
+	return newLiteralExpression(namedParam{name})
+}
+
+func BoolParam(name string) BoolExpression {
+	expr := &boolExpressionImpl{}
+	expr.expressionImpl.initLiteralExpression(expr, namedParam{name})
+	return expr
+}
+
+func DateTimeParam(name string) DateTimeExpression {
+	expr := &dateTimeExpressionImpl{}
+	expr.expressionImpl.initLiteralExpression(expr, namedParam{name})
+	return expr
+}
+
+func NumericParam(name string) NumericExpression {
+	expr := &numericExpressionImpl{}
+	expr.expressionImpl.initLiteralExpression(expr, namedParam{name})
+	return expr
+}
+
+func StringParam(name string) StringExpression {
+	expr := &stringExpressionImpl{}
+	expr.expressionImpl.initLiteralExpression(expr, namedParam{name})
+	return expr
+}
+
+func UUIDParam(name string) UUIDExpression {
+	expr := &uuidExpressionImpl{}
+	expr.expressionImpl.initLiteralExpression(expr, namedParam{name})
+	return expr
+}
+
+// PreparedStatement is a statement that is built and prepared once and can
+// be executed repeatedly with different bindings for its parameters. The
+// bindings are either a map[string]interface{} or a struct (or a pointer to
+// one) whose fields are matched like sqlx does, i.e. by db tag or by lower
+// case field name. It is safe for concurrent use.
+type PreparedStatement struct {
+	stmt      *sqlx.Stmt
+	query     string
+	arguments []interface{}
+	hooks     []QueryHook
+}
+
+// Prepare builds stmt for Postgres and prepares it on db
+func Prepare(
+	ctx context.Context, db DBInterface, stmt Buildable,
+) (*PreparedStatement, error) {
+	return PrepareWithDialect(ctx, Postgres, db, stmt)
+}
+
+// PrepareWithDialect builds stmt for the given dialect and prepares it on db.
+// The hooks of a db returned by NewHookedDB, as well as the hooks attached to
+// the context of each execution, run around every execution.
+func PrepareWithDialect(
+	ctx context.Context, dl Dialect, db DBInterface, stmt Buildable,
+) (*PreparedStatement, error) {
+	builder := stmt.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	db, hooks := unwrapQueryHooks(db)
+	prepared, err := prepareContext(ctx, db, builder.String())
+	if err != nil {
+		return nil, err
+	}
+	return &PreparedStatement{
+		stmt:      prepared,
+		query:     builder.String(),
+		arguments: builder.arguments,
+		hooks:     hooks,
+	}, nil
+}
+
+// unwrapQueryHooks returns the handle wrapped by NewHookedDB, including the
+// hooked transactions of RunInTx, and its hooks
+func unwrapQueryHooks(
+	db DBInterface,
+) (DBInterface, []QueryHook) {
+	switch hooked := db.(type) {
+	case *transaction:
+		return unwrapQueryHooks(hooked.TxInterface)
+	case *hookedDB:
+		return hooked.DBInterface, hooked.hooks
+	case *hookedTx:
+		return hooked.DBInterface, hooked.hooks
+	}
+	return db, nil
+}
+
+func prepareContext(
+	ctx context.Context, db DBInterface, query string,
+) (*sqlx.Stmt, error) {
+	// *sqlx.DB and *sqlx.Tx return statements with their own mapper
+	if preparer, ok := db.(interface {
+		PreparexContext(context.Context, string) (*sqlx.Stmt, error)
+	}); ok {
+		return preparer.PreparexContext(ctx, query)
+	}
+	stmt, err := db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlx.Stmt{Stmt: stmt, Mapper: reflectx.NewMapperFunc("db", sqlx.NameMapper)}, nil
+}
+
+// SQL returns the prepared query
+func (p *PreparedStatement) SQL() string {
+	return p.query
+}
+
+// Params returns the names of the parameters in order of appearance
+func (p *PreparedStatement) Params() []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, argument := range p.arguments {
+		if param, ok := argument.(namedParam); ok && !seen[param.name] {
+			seen[param.name] = true
+			names = append(names, param.name)
+		}
+	}
+	return names
+}
+
+func (p *PreparedStatement) Exec(
+	ctx context.Context, bindings interface{},
+) (result sql.Result, err error) {
+	arguments, err := p.bind(bindings)
+	if err != nil {
+		return nil, err
+	}
+	p.run(ctx, arguments, func(ctx context.Context, event *QueryEvent) {
+		result, err = p.stmt.ExecContext(ctx, arguments...)
+		event.setResult(result, err)
+	})
+	return
+}
+
+func (p *PreparedStatement) Fetch(
+	ctx context.Context, bindings interface{},
+) (rows *sqlx.Rows, err error) {
+	arguments, err := p.bind(bindings)
+	if err != nil {
+		return nil, err
+	}
+	p.run(ctx, arguments, func(ctx context.Context, event *QueryEvent) {
+		rows, err = p.stmt.QueryxContext(ctx, arguments...)
+		event.Err = err
+	})
+	return
+}
+
+func (p *PreparedStatement) FetchRow(
+	ctx context.Context, bindings interface{},
+) (row *sqlx.Row, err error) {
+	arguments, err := p.bind(bindings)
+	if err != nil {
+		return nil, err
+	}
+	p.run(ctx, arguments, func(ctx context.Context, event *QueryEvent) {
+		row = p.stmt.QueryRowxContext(ctx, arguments...)
+		event.Err = row.Err()
+	})
+	return
+}
+
+func (p *PreparedStatement) Close() error {
+	return p.stmt.Close()
+}
+
+func (p *PreparedStatement) run(
+	ctx context.Context, arguments []interface{},
+	fn func(ctx context.Context, event *QueryEvent),
+) {
+	contextHooks := queryHooksFromContext(ctx)
+	hooks := make([]QueryHook, 0, len(contextHooks)+len(p.hooks))
+	hooks = append(append(hooks, contextHooks...), p.hooks...)
+	runQueryHooks(ctx, hooks, p.query, arguments, fn)
+}
+
+// bind replaces the parameters captured when the statement was built with
+// their values in bindings
+func (p *PreparedStatement) bind(
+	bindings interface{},
+) ([]interface{}, error) {
+	lookup, err := p.newBindingLookup(bindings)
+	if err != nil {
+		return nil, err
+	}
+	arguments := make([]interface{}, len(p.arguments))
+	for index, argument := range p.arguments {
+		param, ok := argument.(namedParam)
+		if !ok {
+			arguments[index] = argument
+			continue
+		}
+		value, ok := lookup(param.name)
+		if !ok {
+			return nil, &UnboundParamError{Name: param.name}
+		}
+		arguments[index] = value
+	}
+	return arguments, nil
+}
+
+func (p *PreparedStatement) newBindingLookup(
+	bindings interface{},
+) (func(name string) (interface{}, bool), error) {
+	switch bindings := bindings.(type) {
+	case nil:
+		return func(string) (interface{}, bool) { return nil, false }, nil
+	case map[string]interface{}:
+		return func(name string) (interface{}, bool) {
+			value, ok := bindings[name]
+			return value, ok
+		}, nil
+	}
+	value := reflect.Indirect(reflect.ValueOf(bindings))
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf(
+			"bindings must be a map[string]interface{} or a struct, got %T", bindings)
+	}
+	return func(name string) (interface{}, bool) {
+		field := p.stmt.Mapper.FieldByName(value, name)
+		if !field.IsValid() {
+			return nil, false
+		}
+		return field.Interface(), true
+	}, nil
+}
+
+// namedParam is the argument rendered for a Param until it is bound
+type namedParam struct {
+	name string
+}
+
+// Value reports the parameter as unbound when a statement with parameters is
+// executed without being prepared
+func (param namedParam) Value() (driver.Value, error) {
+	return nil, &UnboundParamError{Name: param.name}
+}
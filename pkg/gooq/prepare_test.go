@@ -0,0 +1,125 @@
+package gooq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParams(t *testing.T) {
+	runTestCases(t, []TestCase{
+		{
+			Constructed:  Select().From(Table1).Where(Table1.Column1.Eq(StringParam("name")), Table1.Column3.Gt(Int64(3))),
+			ExpectedStmt: `SELECT * FROM public.table1 WHERE table1.column1 = $1 AND table1.column3 > $2`,
+			Arguments:    []interface{}{namedParam{"name"}, int64(3)},
+		},
+		{
+			Constructed:  Update(Table1).Set(Table1.TimeColumn, DateTimeParam("now")).Where(Table1.ID.Eq(UUIDParam("id"))),
+			ExpectedStmt: `UPDATE public.table1 SET time_column = $1 WHERE table1.id = $2`,
+			Arguments:    []interface{}{namedParam{"now"}, namedParam{"id"}},
+		},
+		{
+			Constructed:  Select(Coalesce(Table1.Column3, Param("fallback"))).From(Table1).Where(BoolParam("enabled")),
+			ExpectedStmt: `SELECT COALESCE(table1.column3, $1) FROM public.table1 WHERE $2`,
+			Arguments:    []interface{}{namedParam{"fallback"}, namedParam{"enabled"}},
+		},
+	})
+
+	stmt, err := RenderInline(Select().From(Table1).Where(Table1.Column3.Gt(NumericParam("min"))))
+	require.NoError(t, err)
+	require.Equal(t, `SELECT * FROM public.table1 WHERE table1.column3 > :'min'`, stmt)
+}
+
+func TestPrepare(t *testing.T) {
+	ctx := context.Background()
+	selectStmt := Select().From(Table1).
+		Where(Table1.Column1.Eq(StringParam("name")), Table1.Column3.Gt(Int64(3)), Table1.Column2.Eq(StringParam("name")))
+	selectQuery := `SELECT * FROM public.table1 WHERE table1.column1 = $1 AND table1.column3 > $2 AND table1.column2 = $3`
+
+	t.Run("bindings", func(t *testing.T) {
+		db, connector := newRecordingDB(nil)
+		prepared, err := Prepare(ctx, db, selectStmt)
+		require.NoError(t, err)
+		require.Equal(t, selectQuery, prepared.SQL())
+		require.Equal(t, []string{"name"}, prepared.Params())
+
+		rows, err := prepared.Fetch(ctx, map[string]interface{}{"name": "foo"})
+		require.NoError(t, err)
+		require.NoError(t, rows.Close())
+		type binding struct {
+			Name string `db:"name"`
+		}
+		rows, err = prepared.Fetch(ctx, &binding{Name: "bar"})
+		require.NoError(t, err)
+		require.NoError(t, rows.Close())
+		require.NoError(t, prepared.Close())
+
+		require.Equal(t, []string{"PREPARE " + selectQuery, selectQuery, selectQuery}, connector.log)
+		require.Equal(t, [][]interface{}{
+			{"foo", int64(3), "foo"},
+			{"bar", int64(3), "bar"},
+		}, connector.arguments)
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		db, connector := newRecordingDB(nil)
+		prepared, err := Prepare(ctx, db, InsertInto(Table1).
+			Set(Table1.Column1, Param("column1")).Set(Table1.Column3, Param("column3")))
+		require.NoError(t, err)
+		type binding struct {
+			Column1 string
+			Column3 int64
+		}
+		result, err := prepared.Exec(ctx, binding{Column1: "foo", Column3: 7})
+		require.NoError(t, err)
+		rowsAffected, err := result.RowsAffected()
+		require.NoError(t, err)
+		require.Equal(t, int64(1), rowsAffected)
+		require.Equal(t, [][]interface{}{{"foo", int64(7)}}, connector.arguments)
+	})
+
+	t.Run("unbound parameters", func(t *testing.T) {
+		db, connector := newRecordingDB(nil)
+		prepared, err := Prepare(ctx, db, selectStmt)
+		require.NoError(t, err)
+		_, err = prepared.Fetch(ctx, map[string]interface{}{"other": "foo"})
+		require.Equal(t, &UnboundParamError{Name: "name"}, err)
+		_, err = prepared.Fetch(ctx, nil)
+		require.Equal(t, &UnboundParamError{Name: "name"}, err)
+		_, err = prepared.Fetch(ctx, 42)
+		require.EqualError(t, err, "bindings must be a map[string]interface{} or a struct, got int")
+		_, err = prepared.FetchRow(ctx, nil)
+		require.Equal(t, &UnboundParamError{Name: "name"}, err)
+		require.Equal(t, []string{"PREPARE " + selectQuery}, connector.log)
+
+		_, err = selectStmt.Fetch(Postgres, db)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `parameter "name" is not bound`)
+	})
+
+	t.Run("build errors", func(t *testing.T) {
+		db, connector := newRecordingDB(nil)
+		_, err := Prepare(ctx, db, Select().From(Table1).Seek("foo"))
+		require.Equal(t, &SeekArityError{Values: 1, Ordering: 0}, err)
+		require.Empty(t, connector.log)
+	})
+
+	t.Run("hooks", func(t *testing.T) {
+		var log []string
+		dbHook := &recordingHook{name: "db", log: &log}
+		ctxHook := &recordingHook{name: "ctx", log: &log}
+		sqlxDB, _ := newRecordingDB(nil)
+		prepared, err := PrepareWithDialect(ctx, Sqlite, NewHookedDB(sqlxDB, dbHook), selectStmt)
+		require.NoError(t, err)
+		require.Equal(t, `SELECT * FROM public.table1 WHERE table1.column1 = ? AND table1.column3 > ? AND table1.column2 = ?`,
+			prepared.SQL())
+		row, err := prepared.FetchRow(WithQueryHooks(ctx, ctxHook), map[string]interface{}{"name": "foo"})
+		require.NoError(t, err)
+		require.NoError(t, row.Err())
+		require.Equal(t, []string{"before ctx", "before db", "after db", "after ctx"}, log)
+		require.Equal(t, []interface{}{"foo", int64(3), "foo"}, dbHook.events[0].Args)
+		require.True(t, dbHook.events[0].Duration < time.Minute)
+	})
+}
@@ -0,0 +1,82 @@
+package gooq
+
+import (
+	"context"
+	"database/sql"
+)
+
+type RefreshMaterializedViewStep interface {
+	RefreshMaterializedViewFinalStep
+	Concurrently() RefreshMaterializedViewFinalStep
+}
+
+type RefreshMaterializedViewFinalStep interface {
+	Buildable
+	Executable
+	Renderable
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Implementation
+///////////////////////////////////////////////////////////////////////////////
+
+// https://www.postgresql.org/docs/current/sql-refreshmaterializedview.html
+
+type refresh struct {
+	view         MaterializedView
+	concurrently bool
+}
+
+func RefreshMaterializedView(view MaterializedView) RefreshMaterializedViewStep {
+	return &refresh{view: view}
+}
+
+// Concurrently refreshes the view without locking out concurrent selects,
+// which requires a unique index on the view
+func (r *refresh) Concurrently() RefreshMaterializedViewFinalStep {
+	c := *r
+	c.concurrently = true
+	return &c
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Executable
+///////////////////////////////////////////////////////////////////////////////
+
+func (r *refresh) Exec(dl Dialect, db DBInterface) (sql.Result, error) {
+	builder := r.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return db.Exec(builder.String(), builder.arguments...)
+}
+
+func (r *refresh) ExecWithContext(
+	ctx context.Context, dl Dialect, db DBInterface) (sql.Result, error) {
+	builder := r.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return withContextHooks(ctx, db).ExecContext(ctx, builder.String(), builder.arguments...)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Renderable
+///////////////////////////////////////////////////////////////////////////////
+
+func (r *refresh) Build(dl Dialect) *Builder {
+	builder := NewBuilder(dl)
+	r.Render(builder)
+	return builder
+}
+
+func (r *refresh) Render(
+	builder *Builder,
+) {
+	builder.requireFeature(DialectFeatureMaterializedView)
+	builder.Print("REFRESH MATERIALIZED VIEW ")
+	if r.concurrently {
+		builder.Print("CONCURRENTLY ")
+	}
+	builder.Print(builder.quoteTableName(r.view))
+}
@@ -0,0 +1,57 @@
+package gooq
+
+// ForeignKey is a foreign key of Table whose Columns reference the
+// ReferencedColumns of ReferencedTable. The columns are the fields of the
+// generated tables, which are expressions as well.
+type ForeignKey struct {
+	Name              string
+	Table             View
+	Columns           []Field
+	ReferencedTable   View
+	ReferencedColumns []Field
+}
+
+// Relationship is a relation together with the conditions that join it to
+// the relations that are already selected from, e.g.
+//
+//	Select().From(Person).JoinRelationship(Person.JoinSpecies())
+//
+// renders SELECT * FROM public.person JOIN public.species ON
+// person.species_id = species.id
+type Relationship struct {
+	Target     Selectable
+	Conditions []Expression
+}
+
+// Join returns the relationship that joins the referenced table to the rows
+// of the table, i.e. from a row to the row that it references
+func (fk ForeignKey) Join() Relationship {
+	return Relationship{
+		Target:     fk.ReferencedTable,
+		Conditions: fk.conditions(),
+	}
+}
+
+// ReverseJoin returns the relationship that joins the table to the rows of
+// the referenced table, i.e. from a row to the rows that reference it
+func (fk ForeignKey) ReverseJoin() Relationship {
+	return Relationship{
+		Target:     fk.Table,
+		Conditions: fk.conditions(),
+	}
+}
+
+// conditions compares the columns with the referenced columns pairwise. The
+// comparison is not typed as the referencing column can be of a different
+// type than the referenced column, e.g. integer and bigint.
+func (fk ForeignKey) conditions() []Expression {
+	var conditions []Expression
+	for index, column := range fk.Columns {
+		if index >= len(fk.ReferencedColumns) {
+			break
+		}
+		conditions = append(conditions, newBinaryBooleanExpressionImpl(OperatorEq,
+			column.(Expression), fk.ReferencedColumns[index].(Expression)))
+	}
+	return conditions
+}
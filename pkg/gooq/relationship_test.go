@@ -0,0 +1,52 @@
+package gooq
+
+import (
+	"testing"
+)
+
+var table2Table1ForeignKey = ForeignKey{
+	Name:              "table2_column3_fkey",
+	Table:             Table2,
+	Columns:           []Field{Table2.Column3},
+	ReferencedTable:   Table1,
+	ReferencedColumns: []Field{Table1.Column3},
+}
+
+var table2Table1CompositeForeignKey = ForeignKey{
+	Name:              "table2_column1_column2_fkey",
+	Table:             Table2,
+	Columns:           []Field{Table2.Column1, Table2.Column2},
+	ReferencedTable:   Table1,
+	ReferencedColumns: []Field{Table1.Column1, Table1.Column2},
+}
+
+var relationshipTestCases = []TestCase{
+	{
+		Constructed:  Select().From(Table2).JoinRelationship(table2Table1ForeignKey.Join()),
+		ExpectedStmt: "SELECT * FROM public.table2 JOIN public.table1 ON table2.column3 = table1.column3",
+	},
+	{
+		Constructed:  Select().From(Table1).LeftOuterJoinRelationship(table2Table1ForeignKey.ReverseJoin()),
+		ExpectedStmt: "SELECT * FROM public.table1 LEFT OUTER JOIN public.table2 ON table2.column3 = table1.column3",
+	},
+	{
+		Constructed: Select().From(Table2).
+			JoinRelationship(table2Table1CompositeForeignKey.Join()).
+			Where(Table1.Column4.Gt(Int64(1))),
+		ExpectedStmt: "SELECT * FROM public.table2 JOIN public.table1 ON table2.column1 = table1.column1 AND table2.column2 = table1.column2 WHERE table1.column4 > $1",
+		Arguments:    []interface{}{int64(1)},
+	},
+	{
+		Constructed: Select().From(Table2).JoinRelationship(ForeignKey{
+			Table:             Table2,
+			Columns:           []Field{Table2.Column3},
+			ReferencedTable:   Table1.As("t"),
+			ReferencedColumns: []Field{Table1.As("t").(*testTable).Column3},
+		}.Join()),
+		ExpectedStmt: "SELECT * FROM public.table2 JOIN public.table1 AS t ON table2.column3 = t.column3",
+	},
+}
+
+func TestRelationships(t *testing.T) {
+	runTestCases(t, relationshipTestCases)
+}
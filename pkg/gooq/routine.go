@@ -0,0 +1,138 @@
+package gooq
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"gopkg.in/guregu/null.v3"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// Table Function
+///////////////////////////////////////////////////////////////////////////////
+
+// TableFunctionImpl is embedded by the types of set-returning functions so
+// that they can be selected from like a view, e.g. From(ListPeople(Int64(18)))
+// renders FROM public.list_people($1). Their fields are qualified by the name
+// of the function, which is the default alias of a function in FROM.
+type TableFunctionImpl struct {
+	name      string
+	schema    string
+	arguments []Expression
+	alias     null.String
+}
+
+func NewTableFunction(schema, name string, arguments ...Expression) *TableFunctionImpl {
+	t := &TableFunctionImpl{}
+	t.Initialize(schema, name, arguments...)
+	return t
+}
+
+func (t *TableFunctionImpl) Initialize(schema, name string, arguments ...Expression) {
+	t.schema = schema
+	t.name = name
+	t.arguments = arguments
+}
+
+func (t *TableFunctionImpl) As(alias string) *TableFunctionImpl {
+	return &TableFunctionImpl{
+		name:      t.name,
+		schema:    t.schema,
+		arguments: t.arguments,
+		alias:     null.StringFrom(alias),
+	}
+}
+
+func (t TableFunctionImpl) GetAlias() null.String {
+	return t.alias
+}
+
+func (t TableFunctionImpl) GetName() string {
+	return t.name
+}
+
+func (t TableFunctionImpl) GetQualifiedName() string {
+	if t.schema == "" {
+		return t.name
+	}
+	return fmt.Sprintf("%s.%s", t.schema, t.name)
+}
+
+func (t TableFunctionImpl) GetUnqualifiedName() string {
+	if t.alias.Valid {
+		return t.alias.String
+	}
+	return t.name
+}
+
+func (t TableFunctionImpl) GetSchema() string {
+	return t.schema
+}
+
+func (t *TableFunctionImpl) Render(
+	builder *Builder,
+) {
+	builder.requireFeature(DialectFeatureTableFunction)
+	builder.Printf("%s(", builder.quoteTableName(t))
+	builder.RenderExpressions(t.arguments)
+	builder.Print(")")
+	if t.alias.Valid {
+		builder.Printf(" AS %s", builder.QuoteIdentifier(t.alias.String))
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Call
+///////////////////////////////////////////////////////////////////////////////
+
+type CallFinalStep interface {
+	Buildable
+	Executable
+	Renderable
+}
+
+// https://www.postgresql.org/docs/current/sql-call.html
+
+type call struct {
+	procedure string
+	arguments []Expression
+}
+
+// Call invokes a procedure, the name is rendered as is like the name of
+// NewExpressionFunction, e.g. Call("public.archive_people", Int64(30))
+func Call(procedure string, arguments ...Expression) CallFinalStep {
+	return &call{procedure: procedure, arguments: arguments}
+}
+
+func (c *call) Exec(dl Dialect, db DBInterface) (sql.Result, error) {
+	builder := c.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return db.Exec(builder.String(), builder.arguments...)
+}
+
+func (c *call) ExecWithContext(
+	ctx context.Context, dl Dialect, db DBInterface) (sql.Result, error) {
+	builder := c.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return withContextHooks(ctx, db).ExecContext(ctx, builder.String(), builder.arguments...)
+}
+
+func (c *call) Build(dl Dialect) *Builder {
+	builder := NewBuilder(dl)
+	c.Render(builder)
+	return builder
+}
+
+func (c *call) Render(
+	builder *Builder,
+) {
+	builder.requireFeature(DialectFeatureCall)
+	builder.Printf("CALL %s(", c.procedure)
+	builder.RenderExpressions(c.arguments)
+	builder.Print(")")
+}
@@ -0,0 +1,72 @@
+package gooq
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testTableFunction struct {
+	TableFunctionImpl
+	ID   UUIDField
+	Name StringField
+}
+
+func newTestTableFunction(impl TableFunctionImpl) *testTableFunction {
+	instance := &testTableFunction{TableFunctionImpl: impl}
+	instance.ID = NewUUIDField(instance, "id")
+	instance.Name = NewStringField(instance, "name")
+	return instance
+}
+
+func listPeople(minAge NumericExpression) *testTableFunction {
+	return newTestTableFunction(*NewTableFunction("public", "list_people", minAge))
+}
+
+func (t *testTableFunction) As(alias string) *testTableFunction {
+	return newTestTableFunction(*t.TableFunctionImpl.As(alias))
+}
+
+var routineTestCases = []TestCase{
+	{
+		Constructed:  Select(listPeople(Int64(18)).Name).From(listPeople(Int64(18))),
+		ExpectedStmt: "SELECT list_people.name FROM public.list_people($1)",
+		Arguments:    []interface{}{int64(18)},
+	},
+	{
+		Constructed: Select(Table1.Column1, listPeople(Int64(18)).As("p").Name).
+			From(Table1).
+			Join(listPeople(Table1.Column3).As("p")).On(listPeople(Int64(18)).As("p").ID.Eq(Table1.ID)),
+		ExpectedStmt: "SELECT table1.column1, p.name FROM public.table1 JOIN public.list_people(table1.column3) AS p ON p.id = table1.id",
+	},
+	{
+		Constructed:  Call("public.archive_people", Int64(30), String("archived")),
+		ExpectedStmt: "CALL public.archive_people($1, $2)",
+		Arguments:    []interface{}{int64(30), "archived"},
+	},
+	{
+		Constructed:  Call("public.count_people", Int64(30), Null()),
+		ExpectedStmt: "CALL public.count_people($1, NULL)",
+		Arguments:    []interface{}{int64(30)},
+	},
+	{
+		Constructed:  Call("public.refresh_all"),
+		ExpectedStmt: "CALL public.refresh_all()",
+	},
+}
+
+func TestRoutines(t *testing.T) {
+	runTestCases(t, routineTestCases)
+}
+
+func TestRoutineDialects(t *testing.T) {
+	builder := Call("archive_people", Int64(30)).Build(Sqlite)
+	require.Equal(t, []error{&UnsupportedFeatureError{
+		Dialect: Sqlite, Feature: DialectFeatureCall,
+	}}, builder.Errors())
+
+	builder = Select(listPeople(Int64(18)).Name).From(listPeople(Int64(18))).Build(MySQL)
+	require.Equal(t, []error{&UnsupportedFeatureError{
+		Dialect: MySQL, Feature: DialectFeatureTableFunction,
+	}}, builder.Errors())
+}
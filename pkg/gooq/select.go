@@ -2,17 +2,34 @@ package gooq
 
 import (
 	"context"
-	"fmt"
 
 	"gopkg.in/guregu/null.v3"
 
 	"github.com/jmoiron/sqlx"
 )
 
+var (
+	compoundSeekError = &UnsupportedClauseError{
+		Statement: "a compound select",
+		Clause:    "seek",
+	}
+	compoundLockingError = &UnsupportedClauseError{
+		Statement: "a compound select",
+		Clause:    "a locking clause",
+	}
+)
+
+type setOperation struct {
+	operator SetOperator
+	operand  SelectFinalStep
+}
+
 type join struct {
 	target     Selectable
 	joinType   JoinType
+	isLateral  bool
 	conditions []Expression
+	using      []Field
 }
 
 type SelectWithStep interface {
@@ -36,16 +53,38 @@ type SelectJoinStep interface {
 	SelectWhereStep
 	Join(Selectable) SelectOnStep
 	LeftOuterJoin(Selectable) SelectOnStep
+	RightOuterJoin(Selectable) SelectOnStep
+	FullOuterJoin(Selectable) SelectOnStep
+	CrossJoin(Selectable) SelectJoinStep
+	NaturalJoin(Selectable) SelectJoinStep
+	// LATERAL joins are meant for subqueries (or set returning functions)
+	// that reference columns of the preceding FROM items
+	JoinLateral(Selectable) SelectOnStep
+	LeftJoinLateral(Selectable) SelectOnStep
+	CrossJoinLateral(Selectable) SelectJoinStep
+	// JoinRelationship joins the target of a relationship on its conditions,
+	// e.g. JoinRelationship(Person.JoinSpecies())
+	JoinRelationship(Relationship) SelectJoinStep
+	LeftOuterJoinRelationship(Relationship) SelectJoinStep
 }
 
 type SelectOnStep interface {
 	SelectWhereStep
 	On(...Expression) SelectJoinStep
+	Using(...Field) SelectJoinStep
 }
 
 type SelectWhereStep interface {
 	SelectGroupByStep
-	Where(conditions ...Expression) SelectGroupByStep
+	Where(conditions ...Expression) SelectConditionStep
+	WhereIf(cond bool, conditions ...Expression) SelectConditionStep
+}
+
+type SelectConditionStep interface {
+	SelectGroupByStep
+	AndWhere(conditions ...Expression) SelectConditionStep
+	OrWhere(conditions ...Expression) SelectConditionStep
+	WhereIf(cond bool, conditions ...Expression) SelectConditionStep
 }
 
 type SelectGroupByStep interface {
@@ -54,13 +93,19 @@ type SelectGroupByStep interface {
 }
 
 type SelectHavingStep interface {
+	SelectWindowStep
+	Having(conditions ...Expression) SelectWindowStep
+}
+
+type SelectWindowStep interface {
 	SelectOrderByStep
-	Having(conditions ...Expression) SelectOrderByStep
+	Window(name string, specification WindowSpecification) SelectWindowStep
 }
 
 type SelectOrderByStep interface {
 	SelectOffsetStep
 	OrderBy(...Expression) SelectOffsetStep
+	OrderByKeys(fields SortFields, keys ...string) SelectOffsetStep
 }
 
 type SelectOffsetStep interface {
@@ -76,10 +121,21 @@ type SelectLimitStep interface {
 
 type SelectFinalStep interface {
 	Selectable
+	Buildable
 	Fetchable
+	RowFetchable
 	As(alias string) Selectable
 	For(LockingType, LockingOption) SelectFinalStep
+	// Set operations combine this query with another one. OrderBy, Offset
+	// and Limit called on the returned step apply to the combined result,
+	// while those called on either operand beforehand only apply to that
+	// operand, which is then rendered in parentheses.
 	Union(SelectFinalStep) SelectOrderByStep
+	UnionAll(SelectFinalStep) SelectOrderByStep
+	Intersect(SelectFinalStep) SelectOrderByStep
+	IntersectAll(SelectFinalStep) SelectOrderByStep
+	Except(SelectFinalStep) SelectOrderByStep
+	ExceptAll(SelectFinalStep) SelectOrderByStep
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -87,19 +143,22 @@ type SelectFinalStep interface {
 ///////////////////////////////////////////////////////////////////////////////
 
 type selection struct {
-	with          Selectable
-	withAlias     null.String
+	with          withClause
 	selection     Selectable
 	distinctOn    []Expression
 	projections   []Selectable
 	joins         []join
 	joinTarget    Selectable
 	joinType      JoinType
+	joinLateral   bool
 	predicate     []Expression
 	groups        []Expression
 	havings       []Expression
+	windows       []namedWindow
 	ordering      []Expression
-	unions        []SelectFinalStep
+	orderingError error
+	setOperand    SelectFinalStep // left-most operand of a compound select
+	setOperations []setOperation
 	alias         null.String
 	isDistinct    bool
 	limit         int
@@ -109,6 +168,20 @@ type selection struct {
 	lockingOption LockingOption
 }
 
+// clone returns a copy of s for a step to modify so that the steps taken
+// from a query never change it, e.g. a base query can be completed in
+// different ways. The slices are capped so that appending to the copy
+// reallocates them instead of writing to the array shared with s.
+func (s *selection) clone() *selection {
+	c := *s
+	c.with = s.with.clone()
+	c.joins = c.joins[:len(c.joins):len(c.joins)]
+	c.predicate = c.predicate[:len(c.predicate):len(c.predicate)]
+	c.windows = c.windows[:len(c.windows):len(c.windows)]
+	c.setOperations = c.setOperations[:len(c.setOperations):len(c.setOperations)]
+	return &c
+}
+
 func Select(projections ...Selectable) SelectDistinctStep {
 	return &selection{projections: projections}
 }
@@ -119,100 +192,260 @@ func SelectCount() SelectDistinctStep {
 	}
 }
 
-func With(withAlias string, t Selectable) SelectWithStep {
-	s := &selection{
-		withAlias: null.StringFrom(withAlias),
-		with:      t,
-	}
-	return s
-}
-
 func (s *selection) Select(projections ...Selectable) SelectFromStep {
+	s = s.clone()
 	s.projections = projections
 	return s
 }
 
 func (s *selection) Distinct() SelectFromStep {
+	s = s.clone()
 	s.isDistinct = true
 	return s
 }
 
 func (s *selection) DistinctOn(f ...Expression) SelectFromStep {
+	s = s.clone()
 	s.distinctOn = f
 	return s
 }
 
 func (s *selection) From(t Selectable) SelectJoinStep {
+	s = s.clone()
 	s.selection = t
 	return s
 }
 
 func (s *selection) Join(t Selectable) SelectOnStep {
-	s.joinTarget = t
-	s.joinType = Join
-	return s
+	return s.startJoin(t, Join, false)
 }
 
 func (s *selection) LeftOuterJoin(t Selectable) SelectOnStep {
-	// TODO copy and paste from From(.)
+	return s.startJoin(t, LeftOuterJoin, false)
+}
+
+func (s *selection) RightOuterJoin(t Selectable) SelectOnStep {
+	return s.startJoin(t, RightOuterJoin, false)
+}
+
+func (s *selection) FullOuterJoin(t Selectable) SelectOnStep {
+	return s.startJoin(t, FullOuterJoin, false)
+}
+
+func (s *selection) CrossJoin(t Selectable) SelectJoinStep {
+	s = s.clone()
+	s.joins = append(s.joins, join{target: t, joinType: CrossJoin})
+	return s
+}
+
+func (s *selection) NaturalJoin(t Selectable) SelectJoinStep {
+	s = s.clone()
+	s.joins = append(s.joins, join{target: t, joinType: NaturalJoin})
+	return s
+}
+
+func (s *selection) JoinLateral(t Selectable) SelectOnStep {
+	return s.startJoin(t, Join, true)
+}
+
+func (s *selection) LeftJoinLateral(t Selectable) SelectOnStep {
+	return s.startJoin(t, LeftOuterJoin, true)
+}
+
+func (s *selection) CrossJoinLateral(t Selectable) SelectJoinStep {
+	s = s.clone()
+	s.joins = append(s.joins, join{target: t, joinType: CrossJoin, isLateral: true})
+	return s
+}
+
+func (s *selection) JoinRelationship(r Relationship) SelectJoinStep {
+	return s.startJoin(r.Target, Join, false).On(r.Conditions...)
+}
+
+func (s *selection) LeftOuterJoinRelationship(r Relationship) SelectJoinStep {
+	return s.startJoin(r.Target, LeftOuterJoin, false).On(r.Conditions...)
+}
+
+// startJoin records the join target until the join condition is given by
+// On(...) or Using(...)
+func (s *selection) startJoin(
+	t Selectable, joinType JoinType, isLateral bool,
+) SelectOnStep {
+	s = s.clone()
 	s.joinTarget = t
-	s.joinType = LeftOuterJoin
+	s.joinType = joinType
+	s.joinLateral = isLateral
 	return s
 }
 
 func (s *selection) Union(t SelectFinalStep) SelectOrderByStep {
-	s.unions = append(s.unions, t)
-	return s
+	return s.combine(SetOperatorUnion, t)
 }
 
-func (s *selection) On(c ...Expression) SelectJoinStep {
-	j := join{
-		target:     s.joinTarget,
-		joinType:   s.joinType,
-		conditions: c,
+func (s *selection) UnionAll(t SelectFinalStep) SelectOrderByStep {
+	return s.combine(SetOperatorUnionAll, t)
+}
+
+func (s *selection) Intersect(t SelectFinalStep) SelectOrderByStep {
+	return s.combine(SetOperatorIntersect, t)
+}
+
+func (s *selection) IntersectAll(t SelectFinalStep) SelectOrderByStep {
+	return s.combine(SetOperatorIntersectAll, t)
+}
+
+func (s *selection) Except(t SelectFinalStep) SelectOrderByStep {
+	return s.combine(SetOperatorExcept, t)
+}
+
+func (s *selection) ExceptAll(t SelectFinalStep) SelectOrderByStep {
+	return s.combine(SetOperatorExceptAll, t)
+}
+
+// combine appends the operation to s when s is a compound select that can be
+// extended without changing its meaning, otherwise it returns a new compound
+// select whose left operand is s.
+func (s *selection) combine(
+	operator SetOperator, operand SelectFinalStep,
+) SelectOrderByStep {
+	operation := setOperation{operator: operator, operand: operand}
+	if s.isCompound() && !s.hasResultModifiers() && !s.alias.Valid {
+		// A UNION B INTERSECT C is evaluated as A UNION (B INTERSECT C) so
+		// (A UNION B).Intersect(C) must keep A UNION B in parentheses
+		canAppend := true
+		if operator.isIntersect() {
+			for _, existing := range s.setOperations {
+				canAppend = canAppend && existing.operator.isIntersect()
+			}
+		}
+		if canAppend {
+			s = s.clone()
+			s.setOperations = append(s.setOperations, operation)
+			return s
+		}
 	}
+	return &selection{
+		setOperand:    s,
+		setOperations: []setOperation{operation},
+	}
+}
+
+func (s *selection) isCompound() bool {
+	return s.setOperand != nil
+}
+
+// hasResultModifiers reports whether the clauses that follow a set operation
+// in a compound select have been given
+func (s *selection) hasResultModifiers() bool {
+	return len(s.ordering) > 0 || s.limit > 0 || s.offset > 0 ||
+		len(s.seek) > 0 || s.lockingType != LockingTypeNone
+}
+
+func (s *selection) On(c ...Expression) SelectJoinStep {
+	return s.finishJoin(join{conditions: c})
+}
+
+func (s *selection) Using(f ...Field) SelectJoinStep {
+	return s.finishJoin(join{using: f})
+}
+
+func (s *selection) finishJoin(j join) SelectJoinStep {
+	s = s.clone()
+	j.target = s.joinTarget
+	j.joinType = s.joinType
+	j.isLateral = s.joinLateral
 	s.joinTarget = nil
 	s.joinType = NotJoined
+	s.joinLateral = false
 	s.joins = append(s.joins, j)
 	return s
 }
 
 func (s *selection) As(alias string) Selectable {
+	s = s.clone()
 	s.alias = null.StringFrom(alias)
 	return s
 }
 
-func (s *selection) Where(c ...Expression) SelectGroupByStep {
-	s.predicate = c
+// Where adds conditions to the WHERE clause, they are combined with AND with
+// each other and with the conditions given before
+func (s *selection) Where(c ...Expression) SelectConditionStep {
+	return s.AndWhere(c...)
+}
+
+func (s *selection) AndWhere(c ...Expression) SelectConditionStep {
+	s = s.clone()
+	s.predicate = append(s.predicate, c...)
 	return s
 }
 
+// OrWhere replaces the WHERE clause by (<conditions given before> OR
+// <conditions>)
+func (s *selection) OrWhere(c ...Expression) SelectConditionStep {
+	s = s.clone()
+	s.predicate = orConditions(s.predicate, c)
+	return s
+}
+
+// WhereIf adds conditions to the WHERE clause like Where only when cond is
+// true, e.g. for the optional filters of a search
+func (s *selection) WhereIf(cond bool, c ...Expression) SelectConditionStep {
+	if !cond {
+		return s
+	}
+	return s.AndWhere(c...)
+}
+
 func (s *selection) GroupBy(f ...Expression) SelectHavingStep {
+	s = s.clone()
 	s.groups = f
 	return s
 }
 
-func (s *selection) Having(c ...Expression) SelectOrderByStep {
+func (s *selection) Having(c ...Expression) SelectWindowStep {
+	s = s.clone()
 	s.havings = c
 	return s
 }
 
+func (s *selection) Window(
+	name string, specification WindowSpecification,
+) SelectWindowStep {
+	s = s.clone()
+	s.windows = append(s.windows, namedWindow{name, specification})
+	return s
+}
+
 func (s *selection) OrderBy(f ...Expression) SelectOffsetStep {
+	s = s.clone()
 	s.ordering = f
+	s.orderingError = nil
+	return s
+}
+
+// OrderByKeys orders by the expressions of the given sort keys, see
+// SortFields.Orderings. An unknown key is reported when the statement is
+// built.
+func (s *selection) OrderByKeys(fields SortFields, keys ...string) SelectOffsetStep {
+	s = s.clone()
+	s.ordering, s.orderingError = fields.Orderings(keys...)
 	return s
 }
 
 func (s *selection) Offset(offset int) SelectLimitStep {
+	s = s.clone()
 	s.offset = offset
 	return s
 }
 
 func (s *selection) Seek(v ...interface{}) SelectLimitStep {
+	s = s.clone()
 	s.seek = v
 	return s
 }
 
 func (s *selection) Limit(limit int) SelectFinalStep {
+	s = s.clone()
 	s.limit = limit
 	return s
 }
@@ -220,6 +453,7 @@ func (s *selection) Limit(limit int) SelectFinalStep {
 func (s *selection) For(
 	lockingType LockingType, lockingOption LockingOption,
 ) SelectFinalStep {
+	s = s.clone()
 	s.lockingType = lockingType
 	s.lockingOption = lockingOption
 	return s
@@ -235,6 +469,9 @@ func (s *selection) GetAlias() null.String {
 
 func (s *selection) Fetch(dl Dialect, db DBInterface) (*sqlx.Rows, error) {
 	builder := s.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
 	return db.Queryx(builder.String(), builder.arguments...)
 }
 
@@ -243,26 +480,46 @@ func (s *selection) FetchRow(dl Dialect, db DBInterface) *sqlx.Row {
 	return db.QueryRowx(builder.String(), builder.arguments...)
 }
 
+func (s *selection) FetchOne(dl Dialect, db DBInterface) (*sqlx.Row, error) {
+	builder := s.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return db.QueryRowx(builder.String(), builder.arguments...), nil
+}
+
 func (s *selection) FetchWithContext(
 	ctx context.Context, dl Dialect, db DBInterface) (*sqlx.Rows, error) {
 	builder := s.Build(dl)
-	return db.QueryxContext(ctx, builder.String(), builder.arguments...)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return withContextHooks(ctx, db).QueryxContext(ctx, builder.String(), builder.arguments...)
 }
 
 func (s *selection) FetchRowWithContext(
 	ctx context.Context, dl Dialect, db DBInterface) *sqlx.Row {
 	builder := s.Build(dl)
-	return db.QueryRowxContext(ctx, builder.String(), builder.arguments...)
+	return withContextHooks(ctx, db).QueryRowxContext(ctx, builder.String(), builder.arguments...)
+}
+
+func (s *selection) FetchOneWithContext(
+	ctx context.Context, dl Dialect, db DBInterface) (*sqlx.Row, error) {
+	builder := s.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return withContextHooks(ctx, db).QueryRowxContext(ctx, builder.String(), builder.arguments...), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////
 // Renderable
 ///////////////////////////////////////////////////////////////////////////////
 
-func (s *selection) Build(d Dialect) *Builder {
-	builder := Builder{}
-	s.Render(&builder)
-	return &builder
+func (s *selection) Build(dl Dialect) *Builder {
+	builder := NewBuilder(dl)
+	s.Render(builder)
+	return builder
 }
 
 func (s *selection) Render(
@@ -274,17 +531,127 @@ func (s *selection) Render(
 		builder.Print("(")
 	}
 
-	if s.withAlias.Valid {
-		builder.Printf("WITH %s AS (", s.withAlias.String)
-		s.with.Render(builder)
-		builder.Print(") ")
+	if s.isCompound() {
+		s.renderSetOperations(builder)
+	} else {
+		s.renderSelect(builder)
 	}
 
+	// render ORDER BY clause
+	if s.orderingError != nil {
+		builder.errors = append(builder.errors, s.orderingError)
+	}
+	if (len(s.ordering)) > 0 {
+		builder.Print(" ORDER BY ")
+		builder.RenderExpressions(s.ordering)
+	}
+
+	// render LIMIT clause
+	if s.limit > 0 {
+		builder.Printf(" LIMIT %d", s.limit)
+	}
+
+	// render OFFSET clause
+	if s.offset > 0 {
+		if s.limit <= 0 && builder.getDialect().offsetWithoutLimit != "" {
+			builder.Printf(" %s", builder.getDialect().offsetWithoutLimit)
+		}
+		builder.Printf(" OFFSET %d", s.offset)
+	}
+
+	// render LOCKING clause
+	if s.lockingType != LockingTypeNone && s.isLockingSupported(builder) {
+		builder.Printf(" %s", s.lockingType.String())
+		if s.lockingOption != LockingOptionNone {
+			builder.Printf(" %s", s.lockingOption.String())
+		}
+	}
+
+	if hasAlias {
+		builder.Printf(") AS %s", builder.QuoteIdentifier(s.alias.String))
+	}
+}
+
+func (s *selection) renderSetOperations(
+	builder *Builder,
+) {
+	if len(s.seek) > 0 {
+		builder.errors = append(builder.errors, compoundSeekError)
+	}
+	if s.lockingType != LockingTypeNone {
+		builder.errors = append(builder.errors, compoundLockingError)
+	}
+	s.renderSetOperand(builder, s.setOperand, true)
+	for _, operation := range s.setOperations {
+		if operation.operator == SetOperatorIntersectAll ||
+			operation.operator == SetOperatorExceptAll {
+			builder.requireFeature(DialectFeatureIntersectExceptAll)
+		}
+		builder.Printf(" %s ", operation.operator)
+		s.renderSetOperand(builder, operation.operand, false)
+	}
+}
+
+func renderJoins(
+	builder *Builder, joins []join,
+) {
+	for _, join := range joins {
+		if join.joinType == FullOuterJoin {
+			builder.requireFeature(DialectFeatureFullOuterJoin)
+		}
+		builder.Printf(" %s ", join.joinType)
+		if join.isLateral && builder.requireFeature(DialectFeatureLateralJoin) {
+			builder.Print("LATERAL ")
+		}
+		join.target.Render(builder)
+		if len(join.conditions) > 0 {
+			builder.Print(" ON ")
+			builder.RenderConditions(join.conditions)
+		} else if len(join.using) > 0 {
+			builder.Print(" USING ")
+			builder.RenderFieldArray(join.using)
+		} else if join.joinType != CrossJoin && join.joinType != NaturalJoin {
+			builder.errors = append(builder.errors,
+				&MissingJoinConditionError{JoinType: join.joinType})
+		}
+	}
+}
+
+// renderSetOperand wraps the operand in parentheses when its own ORDER BY,
+// LIMIT, OFFSET or set operations would otherwise apply to the compound result
+func (s *selection) renderSetOperand(
+	builder *Builder, operand SelectFinalStep, isFirst bool,
+) {
+	needsParentheses := true
+	if operand, ok := operand.(*selection); ok {
+		needsParentheses = operand.isCompound() || operand.hasResultModifiers()
+	}
+	if !builder.getDialect().parenthesizedSelect {
+		if needsParentheses {
+			builder.requireFeature(DialectFeatureParenthesizedSetOperand)
+		}
+		operand.Render(builder)
+		return
+	}
+	if needsParentheses || !isFirst {
+		builder.Print("(")
+		operand.Render(builder)
+		builder.Print(")")
+	} else {
+		operand.Render(builder)
+	}
+}
+
+func (s *selection) renderSelect(
+	builder *Builder,
+) {
+	s.with.render(builder)
+
 	builder.Print("SELECT ")
 
 	if s.isDistinct {
 		builder.Print("DISTINCT ")
-	} else if len(s.distinctOn) > 0 {
+	} else if len(s.distinctOn) > 0 && builder.requireFeature(DialectFeatureDistinctOn) {
 		builder.Print("DISTINCT ON (")
 		builder.RenderExpressions(s.distinctOn)
 		builder.Print(") ")
@@ -305,27 +672,20 @@ func (s *selection) Render(
 	if s.selection != nil {
 		builder.Print(" FROM ")
 		s.selection.Render(builder)
+	} else if len(s.projections) == 0 {
+		builder.errors = append(builder.errors, &MissingFromError{})
 	}
 
 	// render JOIN/ON clause
-	for _, join := range s.joins {
-		var joinString string
-		switch join.joinType {
-		case LeftOuterJoin:
-			joinString = "LEFT OUTER JOIN"
-		case Join:
-			joinString = "JOIN"
-		}
-
-		builder.Printf(" %s ", joinString)
-		join.target.Render(builder)
-		builder.Print(" ON ")
-		builder.RenderConditions(join.conditions)
-	}
+	renderJoins(builder, s.joins)
 
-	predicate := s.predicate
+	predicate := withoutNoops(s.predicate)
 	if len(s.seek) > 0 {
-		predicate = append(predicate, s.getSeekCondition())
+		// capped so that rendering never writes to the array of s.predicate
+		predicate = predicate[:len(predicate):len(predicate)]
+		if seekCondition := s.getSeekCondition(builder); seekCondition != nil {
+			predicate = append(predicate, seekCondition)
+		}
 	}
 
 	// render WHERE clause
@@ -341,46 +701,34 @@ func (s *selection) Render(
 	}
 
 	// render HAVING clause
-	if len(s.havings) > 0 {
+	if havings := withoutNoops(s.havings); len(havings) > 0 {
 		builder.Print(" HAVING ")
-		builder.RenderConditions(s.havings)
+		builder.RenderConditions(havings)
 	}
 
-	// render UNION clause
-	for _, union := range s.unions {
-		builder.Print(" UNION (")
-		union.Render(builder)
+	// render WINDOW clause
+	for index, window := range s.windows {
+		if index == 0 {
+			builder.Print(" WINDOW ")
+		} else {
+			builder.Print(", ")
+		}
+		builder.Printf("%s AS (", builder.QuoteIdentifier(window.name))
+		window.specification.Render(builder)
 		builder.Print(")")
 	}
 
-	// render ORDER BY clause
-	if (len(s.ordering)) > 0 {
-		builder.Print(" ORDER BY ")
-		builder.RenderExpressions(s.ordering)
-	}
-
-	// render LIMIT clause
-	if s.limit > 0 {
-		builder.Printf(" LIMIT %d", s.limit)
-	}
-
-	// render OFFSET clause
-	if s.offset > 0 {
-		builder.Printf(" OFFSET %d", s.offset)
-	}
-
-	// render LOCKING clause
-	if s.lockingType != LockingTypeNone {
-		builder.Printf(" %s", s.lockingType.String())
-		if s.lockingOption != LockingOptionNone {
-			builder.Printf(" %s", s.lockingOption.String())
-		}
-	}
+}
 
-	if hasAlias {
-		builder.Printf(") AS \"%s\"", s.alias.String)
+func (s *selection) isLockingSupported(
+	builder *Builder,
+) bool {
+	switch s.lockingType {
+	case LockingTypeNoKeyUpdate, LockingTypeKeyShare:
+		return builder.requireFeature(DialectFeatureKeyLocking)
+	default:
+		return builder.requireFeature(DialectFeatureRowLocking)
 	}
-
 }
 
 // faster and stable pagination based on these two articles
@@ -396,9 +744,13 @@ func (s *selection) Render(
 // WHERE ((column1 < "foo1")
 // OR (value1 = "foo1" AND value2 < "foo2")
 // OR (value1 = "foo1" AND value2 = "foo2" AND value3 < "foo3"))
-func (s *selection) getSeekCondition() Expression {
-	if len(s.seek) < len(s.ordering) {
-		panic("number of arguments in seek(...) must be gte number of arguments in orderBy")
+func (s *selection) getSeekCondition(
+	builder *Builder,
+) Expression {
+	if len(s.ordering) == 0 || len(s.seek) < len(s.ordering) {
+		builder.errors = append(builder.errors,
+			&SeekArityError{Values: len(s.seek), Ordering: len(s.ordering)})
+		return nil
 	}
 	// we went with the following approach to deal with mixed ordering
 	var orExpressions []BoolExpression
@@ -412,7 +764,9 @@ func (s *selection) getSeekCondition() Expression {
 		case OperatorNil:
 			operator = OperatorGt
 		default:
-			panic(fmt.Sprintf("seek does not support operator=%s", order.getOperator()))
+			builder.errors = append(builder.errors,
+				&UnsupportedSeekOperatorError{Operator: order.getOperator()})
+			return nil
 		}
 
 		var andExpressions []BoolExpression
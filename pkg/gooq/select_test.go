@@ -24,61 +24,61 @@ var selectTestCases = []TestCase{
 	},
 	{
 		Constructed:  Select(Table1.Column1).DistinctOn(Table1.Column2, Table1.Column3).From(Table1),
-		ExpectedStmt: `SELECT DISTINCT ON ("table1".column2, "table1".column3) "table1".column1 FROM public.table1`,
+		ExpectedStmt: `SELECT DISTINCT ON (table1.column2, table1.column3) table1.column1 FROM public.table1`,
 	},
 	{
 		Constructed:  Select(Table1.Column1).From(Table1),
-		ExpectedStmt: `SELECT "table1".column1 FROM public.table1`,
+		ExpectedStmt: `SELECT table1.column1 FROM public.table1`,
 	},
 	{
 		Constructed:  Select(Table1.Column1, Table1.Column2).From(Table1),
-		ExpectedStmt: `SELECT "table1".column1, "table1".column2 FROM public.table1`,
+		ExpectedStmt: `SELECT table1.column1, table1.column2 FROM public.table1`,
 	},
 	{
 		Constructed:  Select(Table1.Column1.As("result")).From(Table1),
-		ExpectedStmt: `SELECT "table1".column1 AS "result" FROM public.table1`,
+		ExpectedStmt: `SELECT table1.column1 AS result FROM public.table1`,
 	},
 	{
 		Constructed:  Select(Table1.Column1).From(Table1).Where(Table1.Column2.Eq(String("foo"))),
-		ExpectedStmt: `SELECT "table1".column1 FROM public.table1 WHERE "table1".column2 = $1`,
+		ExpectedStmt: `SELECT table1.column1 FROM public.table1 WHERE table1.column2 = $1`,
 	},
 	{
 		Constructed:  Select(Table1.Column1.Filter(Table1.Column2.Eq(String("foo")))).From(Table1),
-		ExpectedStmt: `SELECT "table1".column1 FILTER (WHERE "table1".column2 = $1) FROM public.table1`,
+		ExpectedStmt: `SELECT table1.column1 FILTER (WHERE table1.column2 = $1) FROM public.table1`,
 	},
 	{
 		Constructed: Select(Table1.Column1).From(Table1).Where(
 			Table1.Column2.IsIn("quix", "foo"),
 			Table1.Column2.Eq(String("quack"))),
-		ExpectedStmt: `SELECT "table1".column1 FROM public.table1 WHERE "table1".column2 IN ($1, $2) AND "table1".column2 = $3`,
+		ExpectedStmt: `SELECT table1.column1 FROM public.table1 WHERE table1.column2 IN ($1, $2) AND table1.column2 = $3`,
 	},
 	{
 		Constructed:  Select(Table1.Column3.Add(Int64(5))).From(Table1),
-		ExpectedStmt: `SELECT "table1".column3 + $1 FROM public.table1`,
+		ExpectedStmt: `SELECT table1.column3 + $1 FROM public.table1`,
 	},
 	{
 		Constructed:  Select(Table1.Column3.Add(Float64(1.72))).From(Table1),
-		ExpectedStmt: `SELECT "table1".column3 + $1 FROM public.table1`,
+		ExpectedStmt: `SELECT table1.column3 + $1 FROM public.table1`,
 	},
 	{
 		Constructed:  Select(Table1.Column3.Add(Table1.Column4)).From(Table1),
-		ExpectedStmt: `SELECT "table1".column3 + "table1".column4 FROM public.table1`,
+		ExpectedStmt: `SELECT table1.column3 + table1.column4 FROM public.table1`,
 	},
 	{
 		Constructed:  Select(Table1.Column3.Sub(Table1.Column4)).From(Table1),
-		ExpectedStmt: `SELECT "table1".column3 - "table1".column4 FROM public.table1`,
+		ExpectedStmt: `SELECT table1.column3 - table1.column4 FROM public.table1`,
 	},
 	{
 		Constructed:  Select(Table1.Column3.Mult(Table1.Column4)).From(Table1),
-		ExpectedStmt: `SELECT "table1".column3 * "table1".column4 FROM public.table1`,
+		ExpectedStmt: `SELECT table1.column3 * table1.column4 FROM public.table1`,
 	},
 	{
 		Constructed:  Select(Table1.Column3.Div(Table1.Column4)).From(Table1),
-		ExpectedStmt: `SELECT "table1".column3 / "table1".column4 FROM public.table1`,
+		ExpectedStmt: `SELECT table1.column3 / table1.column4 FROM public.table1`,
 	},
 	{
 		Constructed:  Select(Table1.Column3.Div(Table1.Column4).As("result")).From(Table1),
-		ExpectedStmt: `SELECT "table1".column3 / "table1".column4 AS "result" FROM public.table1`,
+		ExpectedStmt: `SELECT table1.column3 / table1.column4 AS result FROM public.table1`,
 	},
 	{
 		Constructed: Select(Table1.Column1).From(Table1).Where(
@@ -89,52 +89,147 @@ var selectTestCases = []TestCase{
 					Table1.Column2.Eq(String("foo")),
 					Table1.Column2.Eq(String("quack")))).
 			OrderBy(NewStringField(NewTable("", ""), "column2").Asc()),
-		ExpectedStmt: `SELECT "table1".column1 FROM public.table1 WHERE "table1".column2 = $1 AND "table1".column2 = $2 UNION (SELECT "table1".column1 FROM public.table1 WHERE "table1".column2 = $3 AND "table1".column2 = $4) ORDER BY column2 ASC`,
+		ExpectedStmt: `SELECT table1.column1 FROM public.table1 WHERE table1.column2 = $1 AND table1.column2 = $2 UNION (SELECT table1.column1 FROM public.table1 WHERE table1.column2 = $3 AND table1.column2 = $4) ORDER BY column2 ASC`,
+	},
+	{
+		Constructed:  Select(Table1.Column1).From(Table1).UnionAll(Select(Table2.Column1).From(Table2)),
+		ExpectedStmt: `SELECT table1.column1 FROM public.table1 UNION ALL (SELECT table2.column1 FROM public.table2)`,
+	},
+	{
+		Constructed: Select(Table1.Column1).From(Table1).
+			Intersect(Select(Table2.Column1).From(Table2)).
+			Except(Select(Table3.Column1).From(Table3)),
+		ExpectedStmt: `SELECT table1.column1 FROM public.table1 INTERSECT (SELECT table2.column1 FROM public.table2) EXCEPT (SELECT table3.column1 FROM public.table3)`,
+	},
+	{
+		Constructed: Select(Table1.Column1).From(Table1).
+			Union(Select(Table2.Column1).From(Table2)).
+			IntersectAll(Select(Table3.Column1).From(Table3)),
+		ExpectedStmt: `(SELECT table1.column1 FROM public.table1 UNION (SELECT table2.column1 FROM public.table2)) INTERSECT ALL (SELECT table3.column1 FROM public.table3)`,
+	},
+	{
+		Constructed: Select(Table1.Column1).From(Table1).
+			ExceptAll(Select(Table2.Column1).From(Table2).Intersect(Select(Table3.Column1).From(Table3))),
+		ExpectedStmt: `SELECT table1.column1 FROM public.table1 EXCEPT ALL (SELECT table2.column1 FROM public.table2 INTERSECT (SELECT table3.column1 FROM public.table3))`,
+	},
+	{
+		Constructed: Select(Table1.Column1).From(Table1).OrderBy(Table1.Column1).Limit(1).
+			Union(Select(Table2.Column1).From(Table2).OrderBy(Table2.Column1.Desc()).Limit(1)).
+			OrderBy(Table1.Column1).Offset(5).Limit(10),
+		ExpectedStmt: `(SELECT table1.column1 FROM public.table1 ORDER BY table1.column1 LIMIT 1) UNION (SELECT table2.column1 FROM public.table2 ORDER BY table2.column1 DESC LIMIT 1) ORDER BY table1.column1 LIMIT 10 OFFSET 5`,
+	},
+	{
+		Constructed: Select(Table1.Column1).From(Table1).Union(Select(Table2.Column1).From(Table2)).Limit(10).
+			UnionAll(Select(Table3.Column1).From(Table3)),
+		ExpectedStmt: `(SELECT table1.column1 FROM public.table1 UNION (SELECT table2.column1 FROM public.table2) LIMIT 10) UNION ALL (SELECT table3.column1 FROM public.table3)`,
+	},
+	{
+		Constructed: Select().From(
+			Select(Table1.Column1).From(Table1).Union(Select(Table2.Column1).From(Table2)).As("combined")),
+		ExpectedStmt: `SELECT * FROM (SELECT table1.column1 FROM public.table1 UNION (SELECT table2.column1 FROM public.table2)) AS combined`,
+	},
+	{
+		Constructed:  Select(Table1.Column1, ArrayAggNumeric(Table1.Column3).Slice(1, 3).As("top")).From(Table1).GroupBy(Table1.Column1),
+		ExpectedStmt: `SELECT table1.column1, (ARRAY_AGG(table1.column3))[1:3] AS top FROM public.table1 GROUP BY table1.column1`,
+	},
+	{
+		Constructed:  Select(Table1.ID, Table1.Tags.Unnest().As("tag")).From(Table1).Where(Table1.Tags.Contains(StringArray("a"))),
+		ExpectedStmt: `SELECT table1.id, UNNEST(table1.tags) AS tag FROM public.table1 WHERE table1.tags @> $1`,
+	},
+	{
+		Constructed: Select(Table1.Column1, RowNumber().OverWindow("w"), Sum(Table1.Column3).OverWindow("w")).
+			From(Table1).
+			Window("w", Window().PartitionBy(Table1.Column2).OrderBy(Table1.Column3.Desc())).
+			OrderBy(Table1.Column1),
+		ExpectedStmt: `SELECT table1.column1, ROW_NUMBER() OVER w, SUM(table1.column3) OVER w FROM public.table1 WINDOW w AS (PARTITION BY table1.column2 ORDER BY table1.column3 DESC) ORDER BY table1.column1`,
+	},
+	{
+		Constructed: Select(Table1.Column2, Sum(Table1.Column3).OverWindow("w1"), Rank().OverWindow("w2")).
+			From(Table1).
+			GroupBy(Table1.Column2, Table1.Column3).
+			Having(Count().IsGt(1)).
+			Window("w1", Window().PartitionBy(Table1.Column2)).
+			Window("w2", WindowFrom("w1").OrderBy(Table1.Column3)),
+		ExpectedStmt: `SELECT table1.column2, SUM(table1.column3) OVER w1, RANK() OVER w2 FROM public.table1 GROUP BY table1.column2, table1.column3 HAVING COUNT(*) > $1 WINDOW w1 AS (PARTITION BY table1.column2), w2 AS (w1 ORDER BY table1.column3)`,
 	},
 	{
 		Constructed:  Select().From(Table1).OrderBy(Table1.Column1.Asc()),
-		ExpectedStmt: `SELECT * FROM public.table1 ORDER BY "table1".column1 ASC`,
+		ExpectedStmt: `SELECT * FROM public.table1 ORDER BY table1.column1 ASC`,
 	},
 	{
 		Constructed:  Select().From(Table1).OrderBy(Table1.Column1.Desc()),
-		ExpectedStmt: `SELECT * FROM public.table1 ORDER BY "table1".column1 DESC`,
+		ExpectedStmt: `SELECT * FROM public.table1 ORDER BY table1.column1 DESC`,
 	},
 	{
 		Constructed:  Select().From(Table1).OrderBy(Table1.Column1, Table1.ID).Seek("foo", "bar"),
-		ExpectedStmt: `SELECT * FROM public.table1 WHERE (("table1".column1 > $1) OR ("table1".column1 = $2 AND "table1".id > $3)) ORDER BY "table1".column1, "table1".id`,
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE ((table1.column1 > $1) OR (table1.column1 = $2 AND table1.id > $3)) ORDER BY table1.column1, table1.id`,
 		Arguments:    []interface{}{"foo", "foo", "bar"},
 	},
 	{
 		Constructed:  Select().From(Table1).OrderBy(Table1.Column1.Desc(), Table1.ID.Desc()).Seek("foo", "bar"),
-		ExpectedStmt: `SELECT * FROM public.table1 WHERE (("table1".column1 < $1) OR ("table1".column1 = $2 AND "table1".id < $3)) ORDER BY "table1".column1 DESC, "table1".id DESC`,
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE ((table1.column1 < $1) OR (table1.column1 = $2 AND table1.id < $3)) ORDER BY table1.column1 DESC, table1.id DESC`,
 		Arguments:    []interface{}{"foo", "foo", "bar"},
 	},
 	{
 		Constructed:  Select().From(Table1).OrderBy(Table1.Column1.Asc(), Table1.ID.Desc()).Seek("foo", "bar"),
-		ExpectedStmt: `SELECT * FROM public.table1 WHERE (("table1".column1 > $1) OR ("table1".column1 = $2 AND "table1".id < $3)) ORDER BY "table1".column1 ASC, "table1".id DESC`,
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE ((table1.column1 > $1) OR (table1.column1 = $2 AND table1.id < $3)) ORDER BY table1.column1 ASC, table1.id DESC`,
 		Arguments:    []interface{}{"foo", "foo", "bar"},
 	},
 	{
 		Constructed:  Select().From(Table1).OrderBy(Table1.Column1.Desc(), Table1.ID.Asc()).Seek("foo", "bar"),
-		ExpectedStmt: `SELECT * FROM public.table1 WHERE (("table1".column1 < $1) OR ("table1".column1 = $2 AND "table1".id > $3)) ORDER BY "table1".column1 DESC, "table1".id ASC`,
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE ((table1.column1 < $1) OR (table1.column1 = $2 AND table1.id > $3)) ORDER BY table1.column1 DESC, table1.id ASC`,
 		Arguments:    []interface{}{"foo", "foo", "bar"},
 	},
 	{
 		Constructed:  Select().From(Table1).OrderBy(Table1.Column1.Desc(), Table1.Column2.Desc(), Table1.ID.Asc()).Seek("foo", "bar", "baz"),
-		ExpectedStmt: `SELECT * FROM public.table1 WHERE (("table1".column1 < $1) OR ("table1".column1 = $2 AND "table1".column2 < $3) OR ("table1".column1 = $4 AND "table1".column2 = $5 AND "table1".id > $6)) ORDER BY "table1".column1 DESC, "table1".column2 DESC, "table1".id ASC`,
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE ((table1.column1 < $1) OR (table1.column1 = $2 AND table1.column2 < $3) OR (table1.column1 = $4 AND table1.column2 = $5 AND table1.id > $6)) ORDER BY table1.column1 DESC, table1.column2 DESC, table1.id ASC`,
 		Arguments:    []interface{}{"foo", "foo", "bar", "foo", "bar", "baz"},
 	},
+	{
+		Constructed:  Select().From(Table1).OrderBy(Table1.Column1, Table1.ID).Seek("foo"),
+		ExpectedStmt: `SELECT * FROM public.table1 ORDER BY table1.column1, table1.id`,
+		Errors:       []error{&SeekArityError{Values: 1, Ordering: 2}},
+	},
+	{
+		Constructed:  Select().From(Table1).Seek("foo"),
+		ExpectedStmt: `SELECT * FROM public.table1`,
+		Errors:       []error{&SeekArityError{Values: 1, Ordering: 0}},
+	},
+	{
+		Constructed:  Select().From(Table1).OrderBy(Table1.Column3.Add(Int64(1))).Seek(1),
+		ExpectedStmt: `SELECT * FROM public.table1 ORDER BY table1.column3 + $1`,
+		Errors:       []error{&UnsupportedSeekOperatorError{Operator: OperatorAdd}},
+	},
+	{
+		Constructed:  Select().Where(Table1.Column1.Eq(String("foo"))),
+		ExpectedStmt: `SELECT * WHERE table1.column1 = $1`,
+		Errors:       []error{&MissingFromError{}},
+	},
+	{
+		Constructed:  Select().From(Table1).Join(Table2).On(),
+		ExpectedStmt: `SELECT * FROM public.table1 JOIN public.table2`,
+		Errors:       []error{&MissingJoinConditionError{JoinType: Join}},
+	},
+	{
+		Constructed:  Select().From(Table1).JoinRelationship(Relationship{Target: Table2}),
+		ExpectedStmt: `SELECT * FROM public.table1 JOIN public.table2`,
+		Errors:       []error{&MissingJoinConditionError{JoinType: Join}},
+	},
+	{
+		Constructed:  Select(Int64(1)),
+		ExpectedStmt: `SELECT $1`,
+	},
 	{
 		Constructed:  Select().From(Table1).GroupBy(Table1.Column1),
-		ExpectedStmt: `SELECT * FROM public.table1 GROUP BY "table1".column1`,
+		ExpectedStmt: `SELECT * FROM public.table1 GROUP BY table1.column1`,
 	},
 	{
 		Constructed:  Select(Table1.Column1.Filter(Table1.Column2.Eq(String("foo")))).From(Table1),
-		ExpectedStmt: `SELECT "table1".column1 FILTER (WHERE "table1".column2 = $1) FROM public.table1`,
+		ExpectedStmt: `SELECT table1.column1 FILTER (WHERE table1.column2 = $1) FROM public.table1`,
 	},
 	{
 		Constructed:  Select(Coalesce(Table1.Column1.Filter(Table1.Column2.Eq(String("foo"))), Int64(0)).As("total")).From(Table1),
-		ExpectedStmt: `SELECT COALESCE("table1".column1 FILTER (WHERE "table1".column2 = $1), $2) AS "total" FROM public.table1`,
+		ExpectedStmt: `SELECT COALESCE(table1.column1 FILTER (WHERE table1.column2 = $1), $2) AS total FROM public.table1`,
 	},
 	{
 		Constructed:  Select().From(Table1).Limit(10),
@@ -142,52 +237,79 @@ var selectTestCases = []TestCase{
 	},
 	{
 		Constructed:  Select(Table1.Column1).From(Table1).Join(Table2).On(Table2.Column1.Eq(Table1.Column1)),
-		ExpectedStmt: `SELECT "table1".column1 FROM public.table1 JOIN public.table2 ON "table2".column1 = "table1".column1`,
+		ExpectedStmt: `SELECT table1.column1 FROM public.table1 JOIN public.table2 ON table2.column1 = table1.column1`,
 	},
 	{
 		Constructed: Select(Table1.Column1, Table2.Column1).From(Table1).
 			Join(Table2).On(Table2.Column1.Eq(Table1.Column1), Table2.Column2.Eq(Table1.Column2)),
-		ExpectedStmt: `SELECT "table1".column1, "table2".column1 FROM public.table1 JOIN public.table2 ON "table2".column1 = "table1".column1 AND "table2".column2 = "table1".column2`,
+		ExpectedStmt: `SELECT table1.column1, table2.column1 FROM public.table1 JOIN public.table2 ON table2.column1 = table1.column1 AND table2.column2 = table1.column2`,
 	},
 	{
 		Constructed: Select(Table1.Column1).From(Table1).
 			LeftOuterJoin(Table2).On(Table2.Column1.Eq(Table1.Column1)).
 			LeftOuterJoin(Table3).On(Table3.Column1.Eq(Table1.Column1)),
-		ExpectedStmt: `SELECT "table1".column1 FROM public.table1 LEFT OUTER JOIN public.table2 ON "table2".column1 = "table1".column1 LEFT OUTER JOIN public.table3 ON "table3".column1 = "table1".column1`,
+		ExpectedStmt: `SELECT table1.column1 FROM public.table1 LEFT OUTER JOIN public.table2 ON table2.column1 = table1.column1 LEFT OUTER JOIN public.table3 ON table3.column1 = table1.column1`,
+	},
+	{
+		Constructed: Select(Table1.Column1).From(Table1).
+			RightOuterJoin(Table2).On(Table2.Column1.Eq(Table1.Column1)).
+			FullOuterJoin(Table3).On(Table3.Column1.Eq(Table1.Column1)),
+		ExpectedStmt: `SELECT table1.column1 FROM public.table1 RIGHT OUTER JOIN public.table2 ON table2.column1 = table1.column1 FULL OUTER JOIN public.table3 ON table3.column1 = table1.column1`,
+	},
+	{
+		Constructed:  Select().From(Table1).CrossJoin(Table2).NaturalJoin(Table3),
+		ExpectedStmt: `SELECT * FROM public.table1 CROSS JOIN public.table2 NATURAL JOIN public.table3`,
+	},
+	{
+		Constructed:  Select().From(Table1).Join(Table2).Using(Table1.Column1, Table1.Column2).Where(Table1.Column3.IsGt(1)),
+		ExpectedStmt: `SELECT * FROM public.table1 JOIN public.table2 USING (column1, column2) WHERE table1.column3 > $1`,
+	},
+	{
+		Constructed: Select().From(Table1).
+			LeftJoinLateral(Select(Table2.Column1).From(Table2).Where(Table2.Column2.Eq(Table1.Column2)).Limit(1).As("latest")).
+			On(Bool(true)),
+		ExpectedStmt: `SELECT * FROM public.table1 LEFT OUTER JOIN LATERAL (SELECT table2.column1 FROM public.table2 WHERE table2.column2 = table1.column2 LIMIT 1) AS latest ON $1`,
+	},
+	{
+		Constructed: Select().From(Table1).
+			JoinLateral(Select(Table2.Column1).From(Table2).Where(Table2.Column2.Eq(Table1.Column2)).As("t")).
+			On(Table1.Column1.Eq(NewStringField(NewTable("", "t"), "column1"))).
+			CrossJoinLateral(Select(Table3.Column1).From(Table3).Where(Table3.Column2.Eq(Table1.Column2)).As("u")),
+		ExpectedStmt: `SELECT * FROM public.table1 JOIN LATERAL (SELECT table2.column1 FROM public.table2 WHERE table2.column2 = table1.column2) AS t ON table1.column1 = t.column1 CROSS JOIN LATERAL (SELECT table3.column1 FROM public.table3 WHERE table3.column2 = table1.column2) AS u`,
 	},
 	{
 		Constructed: Select().From(Table1).
 			LeftOuterJoin(Select(Table1.Column1).From(Table1).As("boo")).
 			On(NewStringField(NewTable("", "boo"), "column1").Eq(Table1.Column1)),
-		ExpectedStmt: `SELECT * FROM public.table1 LEFT OUTER JOIN (SELECT "table1".column1 FROM public.table1) AS "boo" ON "boo".column1 = "table1".column1`,
+		ExpectedStmt: `SELECT * FROM public.table1 LEFT OUTER JOIN (SELECT table1.column1 FROM public.table1) AS boo ON boo.column1 = table1.column1`,
 	},
 	{
 		Constructed:  Select().From(Select(Table1.Column1).From(Table1).As("boo")),
-		ExpectedStmt: `SELECT * FROM (SELECT "table1".column1 FROM public.table1) AS "boo"`,
+		ExpectedStmt: `SELECT * FROM (SELECT table1.column1 FROM public.table1) AS boo`,
 	},
 	{
 		Constructed: Select(Table1.Column1, Table2.Column1).From(
 			Select(Table1.Column1).From(Table1).As("boo")).
 			Join(Table2).On(Table2.Column1.Eq(Table1.Column1)),
-		ExpectedStmt: `SELECT "table1".column1, "table2".column1 FROM (SELECT "table1".column1 FROM public.table1) AS "boo" JOIN public.table2 ON "table2".column1 = "table1".column1`,
+		ExpectedStmt: `SELECT table1.column1, table2.column1 FROM (SELECT table1.column1 FROM public.table1) AS boo JOIN public.table2 ON table2.column1 = table1.column1`,
 	},
 	{
 		Constructed:  Select().From(Table1).GroupBy(Table1.Column1).Having(Count(Asterisk).IsGt(5)),
-		ExpectedStmt: `SELECT * FROM public.table1 GROUP BY "table1".column1 HAVING COUNT(*) > $1`,
+		ExpectedStmt: `SELECT * FROM public.table1 GROUP BY table1.column1 HAVING COUNT(*) > $1`,
 		Arguments:    []interface{}{float64(5)},
 	},
 	{
 		Constructed: Select().From(Table1).
 			Where(Table1.Column1.IsEq("foo")).
 			For(LockingTypeUpdate, LockingOptionNone),
-		ExpectedStmt: `SELECT * FROM public.table1 WHERE "table1".column1 = $1 FOR UPDATE`,
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE table1.column1 = $1 FOR UPDATE`,
 		Arguments:    []interface{}{"foo"},
 	},
 	{
 		Constructed: Select().From(Table1).
 			Where(Table1.Column1.IsEq("foo")).
 			For(LockingTypeUpdate, LockingOptionSkipLocked),
-		ExpectedStmt: `SELECT * FROM public.table1 WHERE "table1".column1 = $1 FOR UPDATE SKIP LOCKED`,
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE table1.column1 = $1 FOR UPDATE SKIP LOCKED`,
 		Arguments:    []interface{}{"foo"},
 	},
 	{
@@ -196,6 +318,40 @@ var selectTestCases = []TestCase{
 			Select().From(NewTable("", "temp")).OrderBy(NewStringField(nil, "column2")),
 		ExpectedStmt: `WITH temp AS (UPDATE public.table1 SET column1 = $1 RETURNING *) SELECT * FROM temp ORDER BY column2`,
 	},
+	{
+		Constructed: With("a", Select(Table1.Column1).From(Table1)).
+			With("b", Select(Table2.Column1).From(Table2)).
+			Select().From(NewTable("", "a")).Join(NewTable("", "b")).
+			On(NewStringField(NewTable("", "a"), "column1").Eq(NewStringField(NewTable("", "b"), "column1"))),
+		ExpectedStmt: `WITH a AS (SELECT table1.column1 FROM public.table1), b AS (SELECT table2.column1 FROM public.table2) SELECT * FROM a JOIN b ON a.column1 = b.column1`,
+	},
+	{
+		Constructed: WithCTE(
+			CTE("a", Select(Table1.Column1, Table1.Column3).From(Table1)).Columns("name", "total").Materialized(),
+			CTE("b", Select(Table2.Column1).From(Table2)).NotMaterialized()).
+			Select().From(NewTable("", "a")),
+		ExpectedStmt: `WITH a (name, total) AS MATERIALIZED (SELECT table1.column1, table1.column3 FROM public.table1), b AS NOT MATERIALIZED (SELECT table2.column1 FROM public.table2) SELECT * FROM a`,
+	},
+	{
+		Constructed: WithRecursive("tree",
+			Select(Table1.ID, Table1.Column1).From(Table1).Where(Table1.Column1.IsNull()),
+			Select(Table1.ID, Table1.Column1).From(Table1).
+				Join(NewTable("", "tree")).On(Table1.Column1.Eq(NewStringField(NewTable("", "tree"), "id")))).
+			Select().From(NewTable("", "tree")),
+		ExpectedStmt: `WITH RECURSIVE tree AS (SELECT table1.id, table1.column1 FROM public.table1 WHERE table1.column1 IS NULL UNION ALL SELECT table1.id, table1.column1 FROM public.table1 JOIN tree ON table1.column1 = tree.id) SELECT * FROM tree`,
+	},
+	{
+		Constructed: With("a", Select(Table1.Column1).From(Table1)).
+			WithCTE(RecursiveCTE("n", Select(Int64(1)), Select(NewIntField(NewTable("", "n"), "i").Add(Int64(1))).
+				From(NewTable("", "n"))).Columns("i")).
+			Select().From(NewTable("", "n")).Limit(5),
+		ExpectedStmt: `WITH RECURSIVE a AS (SELECT table1.column1 FROM public.table1), n (i) AS (SELECT $1 UNION ALL SELECT n.i + $2 FROM n) SELECT * FROM n LIMIT 5`,
+	},
+	{
+		Constructed: Select().From(Table1).Where(Table1.Column1.Eq(String("foo")), Table1.Column2.Eq(String("bar"))).
+			OrWhere(Table1.Column3.Gt(Int64(1))).AndWhere(Table1.BoolColumn.IsEq(true)),
+		ExpectedStmt: `SELECT * FROM public.table1 WHERE ((table1.column1 = $1 AND table1.column2 = $2) OR table1.column3 > $3) AND table1.bool_column = $4`,
+	},
 	//{
 	//	Select(TimeBucket5MinutesField, Table1.Column2.Avg()).From(Table1),
 	//	"SELECT time_bucket('5 minutes', "table1".creation_date) AS five_min, AVG("table1".column2) FROM public.table1",
@@ -213,3 +369,48 @@ var selectTestCases = []TestCase{
 func TestSelects(t *testing.T) {
 	runTestCases(t, selectTestCases)
 }
+
+func TestDerivedSelects(t *testing.T) {
+	base := Select().From(Table1).Where(Table1.Column1.Eq(String("foo")))
+	count := Select(Count(Asterisk)).From(Table1).Where(Table1.Column1.Eq(String("foo")))
+	joined := Select().From(Table1).Join(Table2).On(Table1.Column1.Eq(Table2.Column1))
+	compound := Select(Table1.Column1).From(Table1).Union(Select(Table2.Column1).From(Table2))
+	runTestCases(t, []TestCase{
+		{
+			Constructed:  base.AndWhere(Table1.Column2.Eq(String("bar"))).OrderBy(Table1.Column1).Limit(10),
+			ExpectedStmt: `SELECT * FROM public.table1 WHERE table1.column1 = $1 AND table1.column2 = $2 ORDER BY table1.column1 LIMIT 10`,
+		},
+		{
+			Constructed:  base.AndWhere(Table1.Column3.Gt(Int64(1))),
+			ExpectedStmt: `SELECT * FROM public.table1 WHERE table1.column1 = $1 AND table1.column3 > $2`,
+		},
+		{
+			Constructed:  count.AndWhere(Table1.BoolColumn.IsEq(true)),
+			ExpectedStmt: `SELECT COUNT(*) FROM public.table1 WHERE table1.column1 = $1 AND table1.bool_column = $2`,
+		},
+		{
+			Constructed:  base,
+			ExpectedStmt: `SELECT * FROM public.table1 WHERE table1.column1 = $1`,
+		},
+		{
+			Constructed:  joined.Join(Table3).On(Table2.Column2.Eq(Table3.Column2)),
+			ExpectedStmt: `SELECT * FROM public.table1 JOIN public.table2 ON table1.column1 = table2.column1 JOIN public.table3 ON table2.column2 = table3.column2`,
+		},
+		{
+			Constructed:  joined.LeftOuterJoin(Table3).On(Table1.Column2.Eq(Table3.Column2)),
+			ExpectedStmt: `SELECT * FROM public.table1 JOIN public.table2 ON table1.column1 = table2.column1 LEFT OUTER JOIN public.table3 ON table1.column2 = table3.column2`,
+		},
+		{
+			Constructed:  joined,
+			ExpectedStmt: `SELECT * FROM public.table1 JOIN public.table2 ON table1.column1 = table2.column1`,
+		},
+		{
+			Constructed:  compound.UnionAll(Select(Table3.Column1).From(Table3)),
+			ExpectedStmt: `SELECT table1.column1 FROM public.table1 UNION (SELECT table2.column1 FROM public.table2) UNION ALL (SELECT table3.column1 FROM public.table3)`,
+		},
+		{
+			Constructed:  compound,
+			ExpectedStmt: `SELECT table1.column1 FROM public.table1 UNION (SELECT table2.column1 FROM public.table2)`,
+		},
+	})
+}
@@ -0,0 +1,33 @@
+package gooq
+
+import (
+	"strings"
+)
+
+// SortFields maps the sort keys accepted from clients, e.g. the sort
+// parameter of an API, to the expressions they order by so that clients
+// cannot order by arbitrary expressions.
+type SortFields map[string]Expression
+
+// Orderings returns the ORDER BY expressions of keys in order. A key
+// prefixed by - orders in descending order, e.g. "-created_at". Empty keys
+// are skipped. It returns an *UnknownSortKeyError for a key that is not in
+// fields.
+func (fields SortFields) Orderings(keys ...string) ([]Expression, error) {
+	var orderings []Expression
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		name := strings.TrimPrefix(key, "-")
+		expression, ok := fields[name]
+		if !ok {
+			return nil, &UnknownSortKeyError{Key: key}
+		}
+		if name != key {
+			expression = expression.Desc()
+		}
+		orderings = append(orderings, expression)
+	}
+	return orderings, nil
+}
@@ -0,0 +1,40 @@
+package gooq
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testSortFields = SortFields{
+	"name":    Table1.Column1,
+	"count":   Table1.Column3,
+	"created": Table1.TimeColumn,
+}
+
+func TestSortFieldsOrderings(t *testing.T) {
+	orderings, err := testSortFields.Orderings("-count", "", "name")
+	require.NoError(t, err)
+	require.Equal(t, []Expression{Table1.Column3.Desc(), Table1.Column1}, orderings)
+
+	_, err = testSortFields.Orderings("name", "-password")
+	require.Equal(t, &UnknownSortKeyError{Key: "-password"}, err)
+}
+
+func TestOrderByKeys(t *testing.T) {
+	runTestCases(t, []TestCase{
+		{
+			Constructed:  Select().From(Table1).OrderByKeys(testSortFields, "-created", "name").Limit(10),
+			ExpectedStmt: `SELECT * FROM public.table1 ORDER BY table1.time_column DESC, table1.column1 LIMIT 10`,
+		},
+		{
+			Constructed:  Select().From(Table1).OrderByKeys(testSortFields),
+			ExpectedStmt: `SELECT * FROM public.table1`,
+		},
+		{
+			Constructed:  Select().From(Table1).OrderByKeys(testSortFields, "password"),
+			ExpectedStmt: `SELECT * FROM public.table1`,
+			Errors:       []error{&UnknownSortKeyError{Key: "password"}},
+		},
+	})
+}
@@ -6,13 +6,30 @@ import (
 	"gopkg.in/guregu/null.v3"
 )
 
-type Table interface {
+// View is a relation that can be selected from, e.g. a view or a
+// materialized view. Tables are views that can be modified as well.
+type View interface {
 	Named
 	Selectable
 	GetSchema() string
 	GetUnqualifiedName() string
 }
 
+// Table is a relation that can be inserted into, updated and deleted from.
+// It is implemented by embedding TableImpl, so that views cannot be passed to
+// InsertInto, Update, Delete or MergeInto.
+type Table interface {
+	View
+	isTable()
+}
+
+// MaterializedView is a view that can be refreshed, it is implemented by
+// embedding MaterializedViewImpl
+type MaterializedView interface {
+	View
+	isMaterializedView()
+}
+
 type TableImpl struct {
 	name   string
 	schema string
@@ -65,11 +82,71 @@ func (t TableImpl) GetSchema() string {
 	return t.schema
 }
 
+func (t TableImpl) isTable() {}
+
 func (t *TableImpl) Render(
 	builder *Builder,
 ) {
-	builder.Print(t.GetQualifiedName())
+	builder.Print(builder.quoteTableName(t))
 	if t.alias.Valid {
-		builder.Printf(" AS \"%s\"", t.alias.String)
+		builder.Printf(" AS %s", builder.QuoteIdentifier(t.alias.String))
 	}
 }
+
+// ViewImpl is embedded by the types of views
+type ViewImpl struct {
+	relation TableImpl
+}
+
+func NewView(schema, name string) *ViewImpl {
+	return &ViewImpl{relation: TableImpl{name: name, schema: schema}}
+}
+
+func (v *ViewImpl) Initialize(schema, name string) {
+	v.relation.Initialize(schema, name)
+}
+
+func (v *ViewImpl) As(alias string) *ViewImpl {
+	return &ViewImpl{relation: *v.relation.As(alias)}
+}
+
+func (v ViewImpl) GetAlias() null.String {
+	return v.relation.GetAlias()
+}
+
+func (v ViewImpl) GetName() string {
+	return v.relation.GetName()
+}
+
+func (v ViewImpl) GetQualifiedName() string {
+	return v.relation.GetQualifiedName()
+}
+
+func (v ViewImpl) GetUnqualifiedName() string {
+	return v.relation.GetUnqualifiedName()
+}
+
+func (v ViewImpl) GetSchema() string {
+	return v.relation.GetSchema()
+}
+
+func (v *ViewImpl) Render(
+	builder *Builder,
+) {
+	v.relation.Render(builder)
+}
+
+// MaterializedViewImpl is embedded by the types of materialized views
+type MaterializedViewImpl struct {
+	ViewImpl
+}
+
+func NewMaterializedView(schema, name string) *MaterializedViewImpl {
+	return &MaterializedViewImpl{ViewImpl: *NewView(schema, name)}
+}
+
+func (v *MaterializedViewImpl) As(alias string) *MaterializedViewImpl {
+	return &MaterializedViewImpl{ViewImpl: *v.ViewImpl.As(alias)}
+}
+
+func (v MaterializedViewImpl) isMaterializedView() {}
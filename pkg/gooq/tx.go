@@ -0,0 +1,178 @@
+package gooq
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+var (
+	managedTxError = fmt.Errorf("gooq: the transaction is committed or rolled back by RunInTx")
+)
+
+// TxBeginner is implemented by *sqlx.DB and *sqlx.Conn
+type TxBeginner interface {
+	BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error)
+}
+
+type TxOptions struct {
+	Isolation sql.IsolationLevel
+	ReadOnly  bool
+	// MaxRetries is the number of times the transaction is retried after a
+	// serialization failure (40001) or a deadlock (40P01)
+	MaxRetries int
+	// Backoff returns the delay before the given retry, starting at 1.
+	// Defaults to DefaultTxBackoff.
+	Backoff func(retry int) time.Duration
+}
+
+// maxTxBackoff caps the delay of DefaultTxBackoff, which would otherwise
+// overflow after a few dozen retries
+const maxTxBackoff = 5 * time.Second
+
+// DefaultTxBackoff doubles the delay on every retry starting at 10ms, up to 5s
+func DefaultTxBackoff(retry int) time.Duration {
+	delay := 10 * time.Millisecond
+	for ; retry > 1 && delay < maxTxBackoff; retry-- {
+		delay *= 2
+	}
+	if delay > maxTxBackoff {
+		return maxTxBackoff
+	}
+	return delay
+}
+
+// RunInTx runs fn in a transaction that is committed when fn returns nil and
+// rolled back when it returns an error or panics. Calling RunInTx with the tx
+// passed to fn (or any other TxInterface) runs the nested fn in a SAVEPOINT
+// instead, which is rolled back on its own when the nested fn fails. The
+// isolation level, read-only and retry options only apply to the outermost
+// transaction since a serialization failure aborts the whole transaction.
+func RunInTx(
+	ctx context.Context, db DBInterface, opts *TxOptions, fn func(tx TxInterface) error,
+) error {
+	if opts == nil {
+		opts = &TxOptions{}
+	}
+	switch db := db.(type) {
+	case *transaction:
+		return runInSavepoint(ctx, db, fn)
+	case *hookedDB:
+		return RunInTx(ctx, db.DBInterface, opts, func(tx TxInterface) error {
+			return fn(&transaction{TxInterface: &hookedTx{
+				hookedDB: hookedDB{DBInterface: tx, hooks: db.hooks}, tx: tx,
+			}})
+		})
+	case TxInterface:
+		return runInSavepoint(ctx, &transaction{TxInterface: db}, fn)
+	case TxBeginner:
+		return runInRetryableTx(ctx, db, opts, fn)
+	default:
+		return fmt.Errorf("gooq: %T cannot begin a transaction", db)
+	}
+}
+
+func runInRetryableTx(
+	ctx context.Context, db TxBeginner, opts *TxOptions, fn func(tx TxInterface) error,
+) error {
+	backoff := opts.Backoff
+	if backoff == nil {
+		backoff = DefaultTxBackoff
+	}
+	for retry := 0; ; retry++ {
+		err := runInTx(ctx, db, opts, fn)
+		if err == nil || retry >= opts.MaxRetries || !IsRetryableTxError(err) {
+			return err
+		}
+		timer := time.NewTimer(backoff(retry + 1))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func runInTx(
+	ctx context.Context, db TxBeginner, opts *TxOptions, fn func(tx TxInterface) error,
+) error {
+	tx, err := db.BeginTxx(ctx, &sql.TxOptions{
+		Isolation: opts.Isolation,
+		ReadOnly:  opts.ReadOnly,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+	if err := fn(&transaction{TxInterface: tx}); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rollbackErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+func runInSavepoint(
+	ctx context.Context, parent *transaction, fn func(tx TxInterface) error,
+) error {
+	nested := &transaction{TxInterface: parent.TxInterface, depth: parent.depth + 1}
+	savepoint := fmt.Sprintf("gooq_savepoint_%d", nested.depth)
+	if _, err := parent.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = parent.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepoint)
+			panic(p)
+		}
+	}()
+	if err := fn(nested); err != nil {
+		if _, rollbackErr := parent.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepoint); rollbackErr != nil {
+			return fmt.Errorf("%w (rollback to savepoint failed: %v)", err, rollbackErr)
+		}
+		return err
+	}
+	_, err := parent.ExecContext(ctx, "RELEASE SAVEPOINT "+savepoint)
+	return err
+}
+
+// IsRetryableTxError reports whether err is a serialization failure or a
+// deadlock, after which the whole transaction can be retried
+func IsRetryableTxError(err error) bool {
+	var sqlState string
+	var pqErr *pq.Error
+	var stateErr interface{ SQLState() string }
+	if errors.As(err, &pqErr) {
+		sqlState = string(pqErr.Code)
+	} else if errors.As(err, &stateErr) {
+		sqlState = stateErr.SQLState()
+	}
+	return sqlState == "40001" || sqlState == "40P01"
+}
+
+// transaction is the TxInterface passed to the function of RunInTx. depth is
+// the number of enclosing savepoints.
+type transaction struct {
+	TxInterface
+	depth int
+}
+
+func (tx *transaction) Commit() error {
+	return managedTxError
+}
+
+func (tx *transaction) Rollback() error {
+	return managedTxError
+}
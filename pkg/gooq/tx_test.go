@@ -0,0 +1,328 @@
+package gooq
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingConnector is a database/sql driver that records the statements,
+// their arguments and the transaction boundaries it receives. failOn returns
+// the error a statement (or BEGIN, COMMIT, ROLLBACK and PREPARE) fails with.
+type recordingConnector struct {
+	log       []string
+	arguments [][]interface{}
+	failOn    func(stmt string) error
+}
+
+func newRecordingDB(failOn func(stmt string) error) (*sqlx.DB, *recordingConnector) {
+	connector := &recordingConnector{failOn: failOn}
+	return sqlx.NewDb(sql.OpenDB(connector), "postgres"), connector
+}
+
+func (c *recordingConnector) record(stmt string) error {
+	c.log = append(c.log, stmt)
+	if c.failOn != nil {
+		return c.failOn(stmt)
+	}
+	return nil
+}
+
+func (c *recordingConnector) recordArguments(args []driver.NamedValue) {
+	values := []interface{}{}
+	for _, arg := range args {
+		values = append(values, arg.Value)
+	}
+	c.arguments = append(c.arguments, values)
+}
+
+func (c *recordingConnector) Connect(context.Context) (driver.Conn, error) {
+	return &recordingConn{connector: c}, nil
+}
+
+func (c *recordingConnector) Driver() driver.Driver {
+	return nil
+}
+
+type recordingConn struct {
+	connector *recordingConnector
+}
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	if err := c.connector.record("PREPARE " + query); err != nil {
+		return nil, err
+	}
+	return &recordingStmt{connector: c.connector, query: query}, nil
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *recordingConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	stmt := "BEGIN"
+	if opts.Isolation != driver.IsolationLevel(sql.LevelDefault) {
+		stmt += " ISOLATION LEVEL " + strings.ToUpper(sql.IsolationLevel(opts.Isolation).String())
+	}
+	if opts.ReadOnly {
+		stmt += " READ ONLY"
+	}
+	if err := c.connector.record(stmt); err != nil {
+		return nil, err
+	}
+	return &recordingTx{connector: c.connector}, nil
+}
+
+func (c *recordingConn) ExecContext(
+	ctx context.Context, query string, args []driver.NamedValue,
+) (driver.Result, error) {
+	if err := c.connector.record(query); err != nil {
+		return nil, err
+	}
+	c.connector.recordArguments(args)
+	return driver.RowsAffected(1), nil
+}
+
+func (c *recordingConn) QueryContext(
+	ctx context.Context, query string, args []driver.NamedValue,
+) (driver.Rows, error) {
+	if err := c.connector.record(query); err != nil {
+		return nil, err
+	}
+	c.connector.recordArguments(args)
+	return &emptyRows{}, nil
+}
+
+type recordingStmt struct {
+	connector *recordingConnector
+	query     string
+}
+
+func (s *recordingStmt) Close() error {
+	return nil
+}
+
+func (s *recordingStmt) NumInput() int {
+	return -1
+}
+
+func (s *recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, fmt.Errorf("use ExecContext")
+}
+
+func (s *recordingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, fmt.Errorf("use QueryContext")
+}
+
+func (s *recordingStmt) ExecContext(
+	ctx context.Context, args []driver.NamedValue,
+) (driver.Result, error) {
+	return (&recordingConn{connector: s.connector}).ExecContext(ctx, s.query, args)
+}
+
+func (s *recordingStmt) QueryContext(
+	ctx context.Context, args []driver.NamedValue,
+) (driver.Rows, error) {
+	return (&recordingConn{connector: s.connector}).QueryContext(ctx, s.query, args)
+}
+
+type recordingTx struct {
+	connector *recordingConnector
+}
+
+func (tx *recordingTx) Commit() error {
+	return tx.connector.record("COMMIT")
+}
+
+func (tx *recordingTx) Rollback() error {
+	return tx.connector.record("ROLLBACK")
+}
+
+type emptyRows struct{}
+
+func (r *emptyRows) Columns() []string {
+	return []string{"count"}
+}
+
+func (r *emptyRows) Close() error {
+	return nil
+}
+
+func (r *emptyRows) Next(dest []driver.Value) error {
+	return io.EOF
+}
+
+func TestDefaultTxBackoff(t *testing.T) {
+	require.Equal(t, 10*time.Millisecond, DefaultTxBackoff(0))
+	require.Equal(t, 10*time.Millisecond, DefaultTxBackoff(1))
+	require.Equal(t, 80*time.Millisecond, DefaultTxBackoff(4))
+	require.Equal(t, 5*time.Second, DefaultTxBackoff(10))
+	require.Equal(t, 5*time.Second, DefaultTxBackoff(64))
+	require.Equal(t, 5*time.Second, DefaultTxBackoff(math.MaxInt32))
+}
+
+func TestRunInTx(t *testing.T) {
+	ctx := context.Background()
+	failure := fmt.Errorf("failure")
+	insertStmt := InsertInto(Table1).Set(Table1.Column1, "foo")
+
+	t.Run("commit", func(t *testing.T) {
+		db, connector := newRecordingDB(nil)
+		err := RunInTx(ctx, db, nil, func(tx TxInterface) error {
+			_, err := insertStmt.ExecWithContext(ctx, Postgres, tx)
+			return err
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"BEGIN",
+			`INSERT INTO public.table1 (column1) VALUES ($1)`,
+			"COMMIT",
+		}, connector.log)
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		db, connector := newRecordingDB(nil)
+		err := RunInTx(ctx, db, &TxOptions{Isolation: sql.LevelSerializable, ReadOnly: true},
+			func(tx TxInterface) error {
+				return failure
+			})
+		require.Equal(t, failure, err)
+		require.Equal(t, []string{"BEGIN ISOLATION LEVEL SERIALIZABLE READ ONLY", "ROLLBACK"}, connector.log)
+	})
+
+	t.Run("rollback on panic", func(t *testing.T) {
+		db, connector := newRecordingDB(nil)
+		require.PanicsWithValue(t, "boom", func() {
+			_ = RunInTx(ctx, db, nil, func(tx TxInterface) error {
+				panic("boom")
+			})
+		})
+		require.Equal(t, []string{"BEGIN", "ROLLBACK"}, connector.log)
+	})
+
+	t.Run("commit and rollback are managed", func(t *testing.T) {
+		db, _ := newRecordingDB(nil)
+		err := RunInTx(ctx, db, nil, func(tx TxInterface) error {
+			return tx.Commit()
+		})
+		require.Equal(t, managedTxError, err)
+	})
+
+	t.Run("nested savepoints", func(t *testing.T) {
+		db, connector := newRecordingDB(nil)
+		err := RunInTx(ctx, db, nil, func(tx TxInterface) error {
+			require.NoError(t, RunInTx(ctx, tx, nil, func(tx TxInterface) error {
+				return RunInTx(ctx, tx, nil, func(tx TxInterface) error {
+					return nil
+				})
+			}))
+			nestedErr := RunInTx(ctx, tx, nil, func(tx TxInterface) error {
+				return failure
+			})
+			require.Equal(t, failure, nestedErr)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"BEGIN",
+			"SAVEPOINT gooq_savepoint_1",
+			"SAVEPOINT gooq_savepoint_2",
+			"RELEASE SAVEPOINT gooq_savepoint_2",
+			"RELEASE SAVEPOINT gooq_savepoint_1",
+			"SAVEPOINT gooq_savepoint_1",
+			"ROLLBACK TO SAVEPOINT gooq_savepoint_1",
+			"COMMIT",
+		}, connector.log)
+	})
+
+	t.Run("nested panic", func(t *testing.T) {
+		db, connector := newRecordingDB(nil)
+		require.Panics(t, func() {
+			_ = RunInTx(ctx, db, nil, func(tx TxInterface) error {
+				return RunInTx(ctx, tx, nil, func(tx TxInterface) error {
+					panic("boom")
+				})
+			})
+		})
+		require.Equal(t, []string{
+			"BEGIN",
+			"SAVEPOINT gooq_savepoint_1",
+			"ROLLBACK TO SAVEPOINT gooq_savepoint_1",
+			"ROLLBACK",
+		}, connector.log)
+	})
+
+	t.Run("retry serialization failures", func(t *testing.T) {
+		commits := 0
+		db, connector := newRecordingDB(func(stmt string) error {
+			if stmt != "COMMIT" {
+				return nil
+			}
+			commits++
+			if commits == 1 {
+				return &pq.Error{Code: "40001"}
+			}
+			return nil
+		})
+		attempts := 0
+		var delays []time.Duration
+		err := RunInTx(ctx, db, &TxOptions{
+			MaxRetries: 3,
+			Backoff: func(retry int) time.Duration {
+				delays = append(delays, DefaultTxBackoff(retry))
+				return time.Millisecond
+			},
+		}, func(tx TxInterface) error {
+			attempts++
+			if attempts == 1 {
+				return fmt.Errorf("insert: %w", &pq.Error{Code: "40P01"})
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, attempts)
+		require.Equal(t, []time.Duration{10 * time.Millisecond, 20 * time.Millisecond}, delays)
+		require.Equal(t, []string{"BEGIN", "ROLLBACK", "BEGIN", "COMMIT", "BEGIN", "COMMIT"}, connector.log)
+	})
+
+	t.Run("give up after max retries", func(t *testing.T) {
+		db, _ := newRecordingDB(nil)
+		attempts := 0
+		serializationFailure := &pq.Error{Code: "40001"}
+		err := RunInTx(ctx, db, &TxOptions{
+			MaxRetries: 2,
+			Backoff:    func(int) time.Duration { return 0 },
+		}, func(tx TxInterface) error {
+			attempts++
+			return serializationFailure
+		})
+		require.True(t, errors.Is(err, serializationFailure))
+		require.Equal(t, 3, attempts)
+	})
+
+	t.Run("do not retry other errors", func(t *testing.T) {
+		db, _ := newRecordingDB(nil)
+		attempts := 0
+		err := RunInTx(ctx, db, &TxOptions{MaxRetries: 2}, func(tx TxInterface) error {
+			attempts++
+			return &pq.Error{Code: "23505"}
+		})
+		require.Error(t, err)
+		require.Equal(t, 1, attempts)
+	})
+}
@@ -21,6 +21,12 @@ type Selectable interface {
 	Renderable
 }
 
+// Buildable statements render themselves, with their arguments, for a
+// specific dialect
+type Buildable interface {
+	Build(Dialect) *Builder
+}
+
 type DatabaseConstraint struct {
 	Name      string
 	Columns   []Field
@@ -47,9 +53,19 @@ type Executable interface {
 	ExecWithContext(context.Context, Dialect, DBInterface) (sql.Result, error)
 }
 
+// Fetchable runs a statement that returns rows. FetchRow does not report the
+// errors of building the statement since a *sqlx.Row cannot carry them, see
+// RowFetchable.
 type Fetchable interface {
 	Fetch(Dialect, DBInterface) (*sqlx.Rows, error)
 	FetchRow(Dialect, DBInterface) *sqlx.Row
 	FetchWithContext(context.Context, Dialect, DBInterface) (*sqlx.Rows, error)
 	FetchRowWithContext(context.Context, Dialect, DBInterface) *sqlx.Row
 }
+
+// RowFetchable fetches a single row like FetchRow, but reports the errors of
+// building the statement instead of running it
+type RowFetchable interface {
+	FetchOne(Dialect, DBInterface) (*sqlx.Row, error)
+	FetchOneWithContext(context.Context, Dialect, DBInterface) (*sqlx.Row, error)
+}
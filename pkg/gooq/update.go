@@ -10,22 +10,55 @@ import (
 type UpdateSetStep interface {
 	UpdateFromStep
 	Set(f Field, v interface{}) UpdateSetStep
+	// SetRow assigns the columns of the single row returned by query to
+	// fields, i.e. SET (field1, field2) = (SELECT ...)
+	SetRow(fields []Field, query Selectable) UpdateSetStep
+	// OldValues makes the values of the updated rows from before the update
+	// available through old, an alias of the updated table, e.g. to return
+	// them along with the new values:
+	//
+	//	old := Person.As("old")
+	//	Update(Person).OldValues(old, Person.ID).Set(Person.Name, "Alice").
+	//		Where(Person.ID.Eq(UUID(id))).Returning(old.Name, Person.Name)
+	//
+	// The rows to update are selected FOR UPDATE by a common table expression
+	// named after the alias with the conditions given to Where, which must
+	// only reference the updated table, and are joined on keys (e.g. the
+	// primary key).
+	OldValues(old Table, keys ...Field) UpdateSetStep
 }
 
 type UpdateFromStep interface {
 	UpdateWhereStep
-	From(Selectable) UpdateWhereStep
+	// From adds the tables (or subqueries) that can be referenced by the
+	// SET and WHERE clauses, e.g. an alias of the updated table for a
+	// self-join
+	From(...Selectable) UpdateJoinStep
+}
+
+type UpdateJoinStep interface {
+	UpdateWhereStep
+	Join(Selectable) UpdateOnStep
+	LeftOuterJoin(Selectable) UpdateOnStep
+}
+
+type UpdateOnStep interface {
+	On(...Expression) UpdateJoinStep
 }
 
 type UpdateWhereStep interface {
-	UpdateOnConflictStep
-	Where(conditions ...Expression) UpdateOnConflictStep
+	UpdateReturningStep
+	Where(conditions ...Expression) UpdateConditionStep
+	WhereIf(cond bool, conditions ...Expression) UpdateConditionStep
+	// WhereCurrentOf updates the row the cursor is positioned on
+	WhereCurrentOf(cursor string) UpdateReturningStep
 }
 
-type UpdateOnConflictStep interface {
+type UpdateConditionStep interface {
 	UpdateReturningStep
-	OnConflictDoNothing() UpdateReturningStep
-	OnConflictDoUpdate() UpdateReturningStep
+	AndWhere(conditions ...Expression) UpdateConditionStep
+	OrWhere(conditions ...Expression) UpdateConditionStep
+	WhereIf(cond bool, conditions ...Expression) UpdateConditionStep
 }
 
 type UpdateReturningStep interface {
@@ -34,11 +67,14 @@ type UpdateReturningStep interface {
 }
 
 type UpdateResultStep interface {
+	Buildable
 	Fetchable
+	RowFetchable
 	Renderable
 }
 
 type UpdateFinalStep interface {
+	Buildable
 	Executable
 	Renderable
 }
@@ -50,48 +86,128 @@ type UpdateFinalStep interface {
 type setPredicate struct {
 	field Field
 	value interface{}
+	// row is set instead of field by SetRow
+	row []Field
+}
+
+// oldValues are the values of the updated rows from before the update
+type oldValues struct {
+	table Table
+	keys  []Field
 }
 
 type update struct {
-	table          Table
-	setPredicates  []setPredicate // set predicates
-	conditions     []Expression   // where conditions
-	fromSelection  Selectable     // selection for from clause
-	conflictAction ConflictAction
-	returning      []Expression
+	with          withClause
+	table         Table
+	setPredicates []setPredicate // set predicates
+	conditions    []Expression   // where conditions
+	cursor        string         // WHERE CURRENT OF cursor
+	from          []Selectable   // selections for from clause
+	joins         []join
+	joinTarget    Selectable
+	joinType      JoinType
+	old           *oldValues
+	returning     []Expression
 }
 
 func Update(t Table) UpdateSetStep {
 	return &update{table: t}
 }
 
+// clone returns a copy of u for a step to modify, see selection.clone
+func (u *update) clone() *update {
+	c := *u
+	c.with = u.with.clone()
+	c.setPredicates = c.setPredicates[:len(c.setPredicates):len(c.setPredicates)]
+	c.conditions = c.conditions[:len(c.conditions):len(c.conditions)]
+	c.from = c.from[:len(c.from):len(c.from)]
+	c.joins = c.joins[:len(c.joins):len(c.joins)]
+	return &c
+}
+
 func (u *update) Set(field Field, value interface{}) UpdateSetStep {
-	u.setPredicates = append(u.setPredicates, setPredicate{field, value})
+	u = u.clone()
+	u.setPredicates = append(u.setPredicates, setPredicate{field: field, value: value})
+	return u
+}
+
+func (u *update) SetRow(fields []Field, query Selectable) UpdateSetStep {
+	u = u.clone()
+	u.setPredicates = append(u.setPredicates, setPredicate{row: fields, value: query})
+	return u
+}
+
+func (u *update) OldValues(old Table, keys ...Field) UpdateSetStep {
+	u = u.clone()
+	u.old = &oldValues{table: old, keys: keys}
+	return u
+}
+
+func (u *update) From(s ...Selectable) UpdateJoinStep {
+	u = u.clone()
+	u.from = append(u.from, s...)
 	return u
 }
 
-func (u *update) From(s Selectable) UpdateWhereStep {
-	u.fromSelection = s
+func (u *update) Join(t Selectable) UpdateOnStep {
+	u = u.clone()
+	u.joinTarget = t
+	u.joinType = Join
 	return u
 }
 
-func (u *update) Where(c ...Expression) UpdateOnConflictStep {
-	u.conditions = c
+func (u *update) LeftOuterJoin(t Selectable) UpdateOnStep {
+	u = u.clone()
+	u.joinTarget = t
+	u.joinType = LeftOuterJoin
 	return u
 }
 
-func (u *update) OnConflictDoNothing() UpdateReturningStep {
-	u.conflictAction = ConflictActionDoNothing
+func (u *update) On(c ...Expression) UpdateJoinStep {
+	u = u.clone()
+	u.joins = append(u.joins, join{target: u.joinTarget, joinType: u.joinType, conditions: c})
+	u.joinTarget = nil
+	u.joinType = NotJoined
 	return u
 }
 
-func (u *update) OnConflictDoUpdate() UpdateReturningStep {
-	u.conflictAction = ConflictActionDoUpdate
-	panic("not implemented")
+// Where adds conditions to the WHERE clause, they are combined with AND with
+// each other and with the conditions given before
+func (u *update) Where(c ...Expression) UpdateConditionStep {
+	return u.AndWhere(c...)
+}
+
+func (u *update) AndWhere(c ...Expression) UpdateConditionStep {
+	u = u.clone()
+	u.conditions = append(u.conditions, c...)
+	return u
+}
+
+// OrWhere replaces the WHERE clause by (<conditions given before> OR
+// <conditions>)
+func (u *update) OrWhere(c ...Expression) UpdateConditionStep {
+	u = u.clone()
+	u.conditions = orConditions(u.conditions, c)
+	return u
+}
+
+// WhereIf adds conditions to the WHERE clause like Where only when cond is
+// true
+func (u *update) WhereIf(cond bool, c ...Expression) UpdateConditionStep {
+	if !cond {
+		return u
+	}
+	return u.AndWhere(c...)
+}
+
+func (u *update) WhereCurrentOf(cursor string) UpdateReturningStep {
+	u = u.clone()
+	u.cursor = cursor
 	return u
 }
 
 func (u *update) Returning(f ...Expression) UpdateResultStep {
+	u = u.clone()
 	u.returning = f
 	return u
 }
@@ -102,13 +218,19 @@ func (u *update) Returning(f ...Expression) UpdateResultStep {
 
 func (u *update) Exec(dl Dialect, db DBInterface) (sql.Result, error) {
 	builder := u.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
 	return db.Exec(builder.String(), builder.arguments...)
 }
 
 func (u *update) ExecWithContext(
 	ctx context.Context, dl Dialect, db DBInterface) (sql.Result, error) {
 	builder := u.Build(dl)
-	return db.ExecContext(ctx, builder.String(), builder.arguments...)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return withContextHooks(ctx, db).ExecContext(ctx, builder.String(), builder.arguments...)
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -117,6 +239,9 @@ func (u *update) ExecWithContext(
 
 func (u *update) Fetch(dl Dialect, db DBInterface) (*sqlx.Rows, error) {
 	builder := u.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
 	return db.Queryx(builder.String(), builder.arguments...)
 }
 
@@ -125,33 +250,65 @@ func (u *update) FetchRow(dl Dialect, db DBInterface) *sqlx.Row {
 	return db.QueryRowx(builder.String(), builder.arguments...)
 }
 
+func (u *update) FetchOne(dl Dialect, db DBInterface) (*sqlx.Row, error) {
+	builder := u.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return db.QueryRowx(builder.String(), builder.arguments...), nil
+}
+
 func (u *update) FetchWithContext(
 	ctx context.Context, dl Dialect, db DBInterface) (*sqlx.Rows, error) {
 	builder := u.Build(dl)
-	return db.QueryxContext(ctx, builder.String(), builder.arguments...)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return withContextHooks(ctx, db).QueryxContext(ctx, builder.String(), builder.arguments...)
 }
 
 func (u *update) FetchRowWithContext(
 	ctx context.Context, dl Dialect, db DBInterface) *sqlx.Row {
 	builder := u.Build(dl)
-	return db.QueryRowxContext(ctx, builder.String(), builder.arguments...)
+	return withContextHooks(ctx, db).QueryRowxContext(ctx, builder.String(), builder.arguments...)
+}
+
+func (u *update) FetchOneWithContext(
+	ctx context.Context, dl Dialect, db DBInterface) (*sqlx.Row, error) {
+	builder := u.Build(dl)
+	if err := builder.Err(); err != nil {
+		return nil, err
+	}
+	return withContextHooks(ctx, db).QueryRowxContext(ctx, builder.String(), builder.arguments...), nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////
 // Renderable
 ///////////////////////////////////////////////////////////////////////////////
 
-func (u *update) Build(d Dialect) *Builder {
-	builder := Builder{}
-	u.Render(&builder)
-	return &builder
+func (u *update) Build(dl Dialect) *Builder {
+	builder := NewBuilder(dl)
+	u.Render(builder)
+	return builder
 }
 
 func (u *update) Render(
 	builder *Builder,
 ) {
-	// UPDATE table_name SET
-	builder.Printf("UPDATE %s", u.table.GetQualifiedName())
+	from := u.from
+	with := u.with
+	if u.old != nil {
+		with = with.clone()
+		with.addCTEs(u.oldValuesCTE(builder))
+		from = append([]Selectable{keyword(builder.QuoteIdentifier(u.old.name()))}, from...)
+	}
+
+	// [ WITH [ RECURSIVE ] with_query [, ...] ]
+	with.render(builder)
+
+	// UPDATE [ ONLY ] table_name [ * ] [ [ AS ] alias ] SET
+	builder.Print("UPDATE ")
+	u.table.Render(builder)
 
 	if len(u.setPredicates) > 0 {
 		// render SET clause
@@ -159,26 +316,76 @@ func (u *update) Render(
 		builder.RenderSetPredicates(u.setPredicates)
 	}
 
-	if u.fromSelection != nil {
-		// render WHERE clause
+	if len(from) > 0 && builder.requireFeature(DialectFeatureUpdateFrom) {
+		// render FROM clause
 		builder.Print(" FROM ")
-		u.fromSelection.Render(builder)
+		for index, selectable := range from {
+			selectable.Render(builder)
+			if index != len(from)-1 {
+				builder.Print(", ")
+			}
+		}
+		renderJoins(builder, u.joins)
 	}
 
-	if len(u.conditions) > 0 {
+	conditions := withoutNoops(u.conditions)
+	if u.old != nil {
+		conditions = append(u.old.joinConditions(builder, u.table), conditions...)
+	}
+	if u.cursor != "" {
+		// render WHERE CURRENT OF clause
+		if builder.requireFeature(DialectFeatureWhereCurrentOf) {
+			builder.Printf(" WHERE CURRENT OF %s", builder.QuoteIdentifier(u.cursor))
+		}
+	} else if len(conditions) > 0 {
 		// render WHERE clause
 		builder.Print(" WHERE ")
-		builder.RenderConditions(u.conditions)
-	}
-
-	// render on conflict
-	if u.conflictAction != ConflictActionNil {
-		builder.Printf(" ON CONFLICT %s", string(u.conflictAction))
+		builder.RenderConditions(conditions)
 	}
 
 	// render returning
-	if u.returning != nil {
+	if u.returning != nil && builder.requireFeature(DialectFeatureReturning) {
 		builder.Print(" RETURNING ")
 		builder.RenderExpressions(u.returning)
 	}
 }
+
+// oldValuesCTE selects the rows to update, locking them so that they cannot
+// change before they are updated
+func (u *update) oldValuesCTE(
+	builder *Builder,
+) CommonTableExpression {
+	if u.cursor != "" {
+		builder.errors = append(builder.errors, &UnsupportedClauseError{
+			Statement: "UPDATE ... WHERE CURRENT OF",
+			Clause:    "old values",
+		})
+	}
+	if len(u.old.keys) == 0 {
+		builder.errors = append(builder.errors, &MissingOldValuesKeyError{Table: u.old.name()})
+	}
+	var query SelectFinalStep = Select().From(u.table).Where(u.conditions...)
+	if builder.getDialect().dialect.Supports(DialectFeatureRowLocking) {
+		query = query.For(LockingTypeUpdate, LockingOptionNone)
+	}
+	return CTE(u.old.name(), query)
+}
+
+func (old *oldValues) name() string {
+	return old.table.GetUnqualifiedName()
+}
+
+// joinConditions join the updated rows with their old values on the keys,
+// i.e. table.key = old.key
+func (old *oldValues) joinConditions(
+	builder *Builder, table Table,
+) []Expression {
+	var conditions []Expression
+	for _, key := range old.keys {
+		column := builder.QuoteIdentifier(key.GetName())
+		conditions = append(conditions, newBinaryBooleanExpressionImpl(OperatorEq,
+			keyword(builder.QuoteIdentifier(table.GetUnqualifiedName())+"."+column),
+			keyword(builder.QuoteIdentifier(old.name())+"."+column)))
+	}
+	return conditions
+}
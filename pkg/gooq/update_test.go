@@ -9,36 +9,122 @@ var updateTestCases = []TestCase{
 	},
 	{
 		Constructed:  Update(Table1).Set(Table1.Column3, Table1.Column4.Add(Int64(10))),
-		ExpectedStmt: `UPDATE public.table1 SET column3 = "table1".column4 + $1`,
+		ExpectedStmt: `UPDATE public.table1 SET column3 = table1.column4 + $1`,
 	},
 	{
 		Constructed:  Update(Table1).Set(Table1.Column3, Select().From(Table2)),
 		ExpectedStmt: `UPDATE public.table1 SET column3 = (SELECT * FROM public.table2)`,
 	},
+	{
+		Constructed: Update(Table1).Set(Table1.Column3,
+			CaseOf(Table1.Column1).WhenNumeric(String("a"), Int64(1)).Else(Table1.Column3)),
+		ExpectedStmt: `UPDATE public.table1 SET column3 = CASE table1.column1 WHEN $1 THEN $2 ELSE table1.column3 END`,
+	},
 	{
 		Constructed:  Update(Table1).Set(Table1.Column1, "10").Where(Table1.Column2.Eq(String("foo"))),
-		ExpectedStmt: `UPDATE public.table1 SET column1 = $1 WHERE "table1".column2 = $2`,
+		ExpectedStmt: `UPDATE public.table1 SET column1 = $1 WHERE table1.column2 = $2`,
 	},
 	{
 		Constructed: Update(Table1).Set(Table1.Column1, Table2.Column1).
 			From(Table2).Where(Table1.Column2.Eq(Table2.Column2)),
-		ExpectedStmt: `UPDATE public.table1 SET column1 = "table2".column1 FROM public.table2 WHERE "table1".column2 = "table2".column2`,
+		ExpectedStmt: `UPDATE public.table1 SET column1 = table2.column1 FROM public.table2 WHERE table1.column2 = table2.column2`,
 	},
 	{
 		Constructed: Update(Table1).Set(Table1.Column1, Table2.Column1).
 			From(Select().From(Table2).As("foo")).Where(Table1.Column2.Eq(Table2.Column2)),
-		ExpectedStmt: `UPDATE public.table1 SET column1 = "table2".column1 FROM (SELECT * FROM public.table2) AS "foo" WHERE "table1".column2 = "table2".column2`,
+		ExpectedStmt: `UPDATE public.table1 SET column1 = table2.column1 FROM (SELECT * FROM public.table2) AS foo WHERE table1.column2 = table2.column2`,
 	},
 	{
-		Constructed:  Update(Table1).Set(Table1.Column1, "10").OnConflictDoNothing(),
-		ExpectedStmt: `UPDATE public.table1 SET column1 = $1 ON CONFLICT DO NOTHING`,
+		Constructed: With("src", Select(Table2.Column1, Table2.Column2).From(Table2)).
+			Update(Table1).Set(Table1.Column1, NewStringField(NewTable("", "src"), "column1")).
+			From(NewTable("", "src")).Where(Table1.Column2.Eq(NewStringField(NewTable("", "src"), "column2"))),
+		ExpectedStmt: `WITH src AS (SELECT table2.column1, table2.column2 FROM public.table2) UPDATE public.table1 SET column1 = src.column1 FROM src WHERE table1.column2 = src.column2`,
 	},
 	{
 		Constructed:  Update(Table1).Set(Table1.Column1, "10").Returning(Table1.Column1),
-		ExpectedStmt: `UPDATE public.table1 SET column1 = $1 RETURNING "table1".column1`,
+		ExpectedStmt: `UPDATE public.table1 SET column1 = $1 RETURNING table1.column1`,
+	},
+	{
+		Constructed:  Update(aliasedTable1).Set(aliasedTable1.Column1, "10").Where(aliasedTable1.Column2.Eq(String("foo"))),
+		ExpectedStmt: `UPDATE public.table1 AS t SET column1 = $1 WHERE t.column2 = $2`,
+	},
+	{
+		Constructed: Update(Table1).Set(Table1.Column3, aliasedTable1.Column3).
+			From(aliasedTable1).Where(aliasedTable1.ID.Eq(Table1.ID)),
+		ExpectedStmt: `UPDATE public.table1 SET column3 = t.column3 FROM public.table1 AS t WHERE t.id = table1.id`,
+	},
+	{
+		Constructed: Update(Table1).Set(Table1.Column1, Table2.Column1).Set(Table1.Column2, Table3.Column2).
+			From(Table2, Table3).Where(Table1.ID.Eq(Table2.ID), Table2.Column3.Eq(Table3.Column3)),
+		ExpectedStmt: `UPDATE public.table1 SET column1 = table2.column1, column2 = table3.column2 FROM public.table2, public.table3 WHERE table1.id = table2.id AND table2.column3 = table3.column3`,
+	},
+	{
+		Constructed: Update(Table1).Set(Table1.Column1, Table3.Column1).
+			From(Table2).Join(Table3).On(Table2.Column2.Eq(Table3.Column2)).
+			LeftOuterJoin(aliasedTable1).On(aliasedTable1.ID.Eq(Table3.ID)).
+			Where(Table1.ID.Eq(Table2.ID)),
+		ExpectedStmt: `UPDATE public.table1 SET column1 = table3.column1 FROM public.table2 JOIN public.table3 ON table2.column2 = table3.column2 LEFT OUTER JOIN public.table1 AS t ON t.id = table3.id WHERE table1.id = table2.id`,
+	},
+	{
+		Constructed: Update(Table1).
+			SetRow([]Field{Table1.Column1, Table1.Column3}, Select(Table2.Column1, Table2.Column3).From(Table2).Where(Table2.ID.Eq(Table1.ID))).
+			Set(Table1.Column2, "foo"),
+		ExpectedStmt: `UPDATE public.table1 SET (column1, column3) = (SELECT table2.column1, table2.column3 FROM public.table2 WHERE table2.id = table1.id), column2 = $1`,
+	},
+	{
+		Constructed:  Update(Table1).Set(Table1.Column1, "10").WhereCurrentOf("table1_cursor"),
+		ExpectedStmt: `UPDATE public.table1 SET column1 = $1 WHERE CURRENT OF table1_cursor`,
+	},
+	{
+		Constructed: Update(Table1).OldValues(oldTable1, Table1.ID).Set(Table1.Column1, "bar").
+			Where(Table1.Column2.Eq(String("foo"))).Returning(oldTable1.Column1, Table1.Column1),
+		ExpectedStmt: `WITH old AS (SELECT * FROM public.table1 WHERE table1.column2 = $1 FOR UPDATE) UPDATE public.table1 SET column1 = $2 FROM old WHERE table1.id = old.id AND table1.column2 = $3 RETURNING old.column1, table1.column1`,
+		Arguments:    []interface{}{"foo", "bar", "foo"},
+	},
+	{
+		Constructed: With("src", Select(Table2.ID, Table2.Column1).From(Table2)).
+			Update(Table1).OldValues(oldTable1, Table1.ID, Table1.Column2).Set(Table1.Column1, NewStringField(NewTable("", "src"), "column1")).
+			From(NewTable("", "src")).Where(Table1.Column3.Gt(Int64(1))).Returning(oldTable1.Column1),
+		ExpectedStmt: `WITH src AS (SELECT table2.id, table2.column1 FROM public.table2), old AS (SELECT * FROM public.table1 WHERE table1.column3 > $1 FOR UPDATE) UPDATE public.table1 SET column1 = src.column1 FROM old, src WHERE table1.id = old.id AND table1.column2 = old.column2 AND table1.column3 > $2 RETURNING old.column1`,
+	},
+	{
+		Constructed:  Update(Table1).OldValues(oldTable1, Table1.ID).Set(Table1.Column1, "bar").WhereCurrentOf("c"),
+		ExpectedStmt: `WITH old AS (SELECT * FROM public.table1 FOR UPDATE) UPDATE public.table1 SET column1 = $1 FROM old WHERE CURRENT OF c`,
+		Errors: []error{&UnsupportedClauseError{
+			Statement: "UPDATE ... WHERE CURRENT OF",
+			Clause:    "old values",
+		}},
+	},
+	{
+		Constructed:  Update(Table1).OldValues(oldTable1).Set(Table1.Column1, "bar"),
+		ExpectedStmt: `WITH old AS (SELECT * FROM public.table1 FOR UPDATE) UPDATE public.table1 SET column1 = $1 FROM old`,
+		Errors:       []error{&MissingOldValuesKeyError{Table: "old"}},
 	},
 }
 
+var (
+	aliasedTable1 = Table1.As("t").(*testTable)
+	oldTable1     = Table1.As("old").(*testTable)
+)
+
 func TestUpdate(t *testing.T) {
 	runTestCases(t, updateTestCases)
 }
+
+func TestDerivedUpdates(t *testing.T) {
+	base := Update(Table1).Set(Table1.Column1, "10").Where(Table1.Column2.Eq(String("foo")))
+	runTestCases(t, []TestCase{
+		{
+			Constructed:  base.AndWhere(Table1.Column3.Gt(Int64(1))),
+			ExpectedStmt: `UPDATE public.table1 SET column1 = $1 WHERE table1.column2 = $2 AND table1.column3 > $3`,
+		},
+		{
+			Constructed:  base.OrWhere(Table1.Column3.Gt(Int64(1))).Returning(Table1.ID),
+			ExpectedStmt: `UPDATE public.table1 SET column1 = $1 WHERE (table1.column2 = $2 OR table1.column3 > $3) RETURNING table1.id`,
+		},
+		{
+			Constructed:  base,
+			ExpectedStmt: `UPDATE public.table1 SET column1 = $1 WHERE table1.column2 = $2`,
+		},
+	})
+}
@@ -9,7 +9,10 @@ import (
 func ScanRow(
 	db DBInterface, stmt Fetchable, results interface{},
 ) error {
-	row := stmt.FetchRow(Postgres, db)
+	row, err := fetchRow(db, stmt)
+	if err != nil {
+		return err
+	}
 	return row.StructScan(results)
 }
 
@@ -27,7 +30,10 @@ func ScanRows(
 func ScanCount(
 	db DBInterface, stmt Fetchable,
 ) (int, error) {
-	row := stmt.FetchRow(Postgres, db)
+	row, err := fetchRow(db, stmt)
+	if err != nil {
+		return 0, err
+	}
 	count := 0
 	if err := row.Scan(&count); err != nil {
 		return 0, err
@@ -38,7 +44,10 @@ func ScanCount(
 func ScanRowWithContext(
 	ctx context.Context, db DBInterface, stmt Fetchable, results interface{},
 ) error {
-	row := stmt.FetchRowWithContext(ctx, Postgres, db)
+	row, err := fetchRowWithContext(ctx, db, stmt)
+	if err != nil {
+		return err
+	}
 	return row.StructScan(results)
 }
 
@@ -56,10 +65,33 @@ func ScanRowsWithContext(
 func ScanCountWithContext(
 	ctx context.Context, db DBInterface, stmt Fetchable,
 ) (int, error) {
-	row := stmt.FetchRowWithContext(ctx, Postgres, db)
+	row, err := fetchRowWithContext(ctx, db, stmt)
+	if err != nil {
+		return 0, err
+	}
 	count := 0
 	if err := row.Scan(&count); err != nil {
 		return 0, err
 	}
 	return count, nil
 }
+
+// fetchRow fetches the row of stmt, with the errors of building it when stmt
+// is a RowFetchable
+func fetchRow(
+	db DBInterface, stmt Fetchable,
+) (*sqlx.Row, error) {
+	if stmt, ok := stmt.(RowFetchable); ok {
+		return stmt.FetchOne(Postgres, db)
+	}
+	return stmt.FetchRow(Postgres, db), nil
+}
+
+func fetchRowWithContext(
+	ctx context.Context, db DBInterface, stmt Fetchable,
+) (*sqlx.Row, error) {
+	if stmt, ok := stmt.(RowFetchable); ok {
+		return stmt.FetchOneWithContext(ctx, Postgres, db)
+	}
+	return stmt.FetchRowWithContext(ctx, Postgres, db), nil
+}
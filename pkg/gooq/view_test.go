@@ -0,0 +1,91 @@
+package gooq
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testView struct {
+	ViewImpl
+	ID      UUIDField
+	Column1 StringField
+}
+
+func newTestView(name string) *testView {
+	instance := &testView{}
+	instance.ViewImpl.Initialize("public", name)
+	instance.ID = NewUUIDField(instance, "id")
+	instance.Column1 = NewStringField(instance, "column1")
+	return instance
+}
+
+func (v *testView) As(alias string) *testView {
+	instance := newTestView(v.GetName())
+	instance.ViewImpl = *instance.ViewImpl.As(alias)
+	return instance
+}
+
+type testMaterializedView struct {
+	MaterializedViewImpl
+	ID    UUIDField
+	Total IntField
+}
+
+func newTestMaterializedView(name string) *testMaterializedView {
+	instance := &testMaterializedView{}
+	instance.MaterializedViewImpl.Initialize("public", name)
+	instance.ID = NewUUIDField(instance, "id")
+	instance.Total = NewIntField(instance, "total")
+	return instance
+}
+
+var (
+	View1             = newTestView("view1")
+	MaterializedView1 = newTestMaterializedView("materialized_view1")
+)
+
+var viewTestCases = []TestCase{
+	{
+		Constructed:  Select(View1.Column1).From(View1).Where(View1.ID.Eq(Table1.ID)),
+		ExpectedStmt: "SELECT view1.column1 FROM public.view1 WHERE view1.id = table1.id",
+	},
+	{
+		Constructed: Select(Table1.Column1, MaterializedView1.Total).From(Table1).
+			Join(MaterializedView1).On(MaterializedView1.ID.Eq(Table1.ID)),
+		ExpectedStmt: "SELECT table1.column1, materialized_view1.total FROM public.table1 JOIN public.materialized_view1 ON materialized_view1.id = table1.id",
+	},
+	{
+		Constructed:  Select(View1.As("v").Column1).From(View1.As("v")),
+		ExpectedStmt: "SELECT v.column1 FROM public.view1 AS v",
+	},
+	{
+		Constructed:  RefreshMaterializedView(MaterializedView1),
+		ExpectedStmt: "REFRESH MATERIALIZED VIEW public.materialized_view1",
+	},
+	{
+		Constructed:  RefreshMaterializedView(MaterializedView1).Concurrently(),
+		ExpectedStmt: "REFRESH MATERIALIZED VIEW CONCURRENTLY public.materialized_view1",
+	},
+}
+
+func TestViews(t *testing.T) {
+	runTestCases(t, viewTestCases)
+	// views cannot be passed to InsertInto, Update, Delete or MergeInto
+	require.Implements(t, (*View)(nil), View1)
+	require.False(t, isTable(View1))
+	require.False(t, isTable(MaterializedView1))
+	require.True(t, isTable(Table1))
+}
+
+func isTable(relation View) bool {
+	_, ok := relation.(Table)
+	return ok
+}
+
+func TestRefreshMaterializedViewDialects(t *testing.T) {
+	builder := RefreshMaterializedView(MaterializedView1).Build(MySQL)
+	require.Equal(t, []error{&UnsupportedFeatureError{
+		Dialect: MySQL, Feature: DialectFeatureMaterializedView,
+	}}, builder.Errors())
+}
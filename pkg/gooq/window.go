@@ -0,0 +1,484 @@
+package gooq
+
+import (
+	"gopkg.in/guregu/null.v3"
+)
+
+// 4.2.8 Window Function Calls
+// https://www.postgresql.org/docs/12/sql-expressions.html#SYNTAX-WINDOW-FUNCTIONS
+
+type FrameMode string
+
+const (
+	FrameModeNil    = FrameMode("")
+	FrameModeRows   = FrameMode("ROWS")
+	FrameModeRange  = FrameMode("RANGE")
+	FrameModeGroups = FrameMode("GROUPS")
+)
+
+type FrameExclusion string
+
+const (
+	FrameExclusionNil        = FrameExclusion("")
+	FrameExclusionCurrentRow = FrameExclusion("EXCLUDE CURRENT ROW")
+	FrameExclusionGroup      = FrameExclusion("EXCLUDE GROUP")
+	FrameExclusionTies       = FrameExclusion("EXCLUDE TIES")
+	FrameExclusionNoOthers   = FrameExclusion("EXCLUDE NO OTHERS")
+)
+
+// FrameBound is the start or end of a window frame. The offset of
+// Preceding(...) and Following(...) must be an integer in ROWS and GROUPS
+// mode and may be an interval in RANGE mode.
+type FrameBound struct {
+	offset Expression
+	suffix string
+}
+
+var (
+	UnboundedPreceding = FrameBound{suffix: "UNBOUNDED PRECEDING"}
+	CurrentRow         = FrameBound{suffix: "CURRENT ROW"}
+	UnboundedFollowing = FrameBound{suffix: "UNBOUNDED FOLLOWING"}
+)
+
+func Preceding(offset Expression) FrameBound {
+	return FrameBound{offset: offset, suffix: "PRECEDING"}
+}
+
+func Following(offset Expression) FrameBound {
+	return FrameBound{offset: offset, suffix: "FOLLOWING"}
+}
+
+func (bound FrameBound) Render(
+	builder *Builder,
+) {
+	if bound.offset != nil {
+		builder.RenderExpression(bound.offset)
+		builder.Print(" ")
+	}
+	builder.Print(bound.suffix)
+}
+
+type WindowSpecification interface {
+	Renderable
+	PartitionBy(...Expression) WindowSpecification
+	OrderBy(...Expression) WindowSpecification
+	Rows(start FrameBound) WindowSpecification
+	RowsBetween(start, end FrameBound) WindowSpecification
+	Range(start FrameBound) WindowSpecification
+	RangeBetween(start, end FrameBound) WindowSpecification
+	Groups(start FrameBound) WindowSpecification
+	GroupsBetween(start, end FrameBound) WindowSpecification
+	Exclude(FrameExclusion) WindowSpecification
+}
+
+type windowSpecification struct {
+	existingWindow null.String
+	partitions     []Expression
+	ordering       []Expression
+	frameMode      FrameMode
+	frameStart     FrameBound
+	frameEnd       *FrameBound
+	frameExclusion FrameExclusion
+}
+
+// Window starts an anonymous window specification, e.g.
+// Sum(Table1.Column3).Over(Window().PartitionBy(Table1.Column1))
+func Window() WindowSpecification {
+	return &windowSpecification{}
+}
+
+// WindowFrom starts a window specification that copies the PARTITION BY
+// and ORDER BY clauses of a named window declared with Select(...).Window(...)
+func WindowFrom(name string) WindowSpecification {
+	return &windowSpecification{existingWindow: null.StringFrom(name)}
+}
+
+// clone returns a copy of w for a step to modify so that a specification
+// that is completed in different ways is never changed, see selection.clone
+func (w *windowSpecification) clone() *windowSpecification {
+	c := *w
+	return &c
+}
+
+func (w *windowSpecification) PartitionBy(e ...Expression) WindowSpecification {
+	c := w.clone()
+	c.partitions = e
+	return c
+}
+
+func (w *windowSpecification) OrderBy(e ...Expression) WindowSpecification {
+	c := w.clone()
+	c.ordering = e
+	return c
+}
+
+func (w *windowSpecification) Rows(start FrameBound) WindowSpecification {
+	return w.setFrame(FrameModeRows, start, nil)
+}
+
+func (w *windowSpecification) RowsBetween(start, end FrameBound) WindowSpecification {
+	return w.setFrame(FrameModeRows, start, &end)
+}
+
+func (w *windowSpecification) Range(start FrameBound) WindowSpecification {
+	return w.setFrame(FrameModeRange, start, nil)
+}
+
+func (w *windowSpecification) RangeBetween(start, end FrameBound) WindowSpecification {
+	return w.setFrame(FrameModeRange, start, &end)
+}
+
+func (w *windowSpecification) Groups(start FrameBound) WindowSpecification {
+	return w.setFrame(FrameModeGroups, start, nil)
+}
+
+func (w *windowSpecification) GroupsBetween(start, end FrameBound) WindowSpecification {
+	return w.setFrame(FrameModeGroups, start, &end)
+}
+
+func (w *windowSpecification) Exclude(exclusion FrameExclusion) WindowSpecification {
+	c := w.clone()
+	c.frameExclusion = exclusion
+	return c
+}
+
+func (w *windowSpecification) setFrame(
+	mode FrameMode, start FrameBound, end *FrameBound,
+) WindowSpecification {
+	c := w.clone()
+	c.frameMode = mode
+	c.frameStart = start
+	c.frameEnd = end
+	return c
+}
+
+// Render renders the window specification without the enclosing parentheses
+func (w *windowSpecification) Render(
+	builder *Builder,
+) {
+	var separator string
+	if w.existingWindow.Valid {
+		builder.Print(builder.QuoteIdentifier(w.existingWindow.String))
+		separator = " "
+	}
+
+	if len(w.partitions) > 0 {
+		builder.Printf("%sPARTITION BY ", separator)
+		builder.RenderExpressions(w.partitions)
+		separator = " "
+	}
+
+	if len(w.ordering) > 0 {
+		builder.Printf("%sORDER BY ", separator)
+		builder.RenderExpressions(w.ordering)
+		separator = " "
+	}
+
+	if w.frameMode == FrameModeNil {
+		return
+	}
+	if w.frameMode == FrameModeGroups {
+		builder.requireFeature(DialectFeatureWindowFrameGroups)
+	}
+	builder.Printf("%s%s ", separator, w.frameMode)
+	if w.frameEnd != nil {
+		builder.Print("BETWEEN ")
+		w.frameStart.Render(builder)
+		builder.Print(" AND ")
+		w.frameEnd.Render(builder)
+	} else {
+		w.frameStart.Render(builder)
+	}
+	if w.frameExclusion != FrameExclusionNil &&
+		builder.requireFeature(DialectFeatureWindowFrameExclusion) {
+		builder.Printf(" %s", w.frameExclusion)
+	}
+}
+
+// namedWindow is an entry of the WINDOW clause of a SELECT statement
+type namedWindow struct {
+	name          string
+	specification WindowSpecification
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Window function call
+///////////////////////////////////////////////////////////////////////////////
+
+// windowCall is the OVER clause of a window function call, it is shared by the
+// untyped and the typed window function calls
+type windowCall struct {
+	expression     Expression
+	windowName     null.String
+	specifications []WindowSpecification
+}
+
+func (call *windowCall) render(
+	builder *Builder,
+) {
+	builder.RenderExpression(call.expression)
+	if call.windowName.Valid {
+		builder.Printf(" OVER %s", builder.QuoteIdentifier(call.windowName.String))
+		return
+	}
+	if len(call.specifications) > 1 {
+		builder.errors = append(builder.errors, &ArgumentCountError{
+			Function: "OVER", Max: 1, Actual: len(call.specifications),
+		})
+	}
+	builder.Print(" OVER (")
+	if len(call.specifications) > 0 && call.specifications[0] != nil {
+		call.specifications[0].Render(builder)
+	}
+	builder.Print(")")
+}
+
+type overFunction struct {
+	expressionImpl
+	call windowCall
+}
+
+func newOverFunction(
+	expression Expression, specifications []WindowSpecification,
+) Expression {
+	function := &overFunction{call: windowCall{
+		expression: expression, specifications: specifications,
+	}}
+	function.expressionImpl.initFunctionExpression(function)
+	return function
+}
+
+func newOverWindowFunction(
+	expression Expression, name string,
+) Expression {
+	function := &overFunction{call: windowCall{
+		expression: expression, windowName: null.StringFrom(name),
+	}}
+	function.expressionImpl.initFunctionExpression(function)
+	return function
+}
+
+func (expr *overFunction) Render(
+	builder *Builder,
+) {
+	expr.call.render(builder)
+}
+
+// NumericWindowFunction is a window function with a numeric result, e.g.
+// RowNumber(). It is only valid with an OVER clause, which keeps the numeric
+// type of the result, e.g. RowNumber().Over().Lte(Int64(3))
+type NumericWindowFunction interface {
+	// Over() renders OVER () and Over(Window()...) renders OVER (...), it
+	// takes at most one window specification
+	Over(...WindowSpecification) NumericExpression
+	OverWindow(name string) NumericExpression
+}
+
+type numericWindowFunction struct {
+	function NumericExpression
+}
+
+func newNumericWindowFunction(
+	name string, arguments ...Expression,
+) NumericWindowFunction {
+	return &numericWindowFunction{
+		function: NewNumericExpressionFunction(name, arguments...),
+	}
+}
+
+func (f *numericWindowFunction) Over(
+	specifications ...WindowSpecification,
+) NumericExpression {
+	return newNumericOverFunction(windowCall{
+		expression: f.function, specifications: specifications,
+	})
+}
+
+func (f *numericWindowFunction) OverWindow(
+	name string,
+) NumericExpression {
+	return newNumericOverFunction(windowCall{
+		expression: f.function, windowName: null.StringFrom(name),
+	})
+}
+
+type numericOverFunction struct {
+	numericExpressionImpl
+	call windowCall
+}
+
+func newNumericOverFunction(
+	call windowCall,
+) NumericExpression {
+	function := &numericOverFunction{call: call}
+	function.expressionImpl.initFunctionExpression(function)
+	return function
+}
+
+func (expr *numericOverFunction) Render(
+	builder *Builder,
+) {
+	expr.call.render(builder)
+}
+
+// BoolWindowFunction is a window function with a boolean result, e.g.
+// LagBool(value). The OVER clause keeps the type of the value
+type BoolWindowFunction interface {
+	Over(...WindowSpecification) BoolExpression
+	OverWindow(name string) BoolExpression
+}
+
+type boolWindowFunction struct {
+	function Expression
+}
+
+func newBoolWindowFunction(
+	name string, arguments ...Expression,
+) BoolWindowFunction {
+	return &boolWindowFunction{
+		function: NewExpressionFunction(name, arguments...),
+	}
+}
+
+func (f *boolWindowFunction) Over(
+	specifications ...WindowSpecification,
+) BoolExpression {
+	return newBoolOverFunction(windowCall{
+		expression: f.function, specifications: specifications,
+	})
+}
+
+func (f *boolWindowFunction) OverWindow(
+	name string,
+) BoolExpression {
+	return newBoolOverFunction(windowCall{
+		expression: f.function, windowName: null.StringFrom(name),
+	})
+}
+
+type boolOverFunction struct {
+	boolExpressionImpl
+	call windowCall
+}
+
+func newBoolOverFunction(
+	call windowCall,
+) BoolExpression {
+	function := &boolOverFunction{call: call}
+	function.expressionImpl.initFunctionExpression(function)
+	return function
+}
+
+func (expr *boolOverFunction) Render(
+	builder *Builder,
+) {
+	expr.call.render(builder)
+}
+
+// DateTimeWindowFunction is a window function with a date/time result, e.g.
+// LagDateTime(value). The OVER clause keeps the type of the value
+type DateTimeWindowFunction interface {
+	Over(...WindowSpecification) DateTimeExpression
+	OverWindow(name string) DateTimeExpression
+}
+
+type dateTimeWindowFunction struct {
+	function Expression
+}
+
+func newDateTimeWindowFunction(
+	name string, arguments ...Expression,
+) DateTimeWindowFunction {
+	return &dateTimeWindowFunction{
+		function: NewExpressionFunction(name, arguments...),
+	}
+}
+
+func (f *dateTimeWindowFunction) Over(
+	specifications ...WindowSpecification,
+) DateTimeExpression {
+	return newDateTimeOverFunction(windowCall{
+		expression: f.function, specifications: specifications,
+	})
+}
+
+func (f *dateTimeWindowFunction) OverWindow(
+	name string,
+) DateTimeExpression {
+	return newDateTimeOverFunction(windowCall{
+		expression: f.function, windowName: null.StringFrom(name),
+	})
+}
+
+type dateTimeOverFunction struct {
+	dateTimeExpressionImpl
+	call windowCall
+}
+
+func newDateTimeOverFunction(
+	call windowCall,
+) DateTimeExpression {
+	function := &dateTimeOverFunction{call: call}
+	function.expressionImpl.initFunctionExpression(function)
+	return function
+}
+
+func (expr *dateTimeOverFunction) Render(
+	builder *Builder,
+) {
+	expr.call.render(builder)
+}
+
+// StringWindowFunction is a window function with a string result, e.g.
+// LagString(value). The OVER clause keeps the type of the value
+type StringWindowFunction interface {
+	Over(...WindowSpecification) StringExpression
+	OverWindow(name string) StringExpression
+}
+
+type stringWindowFunction struct {
+	function Expression
+}
+
+func newStringWindowFunction(
+	name string, arguments ...Expression,
+) StringWindowFunction {
+	return &stringWindowFunction{
+		function: NewExpressionFunction(name, arguments...),
+	}
+}
+
+func (f *stringWindowFunction) Over(
+	specifications ...WindowSpecification,
+) StringExpression {
+	return newStringOverFunction(windowCall{
+		expression: f.function, specifications: specifications,
+	})
+}
+
+func (f *stringWindowFunction) OverWindow(
+	name string,
+) StringExpression {
+	return newStringOverFunction(windowCall{
+		expression: f.function, windowName: null.StringFrom(name),
+	})
+}
+
+type stringOverFunction struct {
+	stringExpressionImpl
+	call windowCall
+}
+
+func newStringOverFunction(
+	call windowCall,
+) StringExpression {
+	function := &stringOverFunction{call: call}
+	function.expressionImpl.initFunctionExpression(function)
+	return function
+}
+
+func (expr *stringOverFunction) Render(
+	builder *Builder,
+) {
+	expr.call.render(builder)
+}
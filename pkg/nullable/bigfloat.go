@@ -39,7 +39,10 @@ func (b *BigFloat) Scan(v interface{}) error {
 
 // Value implements the driver Valuer interface.
 func (b BigFloat) Value() (driver.Value, error) {
-	return b.Value()
+	if !b.Valid {
+		return nil, nil
+	}
+	return b.BigFloat.Text('f', -1), nil
 }
 
 // MarshalText implements encoding.TextMarshaler.
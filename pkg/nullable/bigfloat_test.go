@@ -13,3 +13,15 @@ func TestBigFloatScan(t *testing.T) {
 	bigFloat := nullable.BigFloatFrom(*b)
 	require.True(t, bigFloat.Valid)
 }
+
+func TestBigFloatValue(t *testing.T) {
+	b, _, err := big.ParseFloat("12345.678", 10, 64, big.ToNearestEven)
+	require.NoError(t, err)
+	value, err := nullable.BigFloatFrom(*b).Value()
+	require.NoError(t, err)
+	require.Equal(t, "12345.678", value)
+
+	value, err = nullable.BigFloat{}.Value()
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
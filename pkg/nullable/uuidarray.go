@@ -0,0 +1,76 @@
+package nullable
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type UUIDArray struct {
+	UUIDArray []uuid.UUID
+	Valid     bool // Valid is true if UUIDArray is not NULL
+}
+
+// UUIDArrayFrom creates a new UUIDArray that will never be blank.
+func UUIDArrayFrom(value []uuid.UUID) UUIDArray {
+	uuidArray := UUIDArray{
+		Valid: value != nil,
+	}
+	if value != nil {
+		uuidArray.UUIDArray = make([]uuid.UUID, len(value))
+		copy(uuidArray.UUIDArray, value)
+	}
+	return uuidArray
+}
+
+func (self UUIDArray) MarshalText() ([]byte, error) {
+	if !self.Valid {
+		return []byte{}, nil
+	}
+	bytes, err := json.Marshal(self.UUIDArray)
+	if err != nil {
+		return nil, err
+	}
+	return bytes, nil
+}
+
+func (self *UUIDArray) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		self.UUIDArray = nil
+		self.Valid = false
+		return nil
+	}
+	arr := make([]uuid.UUID, 0)
+	if err := json.Unmarshal(data, &arr); err != nil {
+		return err
+	}
+	self.Valid = true
+	self.UUIDArray = arr
+	return nil
+}
+
+// Scan implements the Scanner interface.
+func (self *UUIDArray) Scan(v interface{}) error {
+	if v == nil {
+		self.UUIDArray = nil
+		self.Valid = false
+		return nil
+	}
+	arr := make([]uuid.UUID, 0)
+	err := pq.GenericArray{A: &arr}.Scan(v)
+	if err == nil {
+		self.Valid = true
+		self.UUIDArray = arr
+	}
+	return err
+}
+
+// Value implements the driver Valuer interface.
+func (self UUIDArray) Value() (driver.Value, error) {
+	if !self.Valid {
+		return nil, nil
+	}
+	return pq.GenericArray{A: self.UUIDArray}.Value()
+}
@@ -0,0 +1,54 @@
+package nullable_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lumina-tech/gooq/pkg/nullable"
+)
+
+var (
+	uuid1 = uuid.MustParse("3b241101-e2bb-4255-8caf-4136c566a962")
+	uuid2 = uuid.MustParse("7d444840-9dc0-11d1-b245-5ffdce74fad2")
+)
+
+func TestUUIDArrayScan(t *testing.T) {
+	uuidArray := nullable.UUIDArrayFrom(nil)
+	require.False(t, uuidArray.Valid)
+	require.Nil(t, uuidArray.UUIDArray)
+
+	bytes := []byte("{\"3b241101-e2bb-4255-8caf-4136c566a962\",\"7d444840-9dc0-11d1-b245-5ffdce74fad2\"}")
+	err := uuidArray.Scan(bytes)
+	require.NoError(t, err)
+	require.True(t, uuidArray.Valid)
+	require.Equal(t, []uuid.UUID{uuid1, uuid2}, uuidArray.UUIDArray)
+
+	value, err := uuidArray.Value()
+	require.NoError(t, err)
+	require.Equal(t, string(bytes), value)
+
+	err = uuidArray.Scan(nil)
+	require.NoError(t, err)
+	require.False(t, uuidArray.Valid)
+}
+
+func TestUUIDArrayMarshalText(t *testing.T) {
+	uuidArray := nullable.UUIDArrayFrom([]uuid.UUID{uuid1})
+
+	err := uuidArray.UnmarshalText(nil)
+	require.NoError(t, err)
+	require.False(t, uuidArray.Valid)
+	require.Nil(t, uuidArray.UUIDArray)
+
+	text := []byte("[\"3b241101-e2bb-4255-8caf-4136c566a962\",\"7d444840-9dc0-11d1-b245-5ffdce74fad2\"]")
+	err = uuidArray.UnmarshalText(text)
+	require.NoError(t, err)
+	require.True(t, uuidArray.Valid)
+	require.Equal(t, []uuid.UUID{uuid1, uuid2}, uuidArray.UUIDArray)
+
+	marshalled, err := uuidArray.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, text, marshalled)
+}